@@ -51,29 +51,13 @@ func (c *Config) Client() (*nr.NewRelic, error) {
 		nr.ConfigRegion(c.Region),
 	)
 
-	tlsCfg := &tls.Config{}
-	var t = http.DefaultTransport
-
-	if c.CACertFile != "" {
-		caCert, _, err := read(c.CACertFile)
-		if err != nil {
-			log.Printf("Error reading CA Cert: %s", err)
-			return nil, err
-		}
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM([]byte(caCert))
-		tlsCfg.RootCAs = caCertPool
-
-		t = &http.Transport{TLSClientConfig: tlsCfg}
-	} else if c.InsecureSkipVerify {
-		tlsCfg.InsecureSkipVerify = true
-
-		t = &http.Transport{TLSClientConfig: tlsCfg}
+	t, err := c.HTTPTransport()
+	if err != nil {
+		return nil, err
 	}
 
 	if logging.LogLevel() != "" {
 		options = append(options, nr.ConfigLogLevel(logging.LogLevel()))
-		t = logging.NewTransport("newrelic", t)
 	}
 
 	options = append(options, nr.ConfigHTTPTransport(t))
@@ -105,6 +89,35 @@ func (c *Config) Client() (*nr.NewRelic, error) {
 	return client, nil
 }
 
+// HTTPTransport returns the transport of the requests to New Relic, honoring the TLS settings
+func (c *Config) HTTPTransport() (http.RoundTripper, error) {
+	tlsCfg := &tls.Config{}
+	var t = http.DefaultTransport
+
+	if c.CACertFile != "" {
+		caCert, _, err := read(c.CACertFile)
+		if err != nil {
+			log.Printf("Error reading CA Cert: %s", err)
+			return nil, err
+		}
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM([]byte(caCert))
+		tlsCfg.RootCAs = caCertPool
+
+		t = &http.Transport{TLSClientConfig: tlsCfg}
+	} else if c.InsecureSkipVerify {
+		tlsCfg.InsecureSkipVerify = true
+
+		t = &http.Transport{TLSClientConfig: tlsCfg}
+	}
+
+	if logging.LogLevel() != "" {
+		t = logging.NewTransport("newrelic", t)
+	}
+
+	return t, nil
+}
+
 // ClientInsightsInsert returns a new Insights insert client
 func (c *Config) ClientInsightsInsert() (*insights.InsertClient, error) {
 	client := insights.NewInsertClient(c.InsightsInsertKey, c.InsightsAccountID)
@@ -137,7 +150,10 @@ type ProviderConfig struct {
 	InsightsInsertClient *insights.InsertClient
 	AccountID            int
 	PersonalAPIKey       string
+	Region               string
 	userAgent            string
+	httpClient           *http.Client
+	insightsInsertKey    string
 }
 
 func (p *ProviderConfig) GetUserAgent() string {
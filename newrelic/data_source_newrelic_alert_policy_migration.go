@@ -0,0 +1,233 @@
+package newrelic
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+)
+
+func dataSourceNewRelicAlertPolicyMigration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicAlertPolicyMigrationRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The New Relic account ID of the alert policy.",
+			},
+			"policy_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The ID of the alert policy whose legacy conditions and channels are migrated.",
+			},
+			"hcl": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The configuration of the NRQL conditions, notification destinations and channels, and workflow replacing the legacy resources.",
+			},
+			"condition": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The legacy conditions translated into NRQL conditions.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the legacy resource, newrelic_alert_condition or newrelic_infra_alert_condition.",
+						},
+						"source_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the legacy condition.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the condition.",
+						},
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the newrelic_nrql_alert_condition resource in the generated configuration.",
+						},
+						"query": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The NRQL query of the condition.",
+						},
+						"exact": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the condition is translated exactly, with no entry in the report.",
+						},
+					},
+				},
+			},
+			"channel": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The legacy channels translated into notification destinations and channels.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the legacy channel.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the channel.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the legacy channel.",
+						},
+						"destination_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the notification destination.",
+						},
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the newrelic_notification_destination and newrelic_notification_channel resources in the generated configuration.",
+						},
+						"exact": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the channel is translated exactly, with no entry in the report.",
+						},
+					},
+				},
+			},
+			"report": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The legacy resources which can't be translated, or not exactly, and what to do about them.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the legacy resource.",
+						},
+						"source_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the legacy resource.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the legacy resource.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "What can't be translated.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicAlertPolicyMigrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	updatedContext := updateContextWithAccountID(ctx, accountID)
+
+	policyID := d.Get("policy_id").(int)
+
+	log.Printf("[INFO] Reading the legacy conditions and channels of New Relic alert policy %d", policyID)
+
+	policy, err := client.Alerts.GetPolicyWithContext(updatedContext, policyID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	conditions, err := client.Alerts.ListConditionsWithContext(updatedContext, policyID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	infraConditions, err := client.Alerts.ListInfrastructureConditionsWithContext(updatedContext, policyID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channels, err := client.Alerts.ListChannelsWithContext(updatedContext)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	m := migrateAlertPolicy(policy, conditions, infraConditions, channels)
+
+	d.SetId(strconv.Itoa(policyID))
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("hcl", m.hcl()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("condition", m.conditions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("channel", m.channels); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("report", m.report))
+}
+
+// migrateAlertPolicy translates the legacy conditions of the policy and the legacy channels linked to it.
+func migrateAlertPolicy(policy *alerts.Policy, conditions []*alerts.Condition, infraConditions []alerts.InfrastructureCondition, channels []*alerts.Channel) *alertMigration {
+	m := newAlertMigration(policy.ID, policy.Name)
+
+	for _, c := range conditions {
+		translated, reason := translateAlertCondition(c)
+		if translated == nil {
+			m.note(alertMigrationConditionType, c.ID, c.Name, reason)
+			continue
+		}
+		m.addCondition(translated)
+	}
+
+	for _, c := range infraConditions {
+		translated, reason := translateInfraAlertCondition(c)
+		if translated == nil {
+			m.note(alertMigrationInfraConditionType, c.ID, c.Name, reason)
+			continue
+		}
+		m.addCondition(translated)
+	}
+
+	linked := 0
+	for _, c := range channels {
+		for _, id := range c.Links.PolicyIDs {
+			if id == policy.ID {
+				linked++
+				m.addChannel(c)
+				break
+			}
+		}
+	}
+
+	if linked > 0 && len(m.workflowChannels) == 0 {
+		m.note(alertMigrationPolicyType, policy.ID, policy.Name, "none of the channels of the policy can be translated, so no workflow is generated")
+	}
+	m.addWorkflow()
+
+	return m
+}
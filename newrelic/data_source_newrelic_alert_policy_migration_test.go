@@ -0,0 +1,103 @@
+//go:build integration || ALERTS_DEPRECATED
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicAlertPolicyMigrationDataSource_Basic(t *testing.T) {
+	rName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicAlertPolicyMigrationDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.newrelic_alert_policy_migration.foo", "condition.#", "2"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_policy_migration.foo", "channel.#", "1"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_policy_migration.foo", "channel.0.destination_type", "EMAIL"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_policy_migration.foo", "report.#", "1"),
+					resource.TestMatchResourceAttr("data.newrelic_alert_policy_migration.foo", "hcl", regexp.MustCompile(`resource "newrelic_workflow"`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertPolicyMigrationDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+data "newrelic_application" "app" {
+  name = "%[2]s"
+}
+
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_alert_condition" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  name            = "tf-test-%[1]s-apdex"
+  type            = "apm_app_metric"
+  entities        = [data.newrelic_application.app.id]
+  metric          = "apdex"
+  condition_scope = "application"
+
+  term {
+    duration      = 5
+    operator      = "below"
+    priority      = "critical"
+    threshold     = "0.75"
+    time_function = "all"
+  }
+}
+
+resource "newrelic_infra_alert_condition" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  name       = "tf-test-%[1]s-disk"
+  type       = "infra_metric"
+  event      = "StorageSample"
+  select     = "diskUsedPercent"
+  comparison = "above"
+
+  critical {
+    duration      = 25
+    value         = 90
+    time_function = "all"
+  }
+}
+
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-%[1]s"
+  type = "email"
+
+  config {
+    recipients = "terraform-acctest+foo@hashicorp.com"
+  }
+}
+
+resource "newrelic_alert_policy_channel" "foo" {
+  policy_id   = newrelic_alert_policy.foo.id
+  channel_ids = [newrelic_alert_channel.foo.id]
+}
+
+data "newrelic_alert_policy_migration" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  depends_on = [
+    newrelic_alert_condition.foo,
+    newrelic_infra_alert_condition.foo,
+    newrelic_alert_policy_channel.foo,
+  ]
+}
+`, name, testAccExpectedApplicationName)
+}
@@ -0,0 +1,199 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+	"github.com/stretchr/testify/require"
+)
+
+func testAlertMigrationFloat(f float64) *float64 {
+	return &f
+}
+
+func TestTranslateAlertCondition(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		condition alerts.Condition
+		query     string
+		notes     int
+		reason    string
+	}{
+		"error percentage": {
+			condition: alerts.Condition{Type: "apm_app_metric", Metric: "error_percentage", Entities: []string{"123", "456"}, Scope: "application"},
+			query:     "SELECT percentage(count(*), WHERE error IS true) FROM Transaction WHERE appId IN (123, 456) FACET appName",
+		},
+		"web response time per instance": {
+			condition: alerts.Condition{Type: "apm_app_metric", Metric: "response_time_web", Entities: []string{"123"}, Scope: "instance"},
+			query:     "SELECT average(duration) FROM Transaction WHERE transactionType = 'Web' AND appId IN (123) FACET host",
+		},
+		"apdex": {
+			condition: alerts.Condition{Type: "apm_app_metric", Metric: "apdex", Entities: []string{"123"}},
+			query:     "SELECT apdex(duration, t: 0.5) FROM Transaction WHERE appId IN (123) FACET appName",
+			notes:     1,
+		},
+		"user defined": {
+			condition: alerts.Condition{
+				Type:        "apm_app_metric",
+				Metric:      "user_defined",
+				Entities:    []string{"123"},
+				UserDefined: alerts.ConditionUserDefined{Metric: "Custom/Owner's/Queue", ValueFunction: "total"},
+			},
+			query: `SELECT sum(newrelic.timeslice.value) FROM Metric WHERE metricTimesliceName = 'Custom/Owner\'s/Queue' AND appId IN (123) FACET appName`,
+		},
+		"user defined rate": {
+			condition: alerts.Condition{Type: "apm_app_metric", Metric: "user_defined", UserDefined: alerts.ConditionUserDefined{Metric: "Custom/Queue", ValueFunction: "rate"}},
+			reason:    "the value function rate of user defined metrics can't be translated into NRQL, create the NRQL condition by hand",
+		},
+		"browser": {
+			condition: alerts.Condition{Type: "browser_metric", Metric: "end_user_apdex"},
+			reason:    "conditions of type browser_metric can't be translated into NRQL, create the NRQL condition by hand",
+		},
+	}
+
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			translated, reason := translateAlertCondition(&c.condition)
+			if c.reason != "" {
+				require.Nil(t, translated)
+				require.Equal(t, c.reason, reason)
+				return
+			}
+
+			require.NotNil(t, translated)
+			require.Equal(t, c.query, translated.query)
+			require.Len(t, translated.notes, c.notes)
+		})
+	}
+}
+
+func TestTranslateInfraAlertCondition(t *testing.T) {
+	t.Parallel()
+
+	translated, _ := translateInfraAlertCondition(alerts.InfrastructureCondition{
+		Type:       "infra_metric",
+		Event:      "StorageSample",
+		Select:     "diskUsedPercent",
+		Comparison: "above",
+		Where:      "hostname LIKE '%frontend%'",
+		Warning:    &alerts.InfrastructureConditionThreshold{Duration: 10, Value: testAlertMigrationFloat(80), Function: "all"},
+		Critical:   &alerts.InfrastructureConditionThreshold{Duration: 25, Value: testAlertMigrationFloat(90), Function: "any"},
+	})
+	require.Equal(t, "SELECT average(diskUsedPercent) FROM StorageSample WHERE (hostname LIKE '%frontend%') FACET entityAndMountPoint, mountPoint", translated.query)
+	require.Equal(t, []alertMigrationThreshold{
+		{priority: "critical", operator: "above", value: 90, minutes: 25, timeFunction: "any"},
+		{priority: "warning", operator: "above", value: 80, minutes: 10, timeFunction: "all"},
+	}, translated.thresholds)
+	require.Empty(t, translated.notes)
+
+	translated, _ = translateInfraAlertCondition(alerts.InfrastructureCondition{
+		Type:         "infra_process_running",
+		Comparison:   "equal",
+		Where:        "hostname = 'web01'",
+		ProcessWhere: "commandName = '/usr/bin/ruby'",
+		Critical:     &alerts.InfrastructureConditionThreshold{Duration: 5, Value: testAlertMigrationFloat(0)},
+	})
+	require.Equal(t, "SELECT filter(uniqueCount(processId), WHERE commandName = '/usr/bin/ruby') FROM ProcessSample WHERE hostname IS NOT NULL AND (hostname = 'web01') FACET entityGuid", translated.query)
+	require.Equal(t, "equals", translated.thresholds[0].operator)
+
+	translated, _ = translateInfraAlertCondition(alerts.InfrastructureCondition{
+		Type:     "infra_host_not_responding",
+		Critical: &alerts.InfrastructureConditionThreshold{Duration: 5},
+	})
+	require.Equal(t, "SELECT count(`host.cpuPercent`) FROM Metric WHERE metricName = 'host.cpuPercent' AND host.hostname IS NOT NULL FACET entity.guid", translated.query)
+	require.Equal(t, 5, translated.lossOfSignalMinutes)
+
+	translated, reason := translateInfraAlertCondition(alerts.InfrastructureCondition{Type: "infra_unknown"})
+	require.Nil(t, translated)
+	require.Equal(t, "conditions of type infra_unknown can't be translated into NRQL, create the NRQL condition by hand", reason)
+}
+
+func TestMigrateAlertPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := &alerts.Policy{ID: 42, Name: "Checkout"}
+	conditions := []*alerts.Condition{
+		{
+			ID:                  1,
+			Type:                "apm_app_metric",
+			Name:                "High error rate",
+			Enabled:             true,
+			Metric:              "error_percentage",
+			Entities:            []string{"123"},
+			RunbookURL:          "https://example.com/runbook",
+			ViolationCloseTimer: 24,
+			Terms: []alerts.ConditionTerm{
+				{Priority: "critical", Operator: "above", Threshold: 5, Duration: 5, TimeFunction: "all"},
+			},
+		},
+		{ID: 2, Type: "mobile_metric", Name: "Crashes", Metric: "mobile_crash_rate"},
+	}
+	infraConditions := []alerts.InfrastructureCondition{
+		{ID: 3, Type: "infra_host_not_responding", Name: "High error rate", Critical: &alerts.InfrastructureConditionThreshold{Duration: 5}},
+	}
+	channels := []*alerts.Channel{
+		{ID: 10, Name: "Team email", Type: "email", Configuration: alerts.ChannelConfiguration{Recipients: "team@example.com"}, Links: alerts.ChannelLinks{PolicyIDs: []int{42}}},
+		{ID: 11, Name: "PagerDuty", Type: "pagerduty", Links: alerts.ChannelLinks{PolicyIDs: []int{7, 42}}},
+		{ID: 12, Name: "Ops room", Type: "slack", Links: alerts.ChannelLinks{PolicyIDs: []int{42}}},
+		{ID: 13, Name: "Other team", Type: "email", Links: alerts.ChannelLinks{PolicyIDs: []int{7}}},
+	}
+
+	m := migrateAlertPolicy(policy, conditions, infraConditions, channels)
+
+	_, diags := hclwrite.ParseConfig([]byte(m.hcl()), "migration.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	require.Len(t, m.conditions, 2)
+	require.Equal(t, "high_error_rate", m.conditions[0].(map[string]interface{})["resource_name"])
+	require.Equal(t, "high_error_rate_2", m.conditions[1].(map[string]interface{})["resource_name"])
+
+	require.Len(t, m.channels, 2)
+	require.Equal(t, "team_email", m.channels[0].(map[string]interface{})["resource_name"])
+	require.Equal(t, "pagerduty", m.channels[1].(map[string]interface{})["resource_name"])
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"source_type": "newrelic_alert_condition",
+			"source_id":   2,
+			"name":        "Crashes",
+			"message":     "conditions of type mobile_metric can't be translated into NRQL, create the NRQL condition by hand",
+		},
+		map[string]interface{}{
+			"source_type": "newrelic_alert_channel",
+			"source_id":   12,
+			"name":        "Ops room",
+			"message":     "Slack destinations can't be created by Terraform, connect Slack in New Relic, then import the destination and add a SLACK notification channel to the workflow",
+		},
+	}, m.report)
+
+	config := m.hcl()
+	require.Contains(t, config, `resource "newrelic_nrql_alert_condition" "high_error_rate" {`)
+	require.Contains(t, config, `violation_time_limit_seconds = 86400`)
+	require.Contains(t, config, `threshold_duration    = 300`)
+	require.Contains(t, config, `variable "pagerduty_service_key" {`)
+	require.Contains(t, config, `token  = var.pagerduty_service_key`)
+	require.Contains(t, config, `destination_id = newrelic_notification_destination.team_email.id`)
+	require.Contains(t, config, `values    = ["42"]`)
+	require.Contains(t, config, `channel_id = newrelic_notification_channel.pagerduty.id`)
+	require.NotContains(t, config, "other_team")
+}
+
+func TestMigrateAlertPolicy_NoTranslatableChannel(t *testing.T) {
+	t.Parallel()
+
+	m := migrateAlertPolicy(&alerts.Policy{ID: 42, Name: "Checkout"}, nil, nil, []*alerts.Channel{
+		{ID: 12, Name: "Ops room", Type: "slack", Links: alerts.ChannelLinks{PolicyIDs: []int{42}}},
+	})
+
+	require.NotContains(t, m.hcl(), "newrelic_workflow")
+	require.Len(t, m.report, 2)
+	require.Equal(t, "newrelic_alert_policy", m.report[1].(map[string]interface{})["source_type"])
+}
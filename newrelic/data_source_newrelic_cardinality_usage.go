@@ -0,0 +1,229 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrdb"
+)
+
+// Cardinality is counted, and the limits enforced, per UTC day.
+const (
+	cardinalityUsageQuery  = "SELECT cardinality() FROM Metric FACET metricName SINCE today LIMIT %d"
+	cardinalityBreachQuery = "SELECT earliest(timestamp), latest(timestamp) FROM NrIntegrationError WHERE limitName = %s FACET metricName SINCE today LIMIT MAX"
+)
+
+func dataSourceNewRelicCardinalityUsage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicCardinalityUsageRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The ID of the account in New Relic.",
+			},
+			"limits": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The per-metric limits of the account by metric name, e.g. the limits of newrelic_cardinality_limits. They can't be read from New Relic.",
+			},
+			"top": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      20,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "The number of metrics with the highest cardinality to list, in addition to the ones which breached their limit.",
+			},
+			"default_limit": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The account-wide limit, which applies to the metrics without a per-metric limit.",
+			},
+			"metrics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The cardinality of the metrics today, by decreasing usage of their limit.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the metric.",
+						},
+						"cardinality": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of unique dimension-value combinations of the metric today.",
+						},
+						"limit": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The limit of the metric.",
+						},
+						"usage_percent": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The cardinality of the metric, in percent of its limit.",
+						},
+						"breached": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the metric reached its limit today.",
+						},
+						"first_breached_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When data of the metric was first dropped today because of its limit, in RFC3339 format.",
+						},
+						"last_breached_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When data of the metric was last dropped because of its limit, in RFC3339 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicCardinalityUsageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading the cardinality usage of account %d", accountID)
+
+	defaultLimit := cardinalityLimitPlatformDefault
+	limits, err := client.DataManagement.GetLimitsWithContext(ctx, accountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if limits != nil {
+		for _, l := range *limits {
+			if l.Name == cardinalityLimitName {
+				defaultLimit = l.Value
+				break
+			}
+		}
+	}
+
+	usage, err := client.Nrdb.QueryWithContext(ctx, accountID, nrdb.NRQL(fmt.Sprintf(cardinalityUsageQuery, d.Get("top").(int))))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	breaches, err := client.Nrdb.QueryWithContext(ctx, accountID, nrdb.NRQL(fmt.Sprintf(cardinalityBreachQuery, nrqlQuote(cardinalityLimitName))))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metrics := flattenCardinalityUsage(usage.Results, breaches.Results, defaultLimit, d.Get("limits").(map[string]interface{}))
+
+	d.SetId(strconv.Itoa(accountID))
+
+	if err := d.Set("default_limit", defaultLimit); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("metrics", metrics))
+}
+
+// flattenCardinalityUsage lists the metrics of the results of cardinalityUsageQuery and cardinalityBreachQuery,
+// with the limits by metric name, or the default limit, sorted by decreasing usage of their limit.
+func flattenCardinalityUsage(usage []nrdb.NRDBResult, breaches []nrdb.NRDBResult, defaultLimit int, limits map[string]interface{}) []interface{} {
+	metrics := map[string]map[string]interface{}{}
+
+	metric := func(name string) map[string]interface{} {
+		if m, ok := metrics[name]; ok {
+			return m
+		}
+
+		limit := defaultLimit
+		if l, ok := limits[name]; ok {
+			limit = l.(int)
+		}
+
+		m := map[string]interface{}{
+			"name":              name,
+			"cardinality":       0,
+			"limit":             limit,
+			"usage_percent":     0.0,
+			"breached":          false,
+			"first_breached_at": "",
+			"last_breached_at":  "",
+		}
+		metrics[name] = m
+		return m
+	}
+
+	for _, r := range usage {
+		name := cardinalityUsageMetricName(r)
+		if name == "" {
+			continue
+		}
+		cardinality, _ := r["cardinality"].(float64)
+
+		m := metric(name)
+		m["cardinality"] = int(cardinality)
+	}
+
+	for _, r := range breaches {
+		name := cardinalityUsageMetricName(r)
+		if name == "" {
+			continue
+		}
+		first, _ := r["earliest.timestamp"].(float64)
+		last, _ := r["latest.timestamp"].(float64)
+
+		m := metric(name)
+		m["breached"] = true
+		if first > 0 {
+			m["first_breached_at"] = time.UnixMilli(int64(first)).UTC().Format(time.RFC3339)
+		}
+		if last > 0 {
+			m["last_breached_at"] = time.UnixMilli(int64(last)).UTC().Format(time.RFC3339)
+		}
+	}
+
+	out := make([]interface{}, 0, len(metrics))
+	for _, m := range metrics {
+		if limit := m["limit"].(int); limit > 0 {
+			m["usage_percent"] = float64(m["cardinality"].(int)) * 100 / float64(limit)
+			if m["cardinality"].(int) >= limit {
+				m["breached"] = true
+			}
+		}
+		out = append(out, m)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].(map[string]interface{}), out[j].(map[string]interface{})
+		if a["usage_percent"].(float64) != b["usage_percent"].(float64) {
+			return a["usage_percent"].(float64) > b["usage_percent"].(float64)
+		}
+		return a["name"].(string) < b["name"].(string)
+	})
+
+	return out
+}
+
+// The facet is returned both as a named attribute and in the facet attribute.
+func cardinalityUsageMetricName(r nrdb.NRDBResult) string {
+	if name, ok := r["metricName"].(string); ok {
+		return name
+	}
+	if name, ok := r["facet"].(string); ok {
+		return name
+	}
+
+	return ""
+}
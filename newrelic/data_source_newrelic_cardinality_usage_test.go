@@ -0,0 +1,29 @@
+//go:build integration || INGEST
+
+package newrelic
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicCardinalityUsageDataSource_Basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "newrelic_cardinality_usage" "foo" {
+  top = 5
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.newrelic_cardinality_usage.foo", "default_limit"),
+					resource.TestCheckResourceAttrSet("data.newrelic_cardinality_usage.foo", "metrics.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,82 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenCardinalityUsage(t *testing.T) {
+	t.Parallel()
+
+	first := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	last := first.Add(2 * time.Hour)
+
+	usage := []nrdb.NRDBResult{
+		{"metricName": "http.server.duration", "facet": "http.server.duration", "cardinality": float64(90000)},
+		{"metricName": "db.query.duration", "facet": "db.query.duration", "cardinality": float64(150000)},
+		{"facet": "cache.hits", "cardinality": float64(5000)},
+		{"cardinality": float64(1)},
+	}
+	breaches := []nrdb.NRDBResult{
+		{"metricName": "queue.depth", "earliest.timestamp": float64(first.UnixMilli()), "latest.timestamp": float64(last.UnixMilli())},
+	}
+
+	metrics := flattenCardinalityUsage(usage, breaches, 100000, map[string]interface{}{"db.query.duration": 300000, "queue.depth": 50000})
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":              "http.server.duration",
+			"cardinality":       90000,
+			"limit":             100000,
+			"usage_percent":     90.0,
+			"breached":          false,
+			"first_breached_at": "",
+			"last_breached_at":  "",
+		},
+		map[string]interface{}{
+			"name":              "db.query.duration",
+			"cardinality":       150000,
+			"limit":             300000,
+			"usage_percent":     50.0,
+			"breached":          false,
+			"first_breached_at": "",
+			"last_breached_at":  "",
+		},
+		map[string]interface{}{
+			"name":              "cache.hits",
+			"cardinality":       5000,
+			"limit":             100000,
+			"usage_percent":     5.0,
+			"breached":          false,
+			"first_breached_at": "",
+			"last_breached_at":  "",
+		},
+		// Beyond the top metrics, but breached today
+		map[string]interface{}{
+			"name":              "queue.depth",
+			"cardinality":       0,
+			"limit":             50000,
+			"usage_percent":     0.0,
+			"breached":          true,
+			"first_breached_at": "2026-01-02T03:04:00Z",
+			"last_breached_at":  "2026-01-02T05:04:00Z",
+		},
+	}, metrics)
+}
+
+func TestFlattenCardinalityUsage_AtLimit(t *testing.T) {
+	t.Parallel()
+
+	metrics := flattenCardinalityUsage([]nrdb.NRDBResult{
+		{"metricName": "http.server.duration", "cardinality": float64(100000)},
+	}, nil, 100000, nil)
+
+	require.Len(t, metrics, 1)
+	require.Equal(t, true, metrics[0].(map[string]interface{})["breached"])
+	require.Equal(t, 100.0, metrics[0].(map[string]interface{})["usage_percent"])
+}
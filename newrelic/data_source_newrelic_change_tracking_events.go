@@ -0,0 +1,145 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrdb"
+)
+
+const changeTrackingEventsQuery = "SELECT changeTrackingId, category, type, version, changelog, commit, deepLink, featureFlagId, " +
+	"description, shortDescription, user, groupId, timestamp FROM ChangeTrackingEvent WHERE entity.guid = '%s'%s SINCE %s LIMIT %d"
+
+var changeTrackingEventsSincePattern = regexp.MustCompile(`^\d+ (minute|hour|day|week)s? ago$`)
+
+// The attributes of the events, by the NRDB attributes they are read from.
+var changeTrackingEventsAttributes = map[string]string{
+	"change_tracking_id": "changeTrackingId",
+	"category":           "category",
+	"type":               "type",
+	"version":            "version",
+	"changelog":          "changelog",
+	"commit":             "commit",
+	"deep_link":          "deepLink",
+	"feature_flag_id":    "featureFlagId",
+	"description":        "description",
+	"short_description":  "shortDescription",
+	"user":               "user",
+	"group_id":           "groupId",
+}
+
+func dataSourceNewRelicChangeTrackingEvents() *schema.Resource {
+	event := map[string]*schema.Schema{
+		"timestamp": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The start time of the change, in milliseconds since the Unix epoch.",
+		},
+	}
+	for attribute := range changeTrackingEventsAttributes {
+		event[attribute] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicChangeTrackingEventsRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The ID of the New Relic account the events are recorded in.",
+			},
+			"entity_guid": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(changeTrackingEntityGUIDPattern, "must be an entity GUID"),
+				Description:  "The GUID of the entity to list the change tracking events of.",
+			},
+			"category": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z_]+$`), "must be a change tracking category"),
+				Description:  "Only list the events of the category, e.g. DEPLOYMENT.",
+			},
+			"since": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "1 week ago",
+				ValidateFunc: validation.StringMatch(changeTrackingEventsSincePattern, "must be a time like `3 days ago`"),
+				Description:  "How far back to list events, e.g. `3 days ago`.",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 5000),
+				Description:  "The maximum number of events to list.",
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The change tracking events of the entity, most recent first.",
+				Elem:        &schema.Resource{Schema: event},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicChangeTrackingEventsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	log.Printf("[INFO] Reading New Relic change tracking events")
+
+	accountID := selectAccountID(providerConfig, d)
+	entityGUID := d.Get("entity_guid").(string)
+
+	query := changeTrackingEventsNRQL(entityGUID, d.Get("category").(string), d.Get("since").(string), d.Get("limit").(int))
+	result, err := client.Nrdb.QueryWithContext(ctx, accountID, nrdb.NRQL(query))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(entityGUID)
+
+	return diag.FromErr(d.Set("events", flattenChangeTrackingEvents(result.Results)))
+}
+
+func changeTrackingEventsNRQL(entityGUID string, category string, since string, limit int) string {
+	where := ""
+	if category != "" {
+		where = fmt.Sprintf(" AND category = '%s'", strings.ToUpper(category))
+	}
+
+	return fmt.Sprintf(changeTrackingEventsQuery, entityGUID, where, since, limit)
+}
+
+func flattenChangeTrackingEvents(results []nrdb.NRDBResult) []interface{} {
+	events := make([]interface{}, 0, len(results))
+
+	for _, r := range results {
+		event := map[string]interface{}{}
+		for attribute, key := range changeTrackingEventsAttributes {
+			if v, ok := r[key]; ok && v != nil {
+				event[attribute] = fmt.Sprint(v)
+			} else {
+				event[attribute] = ""
+			}
+		}
+		if timestamp, ok := r["timestamp"].(float64); ok {
+			event["timestamp"] = int(timestamp)
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
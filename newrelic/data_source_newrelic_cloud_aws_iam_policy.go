@@ -0,0 +1,275 @@
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+)
+
+// The actions needed by every integration to poll the metrics of its service from CloudWatch.
+var cloudAwsIAMPolicyCloudWatchActions = []string{
+	"cloudwatch:GetMetricData",
+	"cloudwatch:GetMetricStatistics",
+	"cloudwatch:ListMetrics",
+}
+
+// An integration enabled in a partition, with the boolean arguments enabled in it.
+type cloudAwsIAMPolicyIntegration struct {
+	service   cloudAwsService
+	arguments map[string]bool
+}
+
+type cloudAwsIAMPolicyDocument struct {
+	Version   string
+	Statement []cloudAwsIAMPolicyStatement
+}
+
+type cloudAwsIAMPolicyStatement struct {
+	Effect   string
+	Action   []string
+	Resource string
+}
+
+func dataSourceNewRelicCloudAwsIAMPolicy() *schema.Resource {
+	var partitions []string
+	for name := range cloudAwsPartitionNames {
+		partitions = append(partitions, name)
+	}
+	sort.Strings(partitions)
+
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicCloudAwsIAMPolicyRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The ID of the New Relic account the linked AWS account belongs to.",
+			},
+			"partition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "commercial",
+				ValidateFunc: validation.StringInSlice(partitions, false),
+				Description:  "The AWS partition of the integrations, one of commercial, govcloud or eu_sovereign.",
+			},
+			"linked_account_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"linked_account_id", "integration"},
+				Description:  "The ID of a linked AWS account in New Relic, whose enabled integrations the policy is computed for.",
+			},
+			"integration": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ExactlyOneOf: []string{"linked_account_id", "integration"},
+				Description:  "An integration the policy is computed for.",
+				Elem: &schema.Resource{
+					Schema: cloudAwsIAMPolicyIntegrationSchema(),
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IAM policy document granting the actions, in JSON.",
+			},
+			"actions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IAM actions needed by the integrations.",
+			},
+		},
+	}
+}
+
+// The integration block takes the name of the integration block of the resource, and the boolean
+// arguments of integrations which need additional actions when enabled.
+func cloudAwsIAMPolicyIntegrationSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the integration block in the integrations resource of the partition, e.g. ec2 or aws_msk.",
+		},
+	}
+
+	for _, service := range cloudAwsServices {
+		for a := range service.actions.arguments {
+			argument := *cloudAwsIntegrationArguments[a]
+			argument.Optional = true
+			s[a] = &argument
+		}
+	}
+
+	return s
+}
+
+func dataSourceNewRelicCloudAwsIAMPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Reading New Relic cloud AWS IAM policy")
+
+	partitionName := d.Get("partition").(string)
+	partition := cloudAwsPartitionNames[partitionName]
+
+	var integrations []cloudAwsIAMPolicyIntegration
+
+	if linkedAccountID, ok := d.GetOk("linked_account_id"); ok {
+		providerConfig := meta.(*ProviderConfig)
+		client := providerConfig.NewClient
+
+		linkedAccount, err := client.Cloud.GetLinkedAccountWithContext(ctx, selectAccountID(providerConfig, d), linkedAccountID.(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		integrations = expandCloudAwsIAMPolicyLinkedAccount(partition, linkedAccount)
+		if len(integrations) == 0 {
+			return diag.Errorf("the linked account %d has no integrations enabled in the %s partition", linkedAccountID.(int), partitionName)
+		}
+	} else {
+		var err error
+		integrations, err = expandCloudAwsIAMPolicyIntegrations(partitionName, d.Get("integration").([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	actions := cloudAwsIAMPolicyActions(integrations)
+
+	policy, err := json.MarshalIndent(cloudAwsIAMPolicyDocument{
+		Version: "2012-10-17",
+		Statement: []cloudAwsIAMPolicyStatement{
+			{
+				Effect:   "Allow",
+				Action:   actions,
+				Resource: "*",
+			},
+		},
+	}, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(string(policy))))
+
+	if err := d.Set("json", string(policy)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("actions", actions))
+}
+
+// Returns the service of the integration block with the given name in the partition.
+func cloudAwsServiceByBlock(partition cloudAwsPartition, name string) (cloudAwsService, cloudAwsIntegrationBlock, bool) {
+	for _, service := range cloudAwsServices {
+		if block, ok := service.blocks[partition]; ok && block.name == name {
+			return service, block, true
+		}
+	}
+
+	return cloudAwsService{}, cloudAwsIntegrationBlock{}, false
+}
+
+func expandCloudAwsIAMPolicyIntegrations(partitionName string, b []interface{}) ([]cloudAwsIAMPolicyIntegration, error) {
+	partition := cloudAwsPartitionNames[partitionName]
+
+	var integrations []cloudAwsIAMPolicyIntegration
+
+	for _, v := range b {
+		in, _ := v.(map[string]interface{})
+		name, _ := in["name"].(string)
+
+		service, block, ok := cloudAwsServiceByBlock(partition, name)
+		if !ok {
+			return nil, fmt.Errorf("%s is not an integration of the %s partition", name, partitionName)
+		}
+
+		integration := cloudAwsIAMPolicyIntegration{service: service, arguments: map[string]bool{}}
+		arguments := service.blockArguments(block)
+
+		for a, enabled := range in {
+			if enabled, ok := enabled.(bool); !ok || !enabled {
+				continue
+			}
+
+			supported := false
+			for _, s := range arguments {
+				supported = supported || s == a
+			}
+			if !supported {
+				return nil, fmt.Errorf("%s is not an argument of the %s integration", a, name)
+			}
+
+			integration.arguments[a] = true
+		}
+
+		integrations = append(integrations, integration)
+	}
+
+	return integrations, nil
+}
+
+// Expands the integrations enabled in a linked account, reading the boolean arguments needing
+// additional actions from the fields named after them, e.g. FetchTags for fetch_tags.
+func expandCloudAwsIAMPolicyLinkedAccount(partition cloudAwsPartition, linkedAccount *cloud.CloudLinkedAccount) []cloudAwsIAMPolicyIntegration {
+	var integrations []cloudAwsIAMPolicyIntegration
+
+	for _, i := range linkedAccount.Integrations {
+		for _, service := range cloudAwsServices {
+			block, ok := service.blocks[partition]
+			if !ok || reflect.TypeOf(service.integration) != reflect.TypeOf(i) {
+				continue
+			}
+
+			integration := cloudAwsIAMPolicyIntegration{service: service, arguments: map[string]bool{}}
+			fields := reflect.ValueOf(i).Elem()
+			for _, a := range service.blockArguments(block) {
+				if _, ok := service.actions.arguments[a]; ok {
+					integration.arguments[a] = fields.FieldByName(cloudAwsIntegrationField(a)).Bool()
+				}
+			}
+
+			integrations = append(integrations, integration)
+		}
+	}
+
+	return integrations
+}
+
+// Returns the sorted, unique actions needed by the integrations.
+func cloudAwsIAMPolicyActions(integrations []cloudAwsIAMPolicyIntegration) []string {
+	unique := map[string]bool{}
+	for _, a := range cloudAwsIAMPolicyCloudWatchActions {
+		unique[a] = true
+	}
+
+	for _, integration := range integrations {
+		for _, a := range integration.service.actions.polling {
+			unique[a] = true
+		}
+		for argument, actions := range integration.service.actions.arguments {
+			if !integration.arguments[argument] {
+				continue
+			}
+			for _, a := range actions {
+				unique[a] = true
+			}
+		}
+	}
+
+	actions := make([]string, 0, len(unique))
+	for a := range unique {
+		actions = append(actions, a)
+	}
+	sort.Strings(actions)
+
+	return actions
+}
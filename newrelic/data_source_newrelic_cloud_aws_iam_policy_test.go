@@ -0,0 +1,66 @@
+//go:build integration || CLOUD
+
+package newrelic
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicCloudAwsIAMPolicyDataSource_Basic(t *testing.T) {
+	resourceName := "data.newrelic_cloud_aws_iam_policy.policy"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testNewRelicCloudAwsIAMPolicyDataSourceBasicConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "8"),
+					resource.TestCheckResourceAttr(resourceName, "actions.3", "lambda:GetAccountSettings"),
+					resource.TestMatchResourceAttr(resourceName, "json", regexp.MustCompile(`"lambda:ListTags"`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicCloudAwsIAMPolicyDataSource_Error(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testNewRelicCloudAwsIAMPolicyDataSourceErrorConfig(),
+				ExpectError: regexp.MustCompile("aws_msk is not an integration of the eu_sovereign partition"),
+			},
+		},
+	})
+}
+
+func testNewRelicCloudAwsIAMPolicyDataSourceBasicConfig() string {
+	return `
+data "newrelic_cloud_aws_iam_policy" "policy" {
+	integration {
+		name       = "lambda"
+		fetch_tags = true
+	}
+}
+`
+}
+
+func testNewRelicCloudAwsIAMPolicyDataSourceErrorConfig() string {
+	return `
+data "newrelic_cloud_aws_iam_policy" "policy" {
+	partition = "eu_sovereign"
+
+	integration {
+		name = "aws_msk"
+	}
+}
+`
+}
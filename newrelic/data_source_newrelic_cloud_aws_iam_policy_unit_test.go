@@ -0,0 +1,115 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceNewRelicCloudAwsIAMPolicy_Integrations(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicCloudAwsIAMPolicy().Schema, map[string]interface{}{
+		"integration": []interface{}{
+			map[string]interface{}{"name": "ec2"},
+			map[string]interface{}{"name": "s3", "fetch_tags": true},
+			map[string]interface{}{"name": "rds", "fetch_tags": true},
+		},
+	})
+
+	diags := dataSourceNewRelicCloudAwsIAMPolicyRead(context.Background(), d, nil)
+	require.False(t, diags.HasError(), diags)
+
+	require.Equal(t, []interface{}{
+		"cloudwatch:GetMetricData",
+		"cloudwatch:GetMetricStatistics",
+		"cloudwatch:ListMetrics",
+		"ec2:DescribeInstanceStatus",
+		"ec2:DescribeInstances",
+		"rds:DescribeDBClusters",
+		"rds:DescribeDBInstances",
+		"rds:ListTagsForResource",
+		"s3:GetBucketLocation",
+		"s3:GetBucketTagging",
+		"s3:ListAllMyBuckets",
+	}, d.Get("actions"))
+
+	var policy cloudAwsIAMPolicyDocument
+	require.NoError(t, json.Unmarshal([]byte(d.Get("json").(string)), &policy))
+	require.Equal(t, "2012-10-17", policy.Version)
+	require.Len(t, policy.Statement, 1)
+	require.Equal(t, "Allow", policy.Statement[0].Effect)
+	require.Equal(t, "*", policy.Statement[0].Resource)
+	require.Len(t, policy.Statement[0].Action, 11)
+	require.NotEmpty(t, d.Id())
+}
+
+func TestDataSourceNewRelicCloudAwsIAMPolicy_InvalidIntegrations(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		partition   string
+		integration map[string]interface{}
+		err         string
+	}{
+		"not in partition": {
+			partition:   "govcloud",
+			integration: map[string]interface{}{"name": "aws_msk"},
+			err:         "aws_msk is not an integration of the govcloud partition",
+		},
+		"unknown": {
+			partition:   "commercial",
+			integration: map[string]interface{}{"name": "ec3"},
+			err:         "ec3 is not an integration of the commercial partition",
+		},
+		"unsupported argument": {
+			partition:   "commercial",
+			integration: map[string]interface{}{"name": "lambda", "fetch_extended_inventory": true},
+			err:         "fetch_extended_inventory is not an argument of the lambda integration",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, dataSourceNewRelicCloudAwsIAMPolicy().Schema, map[string]interface{}{
+				"partition":   tc.partition,
+				"integration": []interface{}{tc.integration},
+			})
+
+			diags := dataSourceNewRelicCloudAwsIAMPolicyRead(context.Background(), d, nil)
+			require.True(t, diags.HasError())
+			require.Equal(t, tc.err, diags[0].Summary)
+		})
+	}
+}
+
+func TestExpandCloudAwsIAMPolicyLinkedAccount(t *testing.T) {
+	t.Parallel()
+
+	linkedAccount := &cloud.CloudLinkedAccount{
+		Integrations: []cloud.CloudIntegrationInterface{
+			&cloud.CloudSqsIntegration{FetchExtendedInventory: true},
+			&cloud.CloudLambdaIntegration{FetchTags: true},
+			// Not available in GovCloud.
+			&cloud.CloudAwsMskIntegration{},
+		},
+	}
+
+	integrations := expandCloudAwsIAMPolicyLinkedAccount(cloudAwsPartitionGovCloud, linkedAccount)
+	require.Len(t, integrations, 2)
+
+	actions := cloudAwsIAMPolicyActions(integrations)
+	require.Contains(t, actions, "sqs:GetQueueAttributes")
+	require.NotContains(t, actions, "sqs:ListQueueTags")
+	require.Contains(t, actions, "lambda:ListTags")
+	require.NotContains(t, actions, "kafka:ListClusters")
+}
@@ -0,0 +1,260 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/errors"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrdb"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrtime"
+)
+
+var cloudIntegrationsCoverageDefaultProviders = []string{"aws", "azure", "gcp"}
+
+// The data reported by cloud integrations carries the ID of the linked account it was polled from.
+const cloudIntegrationsCoverageReportingQuery = "SELECT latest(timestamp) FROM Metric, ComputeSample, DatastoreSample, LoadBalancerSample, QueueSample, ServerlessSample, BlockDeviceSample WHERE providerAccountId IS NOT NULL FACET providerAccountId SINCE %d minutes ago LIMIT MAX"
+
+func dataSourceNewRelicCloudIntegrationsCoverage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicCloudIntegrationsCoverageRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The ID of the New Relic account the cloud accounts are linked to.",
+			},
+			"cloud_providers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.NoZeroValues},
+				Description: "The cloud providers of the linked accounts, e.g. aws, azure, gcp. Defaults to aws, azure and gcp.",
+			},
+			"reporting_window": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of minutes a linked account must have reported data within to be considered reporting.",
+			},
+			"linked_accounts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The linked cloud accounts, with their enabled integrations.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the linked account in New Relic.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the linked account.",
+						},
+						"cloud_provider": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The cloud provider of the linked account.",
+						},
+						"external_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the account in the cloud provider.",
+						},
+						"disabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the linked account is disabled.",
+						},
+						"metric_collection_mode": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "How metrics are collected from the linked account, PULL or PUSH.",
+						},
+						"reporting": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the linked account reported data within the reporting window.",
+						},
+						"last_reported_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the linked account last reported data within the reporting window, in RFC3339 format.",
+						},
+						"integrations": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The integrations enabled in the linked account.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The short name of the integrated service, e.g. ec2.",
+									},
+									"service_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the integrated service.",
+									},
+									"metrics_polling_interval": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The data polling interval in seconds, if the integration polls.",
+									},
+									"created_at": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "When the integration was enabled, in RFC3339 format.",
+									},
+									"updated_at": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "When the integration was last configured, in RFC3339 format.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicCloudIntegrationsCoverageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	log.Printf("[INFO] Reading New Relic cloud integrations coverage")
+
+	accountID := selectAccountID(providerConfig, d)
+
+	providers := cloudIntegrationsCoverageDefaultProviders
+	if v, ok := d.GetOk("cloud_providers"); ok {
+		providers = expandStringSlice(v.([]interface{}))
+	}
+
+	var linkedAccounts []interface{}
+
+	for _, provider := range providers {
+		accounts, err := client.Cloud.GetLinkedAccountsWithContext(ctx, provider)
+		if err != nil {
+			if _, ok := err.(*errors.NotFound); ok {
+				continue
+			}
+			return diag.FromErr(err)
+		}
+
+		for i := range *accounts {
+			account := (*accounts)[i]
+			if account.NrAccountId != accountID {
+				continue
+			}
+			linkedAccounts = append(linkedAccounts, flattenCloudIntegrationsCoverageLinkedAccount(provider, &account))
+		}
+	}
+
+	query := nrdb.NRQL(fmt.Sprintf(cloudIntegrationsCoverageReportingQuery, d.Get("reporting_window").(int)))
+	result, err := client.Nrdb.QueryWithContext(ctx, accountID, query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setCloudIntegrationsCoverageLastReported(linkedAccounts, result.Results)
+
+	d.SetId(strconv.Itoa(accountID))
+
+	return diag.FromErr(d.Set("linked_accounts", linkedAccounts))
+}
+
+func flattenCloudIntegrationsCoverageLinkedAccount(provider string, account *cloud.CloudLinkedAccount) map[string]interface{} {
+	var integrations []interface{}
+	for _, i := range account.Integrations {
+		integrations = append(integrations, flattenCloudIntegrationsCoverageIntegration(i))
+	}
+
+	// The integrations are listed by name, so the list only changes when integrations are enabled or disabled.
+	sort.Slice(integrations, func(i, j int) bool {
+		return integrations[i].(map[string]interface{})["name"].(string) < integrations[j].(map[string]interface{})["name"].(string)
+	})
+
+	return map[string]interface{}{
+		"id":                     account.ID,
+		"name":                   account.Name,
+		"cloud_provider":         provider,
+		"external_id":            account.ExternalId,
+		"disabled":               account.Disabled,
+		"metric_collection_mode": string(account.MetricCollectionMode),
+		"reporting":              false,
+		"last_reported_at":       "",
+		"integrations":           integrations,
+	}
+}
+
+// The integrations of each service are distinct types sharing the fields read here,
+// except for MetricsPollingInterval which push based integrations do not have.
+func flattenCloudIntegrationsCoverageIntegration(i cloud.CloudIntegrationInterface) map[string]interface{} {
+	integration := reflect.ValueOf(i).Elem()
+	service := integration.FieldByName("Service").Interface().(cloud.CloudService)
+
+	out := map[string]interface{}{
+		"name":                     service.Slug,
+		"service_name":             service.Name,
+		"metrics_polling_interval": 0,
+		"created_at":               flattenCloudIntegrationsCoverageTime(integration.FieldByName("CreatedAt").Interface().(nrtime.EpochSeconds)),
+		"updated_at":               flattenCloudIntegrationsCoverageTime(integration.FieldByName("UpdatedAt").Interface().(nrtime.EpochSeconds)),
+	}
+
+	if interval := integration.FieldByName("MetricsPollingInterval"); interval.IsValid() {
+		out["metrics_polling_interval"] = int(interval.Int())
+	}
+
+	return out
+}
+
+func flattenCloudIntegrationsCoverageTime(t nrtime.EpochSeconds) string {
+	if time.Time(t).IsZero() {
+		return ""
+	}
+
+	return time.Time(t).UTC().Format(time.RFC3339)
+}
+
+// Sets when each linked account last reported from the results of cloudIntegrationsCoverageReportingQuery,
+// in which the latest timestamp of each linked account is in epoch milliseconds.
+func setCloudIntegrationsCoverageLastReported(linkedAccounts []interface{}, results []nrdb.NRDBResult) {
+	lastReported := map[string]float64{}
+	for _, r := range results {
+		timestamp, ok := r["latest.timestamp"].(float64)
+		if !ok {
+			continue
+		}
+
+		// The facet is a string or a number depending on how the data was reported.
+		switch facet := r["facet"].(type) {
+		case string:
+			lastReported[facet] = timestamp
+		case float64:
+			lastReported[strconv.FormatFloat(facet, 'f', -1, 64)] = timestamp
+		}
+	}
+
+	for _, a := range linkedAccounts {
+		account := a.(map[string]interface{})
+		if timestamp, ok := lastReported[strconv.Itoa(account["id"].(int))]; ok {
+			account["reporting"] = true
+			account["last_reported_at"] = time.UnixMilli(int64(timestamp)).UTC().Format(time.RFC3339)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+//go:build integration || CLOUD
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicCloudIntegrationsCoverageDataSource_Basic(t *testing.T) {
+	resourceName := "data.newrelic_cloud_integrations_coverage.coverage"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testNewRelicCloudIntegrationsCoverageDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprint(testAccountID)),
+					resource.TestCheckResourceAttrSet(resourceName, "linked_accounts.#"),
+				),
+			},
+		},
+	})
+}
+
+func testNewRelicCloudIntegrationsCoverageDataSourceConfig() string {
+	return fmt.Sprintf(`
+data "newrelic_cloud_integrations_coverage" "coverage" {
+	account_id       = %d
+	cloud_providers  = ["aws", "gcp"]
+	reporting_window = 120
+}
+`, testAccountID)
+}
@@ -0,0 +1,101 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrdb"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrtime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenCloudIntegrationsCoverageLinkedAccount(t *testing.T) {
+	t.Parallel()
+
+	createdAt := nrtime.EpochSeconds(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	account := &cloud.CloudLinkedAccount{
+		ID:                   1234,
+		Name:                 "production",
+		ExternalId:           "123456789012",
+		MetricCollectionMode: cloud.CloudMetricCollectionModeTypes.PULL,
+		Integrations: []cloud.CloudIntegrationInterface{
+			&cloud.CloudSqsIntegration{
+				Service:                cloud.CloudService{Slug: "sqs", Name: "SQS"},
+				MetricsPollingInterval: 300,
+				CreatedAt:              createdAt,
+			},
+			&cloud.CloudAlbIntegration{
+				Service:                cloud.CloudService{Slug: "alb", Name: "ALB"},
+				MetricsPollingInterval: 60,
+			},
+		},
+	}
+
+	flattened := flattenCloudIntegrationsCoverageLinkedAccount("aws", account)
+	require.Equal(t, map[string]interface{}{
+		"id":                     1234,
+		"name":                   "production",
+		"cloud_provider":         "aws",
+		"external_id":            "123456789012",
+		"disabled":               false,
+		"metric_collection_mode": "PULL",
+		"reporting":              false,
+		"last_reported_at":       "",
+		"integrations": []interface{}{
+			map[string]interface{}{
+				"name":                     "alb",
+				"service_name":             "ALB",
+				"metrics_polling_interval": 60,
+				"created_at":               "",
+				"updated_at":               "",
+			},
+			map[string]interface{}{
+				"name":                     "sqs",
+				"service_name":             "SQS",
+				"metrics_polling_interval": 300,
+				"created_at":               "2026-01-02T03:04:05Z",
+				"updated_at":               "",
+			},
+		},
+	}, flattened)
+}
+
+// Every integration returned in linked accounts must have the fields read by the data source.
+func TestFlattenCloudIntegrationsCoverageIntegration_Types(t *testing.T) {
+	t.Parallel()
+
+	integrations := append([]cloud.CloudIntegrationInterface{
+		&cloud.CloudGcpVmsIntegration{},
+		&cloud.CloudAzureVirtualmachineIntegration{},
+		&cloud.CloudOciLogsIntegration{},
+		&cloud.CloudOciMetadataAndTagsIntegration{},
+	}, testCloudAwsIntegrations...)
+
+	for _, i := range integrations {
+		require.NotPanicsf(t, func() { flattenCloudIntegrationsCoverageIntegration(i) }, "%T", i)
+	}
+}
+
+func TestSetCloudIntegrationsCoverageLastReported(t *testing.T) {
+	t.Parallel()
+
+	linkedAccounts := []interface{}{
+		map[string]interface{}{"id": 1000000, "reporting": false, "last_reported_at": ""},
+		map[string]interface{}{"id": 1234, "reporting": false, "last_reported_at": ""},
+		map[string]interface{}{"id": 5678, "reporting": false, "last_reported_at": ""},
+	}
+
+	setCloudIntegrationsCoverageLastReported(linkedAccounts, []nrdb.NRDBResult{
+		{"facet": float64(1000000), "providerAccountId": float64(1000000), "latest.timestamp": float64(1767323045000)},
+		{"facet": "1234", "providerAccountId": "1234", "latest.timestamp": float64(1767323105000)},
+	})
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"id": 1000000, "reporting": true, "last_reported_at": "2026-01-02T03:04:05Z"},
+		map[string]interface{}{"id": 1234, "reporting": true, "last_reported_at": "2026-01-02T03:05:05Z"},
+		map[string]interface{}{"id": 5678, "reporting": false, "last_reported_at": ""},
+	}, linkedAccounts)
+}
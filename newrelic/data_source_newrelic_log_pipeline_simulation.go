@@ -0,0 +1,292 @@
+package newrelic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	nrErrors "github.com/newrelic/newrelic-client-go/v2/pkg/errors"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/pipelinecontrol"
+)
+
+func dataSourceNewRelicLogPipelineSimulation() *schema.Resource {
+	matchedRuleSchema := map[string]*schema.Schema{
+		"stage": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The stage of the pipeline the rule belongs to: DROP, PARSE, OBFUSCATE or PARTITION.",
+		},
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the rule.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name or description of the rule.",
+		},
+	}
+
+	unsupportedRuleSchema := map[string]*schema.Schema{
+		"reason": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Why the rule cannot be simulated.",
+		},
+	}
+	for k, v := range matchedRuleSchema {
+		unsupportedRuleSchema[k] = v
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicLogPipelineSimulationRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The account whose drop, pipeline cloud, parsing, obfuscation and data partition rules are simulated.",
+			},
+			"records": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The sample log records, as JSON objects.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The result of the pipeline for each record, in the order of the records.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"input": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The sample log record.",
+						},
+						"dropped": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the record is dropped by a drop rule or a pipeline cloud rule.",
+						},
+						"matched_rules": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The rules matching the record, in the order they are applied.",
+							Elem:        &schema.Resource{Schema: matchedRuleSchema},
+						},
+						"record": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The resulting record as a JSON object, with nested attributes flattened. Empty when the record is dropped.",
+						},
+						"partition": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The data partition the record is stored in.",
+						},
+					},
+				},
+			},
+			"unsupported_rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rules which cannot be simulated, and are left out of the results.",
+				Elem:        &schema.Resource{Schema: unsupportedRuleSchema},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicLogPipelineSimulationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Simulating the log pipeline of account %d", accountID)
+
+	pipeline, err := getLogPipeline(ctx, client, accountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var results []interface{}
+	for _, r := range d.Get("records").([]interface{}) {
+		input := r.(string)
+
+		record, err := expandLogPipelineRecord(input)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		result, err := flattenLogPipelineResult(input, pipeline.process(record))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		results = append(results, result)
+	}
+
+	d.SetId(strconv.Itoa(accountID))
+	_ = d.Set("account_id", accountID)
+
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("unsupported_rules", flattenLogPipelineUnsupportedRules(pipeline.Unsupported)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// Reads the rules of the log pipeline of an account.
+func getLogPipeline(ctx context.Context, client *newrelic.NewRelic, accountID int) (*logPipeline, error) {
+	pipeline := &logPipeline{}
+
+	// The lists of log configurations are reported as not found when empty.
+	isNotFound := func(err error) bool {
+		var notFound *nrErrors.NotFound
+		return errors.As(err, &notFound)
+	}
+
+	dropRules, err := client.Nrqldroprules.GetListWithContext(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading drop rules: %w", err)
+	}
+	if dropRules.Error.Description != "" {
+		return nil, fmt.Errorf("error reading drop rules: %s", dropRules.Error.Description)
+	}
+	pipeline.addDropRules(dropRules.Rules)
+
+	cloudRules, err := getPipelineCloudRules(ctx, client, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading pipeline cloud rules: %w", err)
+	}
+	pipeline.addPipelineCloudRules(cloudRules)
+
+	parsingRules, err := client.Logconfigurations.GetParsingRulesWithContext(ctx, accountID)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error reading parsing rules: %w", err)
+	}
+	if parsingRules != nil {
+		pipeline.addParsingRules(*parsingRules)
+	}
+
+	obfuscationRules, err := client.Logconfigurations.GetObfuscationRulesWithContext(ctx, accountID)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error reading obfuscation rules: %w", err)
+	}
+	if obfuscationRules != nil {
+		pipeline.addObfuscationRules(*obfuscationRules)
+	}
+
+	partitionRules, err := client.Logconfigurations.GetDataPartitionRulesWithContext(ctx, accountID)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error reading data partition rules: %w", err)
+	}
+	if partitionRules != nil {
+		pipeline.addDataPartitionRules(*partitionRules)
+	}
+
+	return pipeline, nil
+}
+
+// The entity search of the client does not paginate, so the rule IDs are searched
+// with a direct query.
+const pipelineCloudRuleSearchQuery = `query(
+	$cursor: String,
+	$query: String!,
+) { actor { entityManagement { entitySearch(
+	cursor: $cursor,
+	query: $query,
+) {
+	entities {
+		id
+		type
+		scope {
+			id
+			type
+		}
+	}
+	nextCursor
+} } } }`
+
+type pipelineCloudRuleSearchResponse struct {
+	Actor struct {
+		EntityManagement struct {
+			EntitySearch struct {
+				Entities []struct {
+					ID    string `json:"id"`
+					Type  string `json:"type"`
+					Scope struct {
+						ID   string `json:"id"`
+						Type string `json:"type"`
+					} `json:"scope"`
+				} `json:"entities"`
+				NextCursor string `json:"nextCursor"`
+			} `json:"entitySearch"`
+		} `json:"entityManagement"`
+	} `json:"actor"`
+}
+
+// Reads the pipeline cloud rules of an account.
+func getPipelineCloudRules(ctx context.Context, client *newrelic.NewRelic, accountID int) ([]*pipelinecontrol.EntityManagementPipelineCloudRuleEntity, error) {
+	var ids []string
+
+	cursor := ""
+	for {
+		resp := pipelineCloudRuleSearchResponse{}
+		vars := map[string]interface{}{
+			"query": "type = 'PIPELINE_CLOUD_RULE'",
+		}
+		if cursor != "" {
+			vars["cursor"] = cursor
+		}
+
+		if err := client.NerdGraph.QueryWithResponseAndContext(ctx, pipelineCloudRuleSearchQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		search := resp.Actor.EntityManagement.EntitySearch
+		for _, entity := range search.Entities {
+			if entity.Scope.Type == string(pipelinecontrol.EntityManagementEntityScopeTypes.ACCOUNT) && entity.Scope.ID == strconv.Itoa(accountID) {
+				ids = append(ids, entity.ID)
+			}
+		}
+
+		if search.NextCursor == "" {
+			break
+		}
+		cursor = search.NextCursor
+	}
+
+	rules := make([]*pipelinecontrol.EntityManagementPipelineCloudRuleEntity, 0, len(ids))
+	for _, id := range ids {
+		entity, err := client.Pipelinecontrol.GetEntityWithContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if entity == nil {
+			continue
+		}
+
+		if rule, ok := (*entity).(*pipelinecontrol.EntityManagementPipelineCloudRuleEntity); ok {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
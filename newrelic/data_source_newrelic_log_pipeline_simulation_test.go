@@ -0,0 +1,62 @@
+//go:build integration || LOGGING_INTEGRATIONS
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicLogPipelineSimulationDataSource_Basic(t *testing.T) {
+	resourceName := "data.newrelic_log_pipeline_simulation.foo"
+	rName := generateNameForIntegrationTestResource()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicLogParsingRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicLogPipelineSimulationDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "results.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "results.0.dropped", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "results.0.matched_rules.*", map[string]string{
+						"stage": "PARSE",
+						"name":  rName,
+					}),
+					resource.TestMatchResourceAttr(resourceName, "results.0.record", regexp.MustCompile(`"bytes":"512"`)),
+					resource.TestCheckResourceAttrSet(resourceName, "results.0.partition"),
+					resource.TestCheckResourceAttr(resourceName, "results.1.record", `{"logtype":"tf-simulation-other","message":"bytes=512"}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicLogPipelineSimulationDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_log_parsing_rule" "foo" {
+	account_id = %[1]d
+	name       = "%[2]s"
+	attribute  = "message"
+	enabled    = true
+	grok       = "bytes=%%%%{NUMBER:bytes:int}"
+	lucene     = "logtype:\"%[2]s\""
+	nrql       = "SELECT * FROM Log WHERE logtype = '%[2]s'"
+}
+
+data "newrelic_log_pipeline_simulation" "foo" {
+	account_id = %[1]d
+	records = [
+		jsonencode({ logtype = "%[2]s", message = "bytes=512" }),
+		jsonencode({ logtype = "tf-simulation-other", message = "bytes=512" }),
+	]
+
+	depends_on = [newrelic_log_parsing_rule.foo]
+}
+`, testAccountID, name)
+}
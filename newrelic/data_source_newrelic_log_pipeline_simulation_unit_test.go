@@ -0,0 +1,72 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPipelineCloudRules(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]string{
+		"": `{"data": {"actor": {"entityManagement": {"entitySearch": {"nextCursor": "page2", "entities": [
+			{"id": "rule-1", "type": "PIPELINE_CLOUD_RULE", "scope": {"id": "1", "type": "ACCOUNT"}},
+			{"id": "rule-2", "type": "PIPELINE_CLOUD_RULE", "scope": {"id": "2", "type": "ACCOUNT"}}
+		]}}}}}`,
+		"page2": `{"data": {"actor": {"entityManagement": {"entitySearch": {"nextCursor": null, "entities": [
+			{"id": "rule-3", "type": "PIPELINE_CLOUD_RULE", "scope": {"id": "1", "type": "ACCOUNT"}}
+		]}}}}}`,
+	}
+	nrql := map[string]string{
+		"rule-1": "DELETE FROM Log WHERE level = 'debug'",
+		"rule-3": "DELETE secret FROM Log",
+	}
+
+	var fetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.Contains(body.Query, "entitySearch(") {
+			require.Equal(t, "type = 'PIPELINE_CLOUD_RULE'", body.Variables["query"])
+			cursor, _ := body.Variables["cursor"].(string)
+			_, _ = w.Write([]byte(pages[cursor]))
+			return
+		}
+
+		id := body.Variables["id"].(string)
+		fetched = append(fetched, id)
+		_, _ = fmt.Fprintf(w, `{"data": {"actor": {"entityManagement": {"entity": {
+			"__typename": "EntityManagementPipelineCloudRuleEntity",
+			"id": %q, "name": "rule %s", "type": "PIPELINE_CLOUD_RULE", "nrql": %q,
+			"scope": {"id": "1", "type": "ACCOUNT"}
+		}}}}}`, id, id, nrql[id])
+	}))
+	defer server.Close()
+
+	client, err := newrelic.New(newrelic.ConfigPersonalAPIKey("NRAK-TEST"), newrelic.ConfigNerdGraphBaseURL(server.URL))
+	require.NoError(t, err)
+
+	rules, err := getPipelineCloudRules(context.Background(), client, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"rule-1", "rule-3"}, fetched)
+	require.Len(t, rules, 2)
+	require.Equal(t, "rule-1", rules[0].ID)
+	require.Equal(t, "DELETE FROM Log WHERE level = 'debug'", string(rules[0].NRQL))
+	require.Equal(t, "rule rule-3", rules[1].Name)
+}
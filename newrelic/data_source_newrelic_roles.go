@@ -0,0 +1,117 @@
+package newrelic
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/customeradministration"
+)
+
+func dataSourceNewRelicRoles() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicRolesRead,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:        schema.TypeString,
+				Description: "The type of the roles to list. Valid values are STANDARD (built-in roles) and CUSTOM.",
+				Optional:    true,
+				Default:     string(customeradministration.MultiTenantAuthorizationRoleTypeEnumTypes.STANDARD),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(customeradministration.MultiTenantAuthorizationRoleTypeEnumTypes.STANDARD),
+					string(customeradministration.MultiTenantAuthorizationRoleTypeEnumTypes.CUSTOM),
+				}, false),
+			},
+			"scope": {
+				Type:        schema.TypeString,
+				Description: "The scope of the roles to list. Valid values are ACCOUNT, GROUP and ORGANIZATION.",
+				Optional:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(customeradministration.MultiTenantAuthorizationRoleScopeEnumTypes.ACCOUNT),
+					string(customeradministration.MultiTenantAuthorizationRoleScopeEnumTypes.GROUP),
+					string(customeradministration.MultiTenantAuthorizationRoleScopeEnumTypes.ORGANIZATION),
+				}, false),
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the role to look up, e.g. `All Product Admin`.",
+				Optional:    true,
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Description: "The roles matching the filters.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scope": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicRolesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderConfig).NewClient
+
+	roleType := d.Get("type").(string)
+	scope := d.Get("scope").(string)
+	name := d.Get("name").(string)
+
+	log.Printf("[INFO] Fetching %s roles", roleType)
+
+	filter := customeradministration.MultiTenantAuthorizationRoleFilterInputExpression{
+		Type: &customeradministration.MultiTenantAuthorizationRoleTypeInputFilter{
+			Eq: customeradministration.MultiTenantAuthorizationRoleTypeEnum(roleType),
+		},
+	}
+	if scope != "" {
+		filter.Scope = &customeradministration.MultiTenantAuthorizationRoleScopeInputFilter{
+			Eq: customeradministration.MultiTenantAuthorizationRoleScopeEnum(scope),
+		}
+	}
+
+	resp, err := client.CustomerAdministration.GetRolesWithContext(ctx, "", filter, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var roles []customeradministration.MultiTenantAuthorizationRole
+	for _, role := range resp.Items {
+		if name != "" && !strings.EqualFold(role.Name, name) {
+			continue
+		}
+		roles = append(roles, role)
+	}
+
+	if name != "" && len(roles) == 0 {
+		return diag.Errorf("no %s role found with name %q", roleType, name)
+	}
+
+	if err := d.Set("roles", flattenRoles(roles)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := strings.Join([]string{roleType, scope, name}, ":")
+	d.SetId(id)
+
+	return nil
+}
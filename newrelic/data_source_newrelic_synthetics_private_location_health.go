@@ -0,0 +1,315 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrdb"
+)
+
+// Minions and job managers report a SyntheticsPrivateMinion event every 30 seconds, with their job counters
+// since they started.
+const syntheticsPrivateLocationHealthQuery = "SELECT latest(timestamp), latest(minionHostname), latest(minionBuildNumber), latest(minionJobsQueued), " +
+	"earliest(minionJobsFailed), latest(minionJobsFailed) FROM SyntheticsPrivateMinion WHERE minionIsPrivate IS TRUE%s " +
+	"FACET minionLocation, minionId SINCE %d minutes ago LIMIT MAX"
+
+func dataSourceNewRelicSyntheticsPrivateLocationHealth() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicSyntheticsPrivateLocationHealthRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The ID of the account in New Relic.",
+			},
+			"location_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.NoZeroValues},
+				Description: "The location IDs of the private locations, e.g. the location_id of newrelic_synthetics_private_location. Defaults to the private locations with minions reporting within the window.",
+			},
+			"window": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntBetween(1, 1440),
+				Description:  "The number of minutes the minions must have reported within to be listed, and failed jobs are counted over.",
+			},
+			"heartbeat_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of minutes after their last heartbeat minions are considered disconnected.",
+			},
+			"locations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The health of the private locations.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"location_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The location ID of the private location.",
+						},
+						"connected": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether at least one minion of the private location is connected.",
+						},
+						"connected_minions": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of connected minions.",
+						},
+						"last_heartbeat_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The last heartbeat of the minions, in RFC3339 format.",
+						},
+						"versions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The distinct versions of the connected minions.",
+						},
+						"queue_depth": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of jobs queued by the connected minions.",
+						},
+						"jobs_failed": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of jobs which failed within the window.",
+						},
+						"minions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The minions and job managers which reported within the window.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The ID of the minion.",
+									},
+									"hostname": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The hostname of the minion.",
+									},
+									"version": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The version of the minion.",
+									},
+									"connected": {
+										Type:        schema.TypeBool,
+										Computed:    true,
+										Description: "Whether the minion sent a heartbeat within the heartbeat timeout.",
+									},
+									"last_heartbeat_at": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The last heartbeat of the minion, in RFC3339 format.",
+									},
+									"queue_depth": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The number of jobs queued by the minion.",
+									},
+									"jobs_failed": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The number of jobs of the minion which failed within the window.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicSyntheticsPrivateLocationHealthRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading the health of Synthetics private locations")
+
+	locationIDs := expandStringSlice(d.Get("location_ids").([]interface{}))
+
+	where := ""
+	if len(locationIDs) > 0 {
+		quoted := make([]string, len(locationIDs))
+		for i, id := range locationIDs {
+			quoted[i] = nrqlQuote(id)
+		}
+		where = fmt.Sprintf(" AND minionLocation IN (%s)", strings.Join(quoted, ", "))
+	}
+
+	query := nrdb.NRQL(fmt.Sprintf(syntheticsPrivateLocationHealthQuery, where, d.Get("window").(int)))
+	result, err := client.Nrdb.QueryWithContext(ctx, accountID, query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	timeout := time.Duration(d.Get("heartbeat_timeout").(int)) * time.Minute
+	locations := flattenSyntheticsPrivateLocationHealth(locationIDs, result.Results, time.Now().Add(-timeout))
+
+	d.SetId(strconv.Itoa(accountID))
+
+	return diag.FromErr(d.Set("locations", locations))
+}
+
+// flattenSyntheticsPrivateLocationHealth aggregates the minions of the results of syntheticsPrivateLocationHealthQuery
+// by location, in the order of the given location IDs, listing the requested locations without minions as disconnected.
+// Minions are connected when their last heartbeat is after the given time.
+func flattenSyntheticsPrivateLocationHealth(locationIDs []string, results []nrdb.NRDBResult, connectedAfter time.Time) []interface{} {
+	locations := map[string]map[string]interface{}{}
+	var order []string
+
+	location := func(id string) map[string]interface{} {
+		if l, ok := locations[id]; ok {
+			return l
+		}
+		l := map[string]interface{}{
+			"location_id":       id,
+			"connected":         false,
+			"connected_minions": 0,
+			"last_heartbeat_at": "",
+			"versions":          []interface{}{},
+			"queue_depth":       0,
+			"jobs_failed":       0,
+			"minions":           []interface{}{},
+		}
+		locations[id] = l
+		return l
+	}
+	for _, id := range locationIDs {
+		if _, ok := locations[id]; !ok {
+			order = append(order, id)
+			location(id)
+		}
+	}
+
+	var discovered []string
+	lastHeartbeats := map[string]float64{}
+	versions := map[string][]string{}
+
+	for _, r := range results {
+		locationID := syntheticsPrivateLocationHealthFacet(r, "minionLocation", 0)
+		if locationID == "" {
+			continue
+		}
+		if _, ok := locations[locationID]; !ok {
+			discovered = append(discovered, locationID)
+		}
+		l := location(locationID)
+
+		heartbeat, _ := r["latest.timestamp"].(float64)
+		queued, _ := r["latest.minionJobsQueued"].(float64)
+		earliestFailed, _ := r["earliest.minionJobsFailed"].(float64)
+		latestFailed, _ := r["latest.minionJobsFailed"].(float64)
+
+		// The counter restarts with the minion
+		failed := latestFailed - earliestFailed
+		if failed < 0 {
+			failed = latestFailed
+		}
+
+		connected := time.UnixMilli(int64(heartbeat)).After(connectedAfter)
+		version := syntheticsPrivateLocationHealthString(r["latest.minionBuildNumber"])
+
+		minion := map[string]interface{}{
+			"id":                syntheticsPrivateLocationHealthFacet(r, "minionId", 1),
+			"hostname":          syntheticsPrivateLocationHealthString(r["latest.minionHostname"]),
+			"version":           version,
+			"connected":         connected,
+			"last_heartbeat_at": "",
+			"queue_depth":       0,
+			"jobs_failed":       int(failed),
+		}
+		if heartbeat > 0 {
+			minion["last_heartbeat_at"] = time.UnixMilli(int64(heartbeat)).UTC().Format(time.RFC3339)
+		}
+		if heartbeat > lastHeartbeats[locationID] {
+			lastHeartbeats[locationID] = heartbeat
+			l["last_heartbeat_at"] = minion["last_heartbeat_at"]
+		}
+
+		l["jobs_failed"] = l["jobs_failed"].(int) + int(failed)
+
+		if connected {
+			minion["queue_depth"] = int(queued)
+			l["connected"] = true
+			l["connected_minions"] = l["connected_minions"].(int) + 1
+			l["queue_depth"] = l["queue_depth"].(int) + int(queued)
+			if version != "" && !stringInSlice(versions[locationID], version) {
+				versions[locationID] = append(versions[locationID], version)
+			}
+		}
+
+		l["minions"] = append(l["minions"].([]interface{}), minion)
+	}
+
+	sort.Strings(discovered)
+	order = append(order, discovered...)
+
+	out := make([]interface{}, 0, len(order))
+	for _, id := range order {
+		l := locations[id]
+
+		// Sorted so that the lists only change when minions come and go
+		sort.Strings(versions[id])
+		for _, v := range versions[id] {
+			l["versions"] = append(l["versions"].([]interface{}), v)
+		}
+
+		minions := l["minions"].([]interface{})
+		sort.Slice(minions, func(i, j int) bool {
+			return minions[i].(map[string]interface{})["id"].(string) < minions[j].(map[string]interface{})["id"].(string)
+		})
+
+		out = append(out, l)
+	}
+
+	return out
+}
+
+// The attributes are strings or numbers depending on how the minion reported them.
+func syntheticsPrivateLocationHealthString(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	return ""
+}
+
+// The facets are returned both as named attributes and in order in the facet attribute.
+func syntheticsPrivateLocationHealthFacet(r nrdb.NRDBResult, name string, index int) string {
+	if v := syntheticsPrivateLocationHealthString(r[name]); v != "" {
+		return v
+	}
+	if facets, ok := r["facet"].([]interface{}); ok && index < len(facets) {
+		return syntheticsPrivateLocationHealthString(facets[index])
+	}
+
+	return ""
+}
@@ -0,0 +1,45 @@
+//go:build integration || SYNTHETICS
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicSyntheticsPrivateLocationHealthDataSource_Basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// A new location has no minions, so it is listed as disconnected
+				Config: testAccNewRelicSyntheticsPrivateLocationHealthDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.newrelic_synthetics_private_location_health.foo", "locations.#", "1"),
+					resource.TestCheckResourceAttrPair("data.newrelic_synthetics_private_location_health.foo", "locations.0.location_id", "newrelic_synthetics_private_location.foo", "location_id"),
+					resource.TestCheckResourceAttr("data.newrelic_synthetics_private_location_health.foo", "locations.0.connected", "false"),
+					resource.TestCheckResourceAttr("data.newrelic_synthetics_private_location_health.foo", "locations.0.minions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicSyntheticsPrivateLocationHealthDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_synthetics_private_location" "foo" {
+  name        = "%[1]s"
+  description = "created via TF integration tests"
+}
+
+data "newrelic_synthetics_private_location_health" "foo" {
+  location_ids = [newrelic_synthetics_private_location.foo.location_id]
+}
+`, name)
+}
@@ -0,0 +1,117 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenSyntheticsPrivateLocationHealth(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	recent := float64(now.Add(-time.Minute).UnixMilli())
+	stale := float64(now.Add(-20 * time.Minute).UnixMilli())
+
+	results := []nrdb.NRDBResult{
+		{
+			"facet":                     []interface{}{"1-east", "minion-b"},
+			"minionLocation":            "1-east",
+			"minionId":                  "minion-b",
+			"latest.timestamp":          recent,
+			"latest.minionHostname":     "jm-b",
+			"latest.minionBuildNumber":  "release-412",
+			"latest.minionJobsQueued":   float64(3),
+			"earliest.minionJobsFailed": float64(10),
+			"latest.minionJobsFailed":   float64(12),
+		},
+		{
+			// Named facet attributes missing, and a restarted minion
+			"facet":                     []interface{}{"1-east", "minion-a"},
+			"latest.timestamp":          recent,
+			"latest.minionHostname":     "jm-a",
+			"latest.minionBuildNumber":  float64(411),
+			"latest.minionJobsQueued":   float64(1),
+			"earliest.minionJobsFailed": float64(40),
+			"latest.minionJobsFailed":   float64(2),
+		},
+		{
+			"minionLocation":            "1-west",
+			"minionId":                  "minion-c",
+			"latest.timestamp":          stale,
+			"latest.minionHostname":     "jm-c",
+			"latest.minionBuildNumber":  "release-412",
+			"latest.minionJobsQueued":   float64(50),
+			"earliest.minionJobsFailed": float64(0),
+			"latest.minionJobsFailed":   float64(0),
+		},
+	}
+
+	locations := flattenSyntheticsPrivateLocationHealth([]string{"1-north", "1-east", "1-north"}, results, now.Add(-5*time.Minute))
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"location_id":       "1-north",
+			"connected":         false,
+			"connected_minions": 0,
+			"last_heartbeat_at": "",
+			"versions":          []interface{}{},
+			"queue_depth":       0,
+			"jobs_failed":       0,
+			"minions":           []interface{}{},
+		},
+		map[string]interface{}{
+			"location_id":       "1-east",
+			"connected":         true,
+			"connected_minions": 2,
+			"last_heartbeat_at": "2026-01-02T03:03:00Z",
+			"versions":          []interface{}{"411", "release-412"},
+			"queue_depth":       4,
+			"jobs_failed":       4,
+			"minions": []interface{}{
+				map[string]interface{}{
+					"id":                "minion-a",
+					"hostname":          "jm-a",
+					"version":           "411",
+					"connected":         true,
+					"last_heartbeat_at": "2026-01-02T03:03:00Z",
+					"queue_depth":       1,
+					"jobs_failed":       2,
+				},
+				map[string]interface{}{
+					"id":                "minion-b",
+					"hostname":          "jm-b",
+					"version":           "release-412",
+					"connected":         true,
+					"last_heartbeat_at": "2026-01-02T03:03:00Z",
+					"queue_depth":       3,
+					"jobs_failed":       2,
+				},
+			},
+		},
+		map[string]interface{}{
+			"location_id":       "1-west",
+			"connected":         false,
+			"connected_minions": 0,
+			"last_heartbeat_at": "2026-01-02T02:44:00Z",
+			"versions":          []interface{}{},
+			"queue_depth":       0,
+			"jobs_failed":       0,
+			"minions": []interface{}{
+				map[string]interface{}{
+					"id":                "minion-c",
+					"hostname":          "jm-c",
+					"version":           "release-412",
+					"connected":         false,
+					"last_heartbeat_at": "2026-01-02T02:44:00Z",
+					"queue_depth":       0,
+					"jobs_failed":       0,
+				},
+			},
+		},
+	}, locations)
+}
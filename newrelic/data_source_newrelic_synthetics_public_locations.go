@@ -0,0 +1,142 @@
+package newrelic
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNewRelicSyntheticsPublicLocations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicSyntheticsPublicLocationsRead,
+		Schema: map[string]*schema.Schema{
+			"continents": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only list the locations of these continents, e.g. Europe.",
+			},
+			"clouds": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only list the locations of these clouds. All the public locations currently run in AWS.",
+			},
+			"select_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of locations to select among the listed ones.",
+			},
+			"selection_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      syntheticsPublicLocationsSpreadContinents,
+				ValidateFunc: validation.StringInSlice([]string{syntheticsPublicLocationsSpreadContinents}, false),
+				Description:  "How the locations are selected. Only spread_continents is supported.",
+			},
+			"locations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The public locations.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the location, as used in locations_public.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The label of the location.",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The cloud region of the location.",
+						},
+						"cloud": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The cloud of the location.",
+						},
+						"continent": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The continent of the location.",
+						},
+					},
+				},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the listed locations.",
+			},
+			"selected_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the selected locations, when select_count is set.",
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicSyntheticsPublicLocationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Reading Synthetics public locations")
+
+	continents := expandStringSlice(d.Get("continents").([]interface{}))
+	clouds := expandStringSlice(d.Get("clouds").([]interface{}))
+
+	var matching []syntheticsPublicLocation
+	for _, l := range syntheticsPublicLocationCatalog {
+		if len(continents) > 0 && !stringInSlice(continents, l.continent) {
+			continue
+		}
+		if len(clouds) > 0 && !stringInSlice(clouds, l.cloud) {
+			continue
+		}
+		matching = append(matching, l)
+	}
+
+	locations := make([]interface{}, 0, len(matching))
+	ids := make([]string, 0, len(matching))
+	for _, l := range matching {
+		locations = append(locations, map[string]interface{}{
+			"id":        string(l.id),
+			"label":     l.label,
+			"region":    l.region,
+			"cloud":     l.cloud,
+			"continent": l.continent,
+		})
+		ids = append(ids, string(l.id))
+	}
+
+	selected := []string{}
+	if count, ok := d.GetOk("select_count"); ok {
+		var err error
+		selected, err = selectSyntheticsPublicLocations(matching, count.(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(d.Get("selection_policy").(string) + ":" + strings.Join(ids, ","))))
+
+	if err := d.Set("locations", locations); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("selected_ids", selected))
+}
@@ -0,0 +1,35 @@
+//go:build integration || SYNTHETICS
+
+package newrelic
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicSyntheticsPublicLocationsDataSource_Basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicSyntheticsPublicLocationsDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.newrelic_synthetics_public_locations.foo", "locations.#", "6"),
+					resource.TestCheckResourceAttr("data.newrelic_synthetics_public_locations.foo", "locations.0.id", "EU_WEST_1"),
+					resource.TestCheckResourceAttr("data.newrelic_synthetics_public_locations.foo", "selected_ids.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicSyntheticsPublicLocationsDataSourceConfig() string {
+	return `
+data "newrelic_synthetics_public_locations" "foo" {
+  continents   = ["Europe"]
+  select_count = 3
+}
+`
+}
@@ -0,0 +1,92 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindSyntheticsPublicLocation(t *testing.T) {
+	t.Parallel()
+
+	l, ok := findSyntheticsPublicLocation("EU_WEST_1")
+	require.True(t, ok)
+	require.Equal(t, "Dublin, IE", l.label)
+
+	l, ok = findSyntheticsPublicLocation("AWS_EU_WEST_1")
+	require.True(t, ok)
+	require.Equal(t, "eu-west-1", l.region)
+
+	_, ok = findSyntheticsPublicLocation("EU_WEST_9")
+	require.False(t, ok)
+}
+
+func TestValidateSyntheticsPublicLocation(t *testing.T) {
+	t.Parallel()
+
+	warns, errs := validateSyntheticsPublicLocation("AWS_US_EAST_1", "locations_public.0")
+	require.Empty(t, warns)
+	require.Empty(t, errs)
+
+	// Locations added after the catalog was updated are not rejected.
+	warns, errs = validateSyntheticsPublicLocation("US_EAST_9", "locations_public.0")
+	require.Empty(t, errs)
+	require.Len(t, warns, 1)
+	require.Contains(t, warns[0], `locations_public.0 contains the unknown public location "US_EAST_9", expected one of US_EAST_1, `)
+}
+
+func TestResourceNewRelicSyntheticsMonitorDiff_UnknownPublicLocation(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"name":             "tf-test",
+		"type":             "SIMPLE",
+		"period":           "EVERY_MINUTE",
+		"status":           "ENABLED",
+		"uri":              "https://www.one.newrelic.com",
+		"locations_public": []interface{}{"AP_SOUTH_1", "EU_WEST_9"},
+	}
+
+	diags := resourceNewRelicSyntheticsMonitor().Validate(terraform.NewResourceConfigRaw(raw))
+	require.False(t, diags.HasError())
+	require.Len(t, diags, 1)
+	require.Equal(t, diag.Warning, diags[0].Severity)
+	require.Contains(t, diags[0].Summary, `"EU_WEST_9"`)
+}
+
+func TestSelectSyntheticsPublicLocations(t *testing.T) {
+	t.Parallel()
+
+	selected, err := selectSyntheticsPublicLocations(syntheticsPublicLocationCatalog, 6)
+	require.NoError(t, err)
+	require.Equal(t, []string{"AF_SOUTH_1", "AP_EAST_1", "AP_SOUTHEAST_2", "EU_WEST_1", "SA_EAST_1", "US_EAST_1"}, selected)
+
+	selected, err = selectSyntheticsPublicLocations(syntheticsPublicLocationCatalog, 8)
+	require.NoError(t, err)
+	require.Equal(t, []string{"AF_SOUTH_1", "AP_EAST_1", "AP_SOUTHEAST_2", "EU_WEST_1", "EU_WEST_2", "SA_EAST_1", "US_EAST_1", "US_EAST_2"}, selected)
+
+	_, err = selectSyntheticsPublicLocations(syntheticsPublicLocationCatalog[:3], 4)
+	require.EqualError(t, err, "4 locations can't be selected from the 3 matching locations")
+}
+
+func TestDataSourceNewRelicSyntheticsPublicLocationsRead(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicSyntheticsPublicLocations().Schema, map[string]interface{}{
+		"continents":   []interface{}{"Europe", "South America"},
+		"select_count": 2,
+	})
+
+	diags := dataSourceNewRelicSyntheticsPublicLocationsRead(context.Background(), d, nil)
+	require.False(t, diags.HasError())
+	require.Equal(t, 7, d.Get("locations.#"))
+	require.Equal(t, "Dublin, IE", d.Get("locations.0.label"))
+	require.Equal(t, []interface{}{"EU_WEST_1", "SA_EAST_1"}, d.Get("selected_ids"))
+	require.NotEmpty(t, d.Id())
+}
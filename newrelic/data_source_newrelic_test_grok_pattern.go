@@ -25,6 +25,12 @@ func dataSourceNewRelicTestGrokPattern() *schema.Resource {
 				Required:    true,
 				Description: "The Grok pattern to test.",
 			},
+			"offline": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to test the Grok pattern with the built-in offline engine instead of New Relic, which requires no credentials.",
+			},
 			"log_lines": {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
@@ -88,8 +94,14 @@ func dataSourceNewRelicTestGrokRead(ctx context.Context, d *schema.ResourceData,
 		perms[i] = line.(string)
 	}
 
-	res, err := client.Logconfigurations.GetTestGrokWithContext(ctx, accountID, d.Get("grok").(string),
-		perms)
+	var res *[]logconfigurations.LogConfigurationsGrokTestResult
+	var err error
+	if d.Get("offline").(bool) {
+		res, err = testGrokPatternOffline(d.Get("grok").(string), perms)
+	} else {
+		res, err = client.Logconfigurations.GetTestGrokWithContext(ctx, accountID, d.Get("grok").(string),
+			perms)
+	}
 
 	if err != nil {
 		return diag.FromErr(err)
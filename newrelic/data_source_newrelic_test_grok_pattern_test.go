@@ -3,12 +3,18 @@
 package newrelic
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"reflect"
 	"regexp"
+	"sort"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/logconfigurations"
 )
 
 func TestAccNewRelicTestGrokDataSource_Basic(t *testing.T) {
@@ -42,6 +48,91 @@ func TestAccNewRelicTestGrokDataSource_InvalidGrokPattern(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicTestGrokDataSource_Offline(t *testing.T) {
+	resourceName := "data.newrelic_test_grok_pattern.grok"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicTestGrokOfflineDataSourceConfig("%%%%{IP:host_ip}"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "test_grok.0.matched", "true"),
+					resource.TestCheckResourceAttr(resourceName, "test_grok.0.attributes.0.name", "host_ip"),
+					resource.TestCheckResourceAttr(resourceName, "test_grok.0.attributes.0.value", "43.3.120.2"),
+					testAccCheckNewRelicTestGrokOfflineParity(),
+				),
+			},
+			{
+				Config:      testAccNewRelicTestGrokOfflineDataSourceConfig("%%%%{IP:host_ip:integer}"),
+				ExpectError: regexp.MustCompile("invalid attribute type"),
+			},
+		},
+	})
+}
+
+// Compares the results of the offline engine with the results of New Relic for the unit test fixtures.
+func testAccCheckNewRelicTestGrokOfflineParity() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+		raw, err := os.ReadFile("testdata/grok_test_results.json")
+		if err != nil {
+			return err
+		}
+
+		var fixtures []struct {
+			Grok     string   `json:"grok"`
+			LogLines []string `json:"logLines"`
+		}
+		if err := json.Unmarshal(raw, &fixtures); err != nil {
+			return err
+		}
+
+		for _, fixture := range fixtures {
+			expected, err := client.Logconfigurations.GetTestGrokWithContext(context.Background(), testAccountID, fixture.Grok, fixture.LogLines)
+			if err != nil {
+				return err
+			}
+
+			actual, err := testGrokPatternOffline(fixture.Grok, fixture.LogLines)
+			if err != nil {
+				return err
+			}
+
+			if !reflect.DeepEqual(sortTestGrokResults(*expected), sortTestGrokResults(*actual)) {
+				return fmt.Errorf("offline results for %q differ from New Relic:\nexpected: %+v\nactual:   %+v", fixture.Grok, *expected, *actual)
+			}
+		}
+
+		return nil
+	}
+}
+
+func sortTestGrokResults(results []logconfigurations.LogConfigurationsGrokTestResult) []logconfigurations.LogConfigurationsGrokTestResult {
+	for i := range results {
+		if len(results[i].Attributes) == 0 {
+			results[i].Attributes = nil
+		}
+		sort.Slice(results[i].Attributes, func(a, b int) bool {
+			return results[i].Attributes[a].Name < results[i].Attributes[b].Name
+		})
+	}
+
+	return results
+}
+
+func testAccNewRelicTestGrokOfflineDataSourceConfig(grok string) string {
+	return fmt.Sprintf(`
+data "newrelic_test_grok_pattern" "grok"{
+	offline   = true
+	grok      = "%s"
+	log_lines = ["43.3.120.2"]
+}
+`, grok)
+}
+
 func testAccNewRelicTestGrokInvalidPatternDataSourceConfig() string {
 	return fmt.Sprintf(`
 data "newrelic_test_grok_pattern" "grok"{
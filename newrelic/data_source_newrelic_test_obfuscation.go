@@ -0,0 +1,206 @@
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/logconfigurations"
+)
+
+// The attribute log lines are tested against.
+const testObfuscationLogLineAttribute = "message"
+
+func dataSourceNewRelicTestObfuscation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicTestObfuscationRead,
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The NRQL condition of the obfuscation rule. Records not matching it are left unchanged.",
+			},
+			"action": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The actions of the obfuscation rule, applied in order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attribute": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Attribute names for action. An empty list applies the action to all the attributes.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"regex": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The regex of the obfuscation expression.",
+							ValidateFunc: validateObfuscationExpressionRegex,
+						},
+						"method": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Obfuscation method to use.",
+							ValidateFunc: validation.StringInSlice(listValidLogConfigurationsObfuscationMethod(), false),
+						},
+					},
+				},
+			},
+			"log_lines": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MinItems:     1,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Description:  "The log lines to obfuscate, as the message attribute of a record.",
+				ExactlyOneOf: []string{"log_lines", "records"},
+			},
+			"records": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MinItems:    1,
+				Description: "The log records to obfuscate, as JSON objects.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+				ExactlyOneOf: []string{"log_lines", "records"},
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The result of the obfuscation of each log line or record, in order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"input": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The log line or record.",
+						},
+						"matched": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the record matched the filter.",
+						},
+						"output": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The obfuscated log line, or the obfuscated record as a JSON object.",
+						},
+						"obfuscated_attributes": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The attributes modified by the actions.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicTestObfuscationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Testing obfuscation actions against log records")
+
+	filter, err := parseNRQLQuery(d.Get("filter").(string))
+	if err != nil {
+		return diag.Errorf("filter: %s", err)
+	}
+
+	actions, err := expandTestObfuscationActions(d.Get("action").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var results []interface{}
+	if lines, ok := d.GetOk("log_lines"); ok {
+		for _, l := range lines.([]interface{}) {
+			line := l.(string)
+			record := map[string]string{testObfuscationLogLineAttribute: line}
+
+			matched, modified := testObfuscation(filter, actions, record)
+			results = append(results, flattenTestObfuscationResult(line, matched, record[testObfuscationLogLineAttribute], modified))
+		}
+	} else {
+		for _, r := range d.Get("records").([]interface{}) {
+			input := r.(string)
+			record, err := expandLogPipelineRecord(input)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			matched, modified := testObfuscation(filter, actions, record)
+			output, err := json.Marshal(record)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			results = append(results, flattenTestObfuscationResult(input, matched, string(output), modified))
+		}
+	}
+
+	d.SetId(strconv.Itoa(rand.Int()))
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func expandTestObfuscationActions(in []interface{}) ([]obfuscationAction, error) {
+	actions := make([]obfuscationAction, len(in))
+	for i, a := range in {
+		cfg := a.(map[string]interface{})
+
+		var attributes []string
+		if v, ok := cfg["attribute"]; ok && v != nil {
+			for _, attribute := range v.(*schema.Set).List() {
+				attributes = append(attributes, attribute.(string))
+			}
+		}
+
+		action, err := newObfuscationAction(cfg["regex"].(string), logconfigurations.LogConfigurationsObfuscationMethod(cfg["method"].(string)), attributes)
+		if err != nil {
+			return nil, fmt.Errorf("action %d: %w", i, err)
+		}
+		actions[i] = *action
+	}
+
+	return actions, nil
+}
+
+// Applies the actions of an obfuscation rule to a record when it matches the filter, and returns the modified attributes.
+func testObfuscation(filter *nrqlQuery, actions []obfuscationAction, record map[string]string) (bool, []string) {
+	if !filter.matches(record) {
+		return false, []string{}
+	}
+
+	modified := []string{}
+	seen := map[string]bool{}
+	for _, a := range actions {
+		for _, attribute := range a.apply(record) {
+			if !seen[attribute] {
+				seen[attribute] = true
+				modified = append(modified, attribute)
+			}
+		}
+	}
+
+	return true, modified
+}
+
+func flattenTestObfuscationResult(input string, matched bool, output string, modified []string) map[string]interface{} {
+	return map[string]interface{}{
+		"input":                 input,
+		"matched":               matched,
+		"output":                output,
+		"obfuscated_attributes": modified,
+	}
+}
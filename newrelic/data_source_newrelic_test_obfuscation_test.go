@@ -0,0 +1,75 @@
+//go:build integration || LOGGING_INTEGRATIONS
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicTestObfuscationDataSource_Basic(t *testing.T) {
+	resourceName := "data.newrelic_test_obfuscation.foo"
+	rName := acctest.RandString(7)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicObfuscationExpressionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicTestObfuscationDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "results.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "results.0.output", "ssn=XXXXXXXXXXX"),
+					resource.TestCheckResourceAttr(resourceName, "results.0.obfuscated_attributes.0", "message"),
+					resource.TestCheckResourceAttr(resourceName, "results.1.output", "no pii here"),
+					resource.TestCheckResourceAttr(resourceName, "results.1.obfuscated_attributes.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicTestObfuscationDataSource_InvalidRegex(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "newrelic_test_obfuscation" "foo" {
+	log_lines = ["ssn=123-45-6789"]
+	action {
+		regex  = "(\\w)\\1"
+		method = "MASK"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile("invalid RE2 regular expression"),
+			},
+		},
+	})
+}
+
+func testAccNewRelicTestObfuscationDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_obfuscation_expression" "foo" {
+	account_id = %[1]d
+	name       = "%[2]s"
+	regex      = "ssn=(\\d{3}-\\d{2}-\\d{4})"
+}
+
+data "newrelic_test_obfuscation" "foo" {
+	log_lines = ["ssn=123-45-6789", "no pii here"]
+	action {
+		attribute = ["message"]
+		regex     = newrelic_obfuscation_expression.foo.regex
+		method    = "MASK"
+	}
+}
+`, testAccountID, name)
+}
@@ -0,0 +1,288 @@
+package newrelic
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNewRelicWorkloadStatusSimulation() *schema.Resource {
+	workloadSchema := resourceNewRelicWorkload().Schema
+
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicWorkloadStatusSimulationRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The New Relic account ID of the workload.",
+			},
+			"entity_guids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A list of entity GUIDs manually assigned to the workload.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"entity_search_query": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A list of search queries that define a dynamic workload.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"query": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "A valid entity search query.",
+							ValidateFunc: validateEntitySearchQuery,
+						},
+					},
+				},
+			},
+			"scope_account_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A list of account IDs that will be used to get entities from. Defaults to account_id.",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"status_config_automatic": workloadSchema["status_config_automatic"],
+			"status_config_static":    workloadSchema["status_config_static"],
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The simulated status of the workload: OPERATIONAL, DEGRADED, DISRUPTED or UNKNOWN.",
+			},
+			"status_source": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The configuration the status was derived from: STATIC, AUTOMATIC or NONE.",
+			},
+			"entity": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The entities of the workload along with their current status.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"guid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"account_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"alert_severity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"rule_result": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The simulated status of each rule of status_config_automatic.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"entity_guids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"remaining_entities_result": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The simulated status of each group of the remaining entities rule.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"entity_guids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicWorkloadStatusSimulationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Simulating New Relic One workload status")
+
+	scopeAccountIDs := []int{accountID}
+	if v, ok := d.GetOk("scope_account_ids"); ok && v.(*schema.Set).Len() > 0 {
+		scopeAccountIDs = expandWorkloadStatusScopeAccountIDs(v.(*schema.Set).List())
+	}
+
+	workloadEntities, err := resolveWorkloadStatusEntities(ctx, client, d.Get("entity_guids").(*schema.Set).List(), workloadStatusQueries(d.Get("entity_search_query").(*schema.Set).List()), scopeAccountIDs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	simulation := workloadStatusSimulation{Status: workloadStatusUnknown}
+	statusSource := "NONE"
+
+	static := expandWorkloadStatusConfigStaticInput(d.Get("status_config_static").(*schema.Set).List())
+	automatic := d.Get("status_config_automatic").(*schema.Set).List()
+
+	if len(static) > 0 && static[0].Enabled {
+		simulation.Status = string(static[0].Status)
+		statusSource = "STATIC"
+	} else if len(automatic) > 0 && automatic[0].(map[string]interface{})["enabled"].(bool) {
+		cfg := automatic[0].(map[string]interface{})
+
+		var rules []workloadStatusRule
+		for _, r := range cfg["rule"].(*schema.Set).List() {
+			rule := r.(map[string]interface{})
+
+			ruleEntities, ruleErr := resolveWorkloadStatusEntities(ctx, client, rule["entity_guids"].(*schema.Set).List(), workloadStatusQueries(rule["nrql_query"].(*schema.Set).List()), scopeAccountIDs)
+			if ruleErr != nil {
+				return diag.FromErr(ruleErr)
+			}
+
+			rules = append(rules, workloadStatusRule{
+				Rollup:   expandWorkloadStatusRollup(rule["rollup"].(*schema.Set).List()),
+				Entities: ruleEntities,
+			})
+		}
+
+		var remaining *workloadStatusRemainingRule
+		if x := cfg["remaining_entities_rule"].(*schema.Set).List(); len(x) > 0 {
+			rollups := x[0].(map[string]interface{})["remaining_entities_rule_rollup"].(*schema.Set).List()
+			remaining = &workloadStatusRemainingRule{
+				Rollup: expandWorkloadStatusRollup(rollups),
+			}
+			if len(rollups) > 0 {
+				remaining.GroupBy = rollups[0].(map[string]interface{})["group_by"].(string)
+			}
+		}
+
+		simulation = simulateWorkloadAutomaticStatus(workloadEntities, rules, remaining)
+		statusSource = "AUTOMATIC"
+	}
+
+	d.SetId(strconv.Itoa(accountID))
+	_ = d.Set("account_id", accountID)
+	_ = d.Set("status", simulation.Status)
+	_ = d.Set("status_source", statusSource)
+
+	if err := d.Set("entity", flattenWorkloadStatusEntities(workloadEntities)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("rule_result", flattenWorkloadStatusRuleResults(simulation.RuleResults)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("remaining_entities_result", flattenWorkloadStatusGroupResults(simulation.RemainingGroups)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func workloadStatusQueries(cfg []interface{}) []string {
+	queries := make([]string, 0, len(cfg))
+	for _, q := range cfg {
+		queries = append(queries, q.(map[string]interface{})["query"].(string))
+	}
+
+	return queries
+}
+
+func expandWorkloadStatusScopeAccountIDs(cfg []interface{}) []int {
+	ids := make([]int, len(cfg))
+	for i, id := range cfg {
+		ids[i] = id.(int)
+	}
+
+	return ids
+}
+
+func expandWorkloadStatusRollup(cfg []interface{}) workloadStatusRollup {
+	rollup := workloadStatusRollup{}
+	for _, v := range cfg {
+		m := v.(map[string]interface{})
+		rollup.Strategy = m["strategy"].(string)
+		rollup.ThresholdType = m["threshold_type"].(string)
+		rollup.ThresholdValue = m["threshold_value"].(int)
+	}
+
+	return rollup
+}
+
+func flattenWorkloadStatusEntities(in []workloadStatusEntity) []interface{} {
+	out := make([]interface{}, len(in))
+	for i, e := range in {
+		out[i] = map[string]interface{}{
+			"guid":           e.GUID,
+			"name":           e.Name,
+			"type":           e.Type,
+			"account_id":     e.AccountID,
+			"alert_severity": e.AlertSeverity,
+			"status":         e.status(),
+		}
+	}
+
+	return out
+}
+
+func flattenWorkloadStatusRuleResults(in []workloadStatusRuleResult) []interface{} {
+	out := make([]interface{}, len(in))
+	for i, r := range in {
+		out[i] = map[string]interface{}{
+			"entity_guids": r.EntityGUIDs,
+			"status":       r.Status,
+		}
+	}
+
+	return out
+}
+
+func flattenWorkloadStatusGroupResults(in []workloadStatusGroupResult) []interface{} {
+	out := make([]interface{}, len(in))
+	for i, g := range in {
+		out[i] = map[string]interface{}{
+			"group":        g.Group,
+			"entity_guids": g.EntityGUIDs,
+			"status":       g.Status,
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,83 @@
+//go:build integration || WORKLOADS
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicWorkloadStatusSimulationDataSource_Basic(t *testing.T) {
+	resourceName := "data.newrelic_workload_status_simulation.preview"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckEnvVars(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicWorkloadStatusSimulationDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status_source", "AUTOMATIC"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+					resource.TestCheckResourceAttr(resourceName, "rule_result.#", "1"),
+				),
+			},
+			{
+				Config: testAccNewRelicWorkloadStatusSimulationDataSourceStaticConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status_source", "STATIC"),
+					resource.TestCheckResourceAttr(resourceName, "status", "DEGRADED"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicWorkloadStatusSimulationDataSourceConfig() string {
+	return fmt.Sprintf(`
+data "newrelic_workload_status_simulation" "preview" {
+	account_id = %[1]d
+
+	entity_search_query {
+		query = "domain = 'APM' AND type = 'APPLICATION'"
+	}
+
+	status_config_automatic {
+		enabled = true
+		rule {
+			nrql_query {
+				query = "domain = 'APM' AND type = 'APPLICATION'"
+			}
+			rollup {
+				strategy = "WORST_STATUS_WINS"
+			}
+		}
+		remaining_entities_rule {
+			remaining_entities_rule_rollup {
+				strategy = "BEST_STATUS_WINS"
+				group_by = "ENTITY_TYPE"
+			}
+		}
+	}
+}
+`, testAccountID)
+}
+
+func testAccNewRelicWorkloadStatusSimulationDataSourceStaticConfig() string {
+	return fmt.Sprintf(`
+data "newrelic_workload_status_simulation" "preview" {
+	account_id = %[1]d
+
+	entity_search_query {
+		query = "domain = 'APM' AND type = 'APPLICATION'"
+	}
+
+	status_config_static {
+		enabled = true
+		status  = "DEGRADED"
+	}
+}
+`, testAccountID)
+}
@@ -0,0 +1,372 @@
+package newrelic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// A minimal parser for the entity search query syntax used by workloads, e.g.
+//
+//	domain IN ('APM', 'BROWSER') AND (tags.env = 'prod' OR name LIKE '%checkout%')
+//
+// It only checks the structure of the query (conditions, operators, values,
+// parentheses and boolean operators); whether attributes exist is left to NerdGraph,
+// as is the syntax the parser doesn't know about, which is only reported with a warning.
+
+type entitySearchTokenKind int
+
+const (
+	entitySearchTokenEOF entitySearchTokenKind = iota
+	entitySearchTokenIdentifier
+	entitySearchTokenKeyword
+	entitySearchTokenString
+	entitySearchTokenNumber
+	entitySearchTokenOperator
+	entitySearchTokenLeftParen
+	entitySearchTokenRightParen
+	entitySearchTokenComma
+)
+
+var entitySearchKeywords = []string{"AND", "OR", "NOT", "IN", "LIKE", "IS", "NULL", "TRUE", "FALSE"}
+
+type entitySearchToken struct {
+	kind  entitySearchTokenKind
+	value string
+	pos   int
+}
+
+func (t entitySearchToken) String() string {
+	if t.kind == entitySearchTokenEOF {
+		return "end of query"
+	}
+
+	return fmt.Sprintf("%q at position %d", t.value, t.pos+1)
+}
+
+func tokenizeEntitySearchQuery(query string) ([]entitySearchToken, error) {
+	var tokens []entitySearchToken
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, entitySearchToken{kind: entitySearchTokenLeftParen, value: "(", pos: i})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, entitySearchToken{kind: entitySearchTokenRightParen, value: ")", pos: i})
+			i++
+
+		case r == ',':
+			tokens = append(tokens, entitySearchToken{kind: entitySearchTokenComma, value: ",", pos: i})
+			i++
+
+		case r == '\'' || r == '"':
+			start := i
+			var value strings.Builder
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					value.WriteRune(runes[i+1])
+					i++
+					continue
+				}
+				if runes[i] == r {
+					// A doubled quote is an escaped quote, e.g. 'O''Reilly'
+					if i+1 < len(runes) && runes[i+1] == r {
+						value.WriteRune(r)
+						i++
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				value.WriteRune(runes[i])
+			}
+			if !closed {
+				return nil, entitySearchQueryMalformedError{fmt.Errorf("unterminated string starting at position %d", start+1)}
+			}
+			tokens = append(tokens, entitySearchToken{kind: entitySearchTokenString, value: value.String(), pos: start})
+
+		case r == '`' || unicode.IsLetter(r) || r == '_':
+			start := i
+			word, quoted, err := scanEntitySearchAttribute(runes, &i)
+			if err != nil {
+				return nil, err
+			}
+			if !quoted && stringInSlice(entitySearchKeywords, strings.ToUpper(word)) {
+				tokens = append(tokens, entitySearchToken{kind: entitySearchTokenKeyword, value: strings.ToUpper(word), pos: start})
+			} else {
+				tokens = append(tokens, entitySearchToken{kind: entitySearchTokenIdentifier, value: word, pos: start})
+			}
+
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			start := i
+			op := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')) {
+				op += string(runes[i+1])
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected character '!' at position %d", start+1)
+			}
+			tokens = append(tokens, entitySearchToken{kind: entitySearchTokenOperator, value: op, pos: start})
+			i += len(op)
+
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i++; i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.'); i++ {
+			}
+			tokens = append(tokens, entitySearchToken{kind: entitySearchTokenNumber, value: string(runes[start:i]), pos: start})
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i+1)
+		}
+	}
+
+	return append(tokens, entitySearchToken{kind: entitySearchTokenEOF, pos: len(runes)}), nil
+}
+
+// An error which makes a query invalid whatever the syntax accepted by NerdGraph, such as an unterminated string.
+type entitySearchQueryMalformedError struct {
+	error
+}
+
+func isEntitySearchAttributeRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// scanEntitySearchAttribute scans an attribute name made of segments separated by dots, each either a word,
+// which may contain hyphens, e.g. tags.k8s.cluster-name, or backticked, e.g. tags.`team name`. The backticks
+// are removed from the name. The first segment starts with a letter, an underscore or a backtick, and a dot
+// is only part of the name when another segment follows it. Words which are not backticked may be keywords.
+func scanEntitySearchAttribute(runes []rune, i *int) (string, bool, error) {
+	var segments []string
+	quoted := false
+
+	for {
+		start := *i
+		if start < len(runes) && runes[start] == '`' {
+			for *i++; *i < len(runes) && runes[*i] != '`'; *i++ {
+			}
+			if *i == len(runes) {
+				return "", false, entitySearchQueryMalformedError{fmt.Errorf("unterminated backtick starting at position %d", start+1)}
+			}
+			if *i == start+1 {
+				return "", false, entitySearchQueryMalformedError{fmt.Errorf("empty attribute name at position %d", start+1)}
+			}
+			segments = append(segments, string(runes[start+1:*i]))
+			quoted = true
+			*i++
+		} else {
+			for ; *i < len(runes); *i++ {
+				if isEntitySearchAttributeRune(runes[*i]) {
+					continue
+				}
+				if runes[*i] == '-' && *i > start && *i+1 < len(runes) && isEntitySearchAttributeRune(runes[*i+1]) {
+					continue
+				}
+				break
+			}
+			segments = append(segments, string(runes[start:*i]))
+		}
+
+		if *i+1 < len(runes) && runes[*i] == '.' && (runes[*i+1] == '`' || isEntitySearchAttributeRune(runes[*i+1])) {
+			*i++
+			continue
+		}
+
+		return strings.Join(segments, "."), quoted || len(segments) > 1, nil
+	}
+}
+
+type entitySearchQueryParser struct {
+	tokens []entitySearchToken
+	pos    int
+}
+
+func (p *entitySearchQueryParser) peek() entitySearchToken {
+	return p.tokens[p.pos]
+}
+
+func (p *entitySearchQueryParser) next() entitySearchToken {
+	t := p.tokens[p.pos]
+	if t.kind != entitySearchTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *entitySearchQueryParser) acceptKeyword(keyword string) bool {
+	if t := p.peek(); t.kind == entitySearchTokenKeyword && t.value == keyword {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expression := term { OR term }
+func (p *entitySearchQueryParser) parseExpression() error {
+	if err := p.parseTerm(); err != nil {
+		return err
+	}
+	for p.acceptKeyword("OR") {
+		if err := p.parseTerm(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// term := factor { AND factor }
+func (p *entitySearchQueryParser) parseTerm() error {
+	if err := p.parseFactor(); err != nil {
+		return err
+	}
+	for p.acceptKeyword("AND") {
+		if err := p.parseFactor(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// factor := [NOT] ( "(" expression ")" | condition )
+func (p *entitySearchQueryParser) parseFactor() error {
+	p.acceptKeyword("NOT")
+
+	if p.peek().kind == entitySearchTokenLeftParen {
+		p.next()
+		if err := p.parseExpression(); err != nil {
+			return err
+		}
+		if t := p.next(); t.kind != entitySearchTokenRightParen {
+			return fmt.Errorf("expected ')' but found %s", t)
+		}
+		return nil
+	}
+
+	return p.parseCondition()
+}
+
+// condition := attribute ( operator value | [NOT] LIKE string | [NOT] IN list | IS [NOT] ( NULL | TRUE | FALSE ) )
+func (p *entitySearchQueryParser) parseCondition() error {
+	attribute := p.next()
+	if attribute.kind != entitySearchTokenIdentifier {
+		return fmt.Errorf("expected an attribute name but found %s", attribute)
+	}
+
+	t := p.next()
+	switch {
+	case t.kind == entitySearchTokenOperator:
+		return p.parseValue(t)
+
+	case t.kind == entitySearchTokenKeyword && t.value == "IS":
+		p.acceptKeyword("NOT")
+		if !p.acceptKeyword("NULL") && !p.acceptKeyword("TRUE") && !p.acceptKeyword("FALSE") {
+			return fmt.Errorf("expected NULL, TRUE or FALSE but found %s", p.peek())
+		}
+		return nil
+
+	case t.kind == entitySearchTokenKeyword && (t.value == "NOT" || t.value == "LIKE" || t.value == "IN"):
+		operator := t
+		if t.value == "NOT" {
+			operator = p.next()
+			if operator.kind != entitySearchTokenKeyword || (operator.value != "LIKE" && operator.value != "IN") {
+				return fmt.Errorf("expected LIKE or IN after NOT but found %s", operator)
+			}
+		}
+
+		if operator.value == "LIKE" {
+			if v := p.next(); v.kind != entitySearchTokenString {
+				return fmt.Errorf("expected a quoted string after LIKE but found %s", v)
+			}
+			return nil
+		}
+
+		return p.parseList(operator)
+	}
+
+	return fmt.Errorf("expected an operator after attribute %q but found %s", attribute.value, t)
+}
+
+func (p *entitySearchQueryParser) parseValue(after entitySearchToken) error {
+	v := p.next()
+	switch {
+	case v.kind == entitySearchTokenString, v.kind == entitySearchTokenNumber:
+		return nil
+	case v.kind == entitySearchTokenKeyword && (v.value == "TRUE" || v.value == "FALSE"):
+		return nil
+	}
+
+	return fmt.Errorf("expected a value after %q but found %s", after.value, v)
+}
+
+// list := "(" value { "," value } ")"
+func (p *entitySearchQueryParser) parseList(after entitySearchToken) error {
+	if t := p.next(); t.kind != entitySearchTokenLeftParen {
+		return fmt.Errorf("expected '(' after IN but found %s", t)
+	}
+
+	for {
+		if err := p.parseValue(after); err != nil {
+			return err
+		}
+
+		t := p.next()
+		if t.kind == entitySearchTokenRightParen {
+			return nil
+		}
+		if t.kind != entitySearchTokenComma {
+			return fmt.Errorf("expected ',' or ')' but found %s", t)
+		}
+	}
+}
+
+// parseEntitySearchQuery checks that the query is a well-formed entity search query.
+func parseEntitySearchQuery(query string) error {
+	if strings.TrimSpace(query) == "" {
+		return entitySearchQueryMalformedError{fmt.Errorf("entity search query must not be empty")}
+	}
+
+	tokens, err := tokenizeEntitySearchQuery(query)
+	if err != nil {
+		return err
+	}
+
+	parser := &entitySearchQueryParser{tokens: tokens}
+	if err := parser.parseExpression(); err != nil {
+		return err
+	}
+
+	if t := parser.peek(); t.kind != entitySearchTokenEOF {
+		return fmt.Errorf("unexpected %s, expected AND, OR or end of query", t)
+	}
+
+	return nil
+}
+
+// validateEntitySearchQuery reports the queries which can't be parsed with a warning, since NerdGraph accepts
+// syntax the parser doesn't know about, and only rejects the ones which are malformed whatever the syntax.
+func validateEntitySearchQuery(val interface{}, key string) (warns []string, errs []error) {
+	err := parseEntitySearchQuery(val.(string))
+	if err == nil {
+		return
+	}
+
+	var malformed entitySearchQueryMalformedError
+	if errors.As(err, &malformed) {
+		errs = append(errs, fmt.Errorf("%q is not a valid entity search query: %s", key, err))
+	} else {
+		warns = append(warns, fmt.Sprintf("%q could not be checked as an entity search query and is left for NerdGraph to validate: %s", key, err))
+	}
+
+	return
+}
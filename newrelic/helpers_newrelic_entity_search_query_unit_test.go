@@ -0,0 +1,129 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"testing"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/workloads"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEntitySearchQuery(t *testing.T) {
+	valid := []string{
+		"name = 'Example application'",
+		"domain IN ('APM', 'BROWSER') AND tags.env = 'prod'",
+		"`tags.environment` = 'production' AND type = 'APPLICATION'",
+		"name NOT LIKE '%staging%' OR name LIKE 'checkout%'",
+		"tags.team IS NULL",
+		"tags.team IS NOT NULL",
+		"accountId IN (1, 2, 3)",
+		"reporting = true",
+		"NOT (domain = 'INFRA' OR domain = 'SYNTH') AND alertSeverity != 'NOT_CONFIGURED'",
+		"name = 'O\\'Reilly'",
+		"domain not in ('APM') and reporting = false",
+		"tags.`team-name` = 'x'",
+		"tags.k8s.cluster-name = 'x'",
+		"`tags`.`team name` = 'x' AND tags.`env` IN ('prod')",
+		`name = "Example application" AND tags.env IN ("prod", 'staging')`,
+		"reporting IS TRUE",
+		"reporting IS NOT FALSE",
+		"name = 'O''Reilly'",
+		`name = "say ""hi"""`,
+		"tags.team-name-2 = 'x' AND accountId > -1",
+	}
+
+	for _, query := range valid {
+		t.Run(query, func(t *testing.T) {
+			require.NoError(t, parseEntitySearchQuery(query))
+		})
+	}
+
+	invalid := map[string]string{
+		"":                                  "must not be empty",
+		"name = 'unterminated":              "unterminated string",
+		"name =":                            "expected a value",
+		"name = 'a' AND":                    "expected an attribute name",
+		"(name = 'a'":                       "expected ')'",
+		"name = 'a')":                       "unexpected \")\"",
+		"domain IN 'APM'":                   "expected '(' after IN",
+		"domain IN ('APM' 'BROWSER')":       "expected ',' or ')'",
+		"name LIKE 5":                       "expected a quoted string after LIKE",
+		"tags.team IS 'x'":                  "expected NULL, TRUE or FALSE",
+		"name 'a'":                          "expected an operator",
+		"`` = 'a'":                          "empty attribute name",
+		"name ! 'a'":                        "unexpected character '!'",
+		"name = 'a' name = 'b'":             "expected AND, OR or end of query",
+		"domain = 'APM' AND type = ;":       "unexpected character ';'",
+		"`tags.env = 'prod'":                "unterminated backtick",
+		"tags.`env = 'prod'":                "unterminated backtick",
+		`name = "unterminated`:              "unterminated string",
+		"tags. = 'x'":                       "unexpected character '.'",
+		"domain NOT = 'APM'":                "expected LIKE or IN after NOT",
+		"domain = 'APM' OR OR type = 'APP'": "expected an attribute name",
+	}
+
+	for query, message := range invalid {
+		t.Run(query, func(t *testing.T) {
+			err := parseEntitySearchQuery(query)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), message)
+		})
+	}
+}
+
+func TestValidateEntitySearchQuery(t *testing.T) {
+	_, errs := validateEntitySearchQuery("domain = 'APM'", "query")
+	require.Empty(t, errs)
+
+	// Syntax the parser doesn't know about may still be accepted by NerdGraph
+	warns, errs := validateEntitySearchQuery("domain = ", "query")
+	require.Empty(t, errs)
+	require.Len(t, warns, 1)
+	require.Contains(t, warns[0], "\"query\" could not be checked as an entity search query")
+
+	warns, errs = validateEntitySearchQuery("name = 'unterminated", "query")
+	require.Empty(t, warns)
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "\"query\" is not a valid entity search query: unterminated string")
+
+	_, errs = validateEntitySearchQuery(" ", "query")
+	require.Len(t, errs, 1)
+}
+
+func TestScopeWorkloadEntitySearchQuery(t *testing.T) {
+	require.Equal(t, "domain = 'APM'", scopeWorkloadEntitySearchQuery("domain = 'APM'", nil))
+	require.Equal(t,
+		"(domain = 'APM' OR domain = 'BROWSER') AND accountId IN (1, 2)",
+		scopeWorkloadEntitySearchQuery("domain = 'APM' OR domain = 'BROWSER'", []int{1, 2}),
+	)
+}
+
+func TestFlattenWorkloadEntitySearchQueries(t *testing.T) {
+	queries := []workloads.WorkloadEntitySearchQuery{
+		{Query: "`tags.env` = 'prod'"},
+		{Query: "domain = 'APM'"},
+	}
+
+	states := map[string]workloadEntitySearchQueryState{
+		"`tags.env` = 'prod'": {MaxEntities: 10},
+		"domain = 'APM'":      {ResolveEntities: true},
+	}
+	resolved := map[string]workloadEntitySearchQueryResult{
+		"`tags.env` = 'prod'": {EntityGUIDs: []string{"a", "b"}, Count: 2},
+	}
+
+	out := flattenWorkloadEntitySearchQueries(queries, states, resolved).([]interface{})
+	require.Len(t, out, 2)
+
+	first := out[0].(map[string]interface{})
+	require.Equal(t, 10, first["max_entities"])
+	require.Equal(t, false, first["resolve_entities"])
+	require.Equal(t, []string{"a", "b"}, first["resolved_entity_guids"])
+	require.Equal(t, 2, first["resolved_entity_count"])
+
+	second := out[1].(map[string]interface{})
+	require.Equal(t, 0, second["max_entities"])
+	require.Equal(t, true, second["resolve_entities"])
+	require.NotContains(t, second, "resolved_entity_guids")
+}
@@ -0,0 +1,413 @@
+package newrelic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/logconfigurations"
+)
+
+// An offline implementation of the Grok patterns of New Relic log parsing rules, used to validate
+// parsing rules at plan time, and to test patterns without calling NerdGraph.
+//
+// A pattern references the built-in library with `%{PATTERN_NAME:attribute_name:attribute_type(parameters)}`,
+// where the attribute name, type and parameters are optional. Patterns must match the whole log line.
+
+var grokAttributeTypes = struct {
+	String   string
+	Boolean  string
+	Int      string
+	Long     string
+	Float    string
+	Double   string
+	JSON     string
+	KeyValue string
+}{
+	String:   "string",
+	Boolean:  "boolean",
+	Int:      "int",
+	Long:     "long",
+	Float:    "float",
+	Double:   "double",
+	JSON:     "json",
+	KeyValue: "keyvalue",
+}
+
+// grokUnsupportedError reports a pattern the offline engine cannot evaluate, although New Relic
+// may accept it, i.e. a regular expression RE2 doesn't compile, such as one using lookarounds or
+// backreferences.
+type grokUnsupportedError struct {
+	reason string
+}
+
+func (e *grokUnsupportedError) Error() string {
+	return e.reason
+}
+
+type grokCapture struct {
+	Name          string
+	AttributeType string
+	Parameters    map[string]interface{}
+}
+
+type grok struct {
+	regexp *regexp.Regexp
+	// Captures by capture group name.
+	captures map[string]grokCapture
+}
+
+type grokCompiler struct {
+	captures map[string]grokCapture
+}
+
+// Compiles a Grok pattern, validating the referenced patterns exist, the attribute types are valid and
+// each attribute is captured only once.
+func compileGrok(pattern string) (*grok, error) {
+	c := &grokCompiler{captures: map[string]grokCapture{}}
+
+	expanded, err := c.expand(pattern, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// New Relic evaluates the regular expressions with Java, which accepts syntax RE2 doesn't, such as
+	// lookarounds, possessive quantifiers or character class intersections, so they are never rejected here.
+	re, err := regexp.Compile(`^(?:` + expanded + `)$`)
+	if err != nil {
+		return nil, &grokUnsupportedError{reason: fmt.Sprintf("the regular expression cannot be checked offline: %s", err)}
+	}
+
+	// Named groups of the regular expression itself, e.g. (?<name>...), are captured as strings.
+	seen := map[string]bool{}
+	for _, group := range re.SubexpNames() {
+		if group == "" {
+			continue
+		}
+
+		capture, ok := c.captures[group]
+		if !ok {
+			capture = grokCapture{Name: group, AttributeType: grokAttributeTypes.String}
+			c.captures[group] = capture
+		}
+
+		if seen[capture.Name] {
+			return nil, fmt.Errorf("the attribute %q is captured more than once", capture.Name)
+		}
+		seen[capture.Name] = true
+	}
+
+	return &grok{regexp: re, captures: c.captures}, nil
+}
+
+// Replaces the references to the library with their definitions, recursively.
+func (c *grokCompiler) expand(pattern string, stack []string) (string, error) {
+	var out strings.Builder
+
+	for {
+		start := strings.Index(pattern, "%{")
+		if start < 0 {
+			out.WriteString(pattern)
+			return out.String(), nil
+		}
+
+		end := grokReferenceEnd(pattern[start+2:])
+		if end < 0 {
+			return "", fmt.Errorf("unterminated pattern reference %q", pattern[start:])
+		}
+
+		reference := pattern[start+2 : start+2+end]
+		out.WriteString(pattern[:start])
+		pattern = pattern[start+2+end+1:]
+
+		name, capture, err := parseGrokReference(reference)
+		if err != nil {
+			return "", err
+		}
+
+		definition, ok := grokPatterns[name]
+		if !ok {
+			return "", fmt.Errorf("unknown Grok pattern %q", name)
+		}
+
+		for _, n := range stack {
+			if n == name {
+				return "", fmt.Errorf("the Grok pattern %q references itself", name)
+			}
+		}
+
+		expanded, err := c.expand(definition, append(stack, name))
+		if err != nil {
+			return "", err
+		}
+
+		if capture == nil {
+			out.WriteString(`(?:` + expanded + `)`)
+			continue
+		}
+
+		group := fmt.Sprintf("grok__%d", len(c.captures))
+		c.captures[group] = *capture
+		out.WriteString(`(?P<` + group + `>` + expanded + `)`)
+	}
+}
+
+// Returns the index of the brace closing a reference, skipping the braces of type parameters.
+func grokReferenceEnd(in string) int {
+	depth := 0
+	var quote rune
+
+	for i, r := range in {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case depth > 0 && (r == '"' || r == '\''):
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == '}' && depth == 0:
+			return i
+		}
+	}
+
+	return -1
+}
+
+var grokAttributeNameRegexp = regexp.MustCompile(`^[A-Za-z_@][\w.@-]*$`)
+
+// Parses `PATTERN_NAME:attribute_name:attribute_type(parameters)`.
+func parseGrokReference(reference string) (string, *grokCapture, error) {
+	parts := strings.SplitN(reference, ":", 3)
+
+	name := parts[0]
+	if name == "" {
+		return "", nil, fmt.Errorf("missing pattern name in %%{%s}", reference)
+	}
+
+	if len(parts) == 1 {
+		return name, nil, nil
+	}
+
+	capture := &grokCapture{Name: parts[1], AttributeType: grokAttributeTypes.String}
+	if !grokAttributeNameRegexp.MatchString(capture.Name) {
+		return "", nil, fmt.Errorf("invalid attribute name %q in %%{%s}", capture.Name, reference)
+	}
+
+	if len(parts) == 3 {
+		attributeType := parts[2]
+		if i := strings.Index(attributeType, "("); i >= 0 {
+			if !strings.HasSuffix(attributeType, ")") {
+				return "", nil, fmt.Errorf("unterminated parameters in %%{%s}", reference)
+			}
+
+			if parameters := strings.TrimSpace(attributeType[i+1 : len(attributeType)-1]); parameters != "" {
+				if err := json.Unmarshal([]byte(parameters), &capture.Parameters); err != nil {
+					return "", nil, fmt.Errorf("invalid parameters in %%{%s}, expected a JSON object: %w", reference, err)
+				}
+			}
+			attributeType = attributeType[:i]
+		}
+
+		switch attributeType {
+		case grokAttributeTypes.String, grokAttributeTypes.Boolean,
+			grokAttributeTypes.Int, grokAttributeTypes.Long,
+			grokAttributeTypes.Float, grokAttributeTypes.Double,
+			grokAttributeTypes.JSON, grokAttributeTypes.KeyValue:
+			capture.AttributeType = attributeType
+		default:
+			return "", nil, fmt.Errorf("invalid attribute type %q in %%{%s}", attributeType, reference)
+		}
+	}
+
+	return name, capture, nil
+}
+
+// Matches a log line, returning the extracted attributes sorted by name.
+// Values which cannot be converted to the type of their attribute are dropped.
+func (g *grok) match(line string) (bool, []logconfigurations.LogConfigurationsGrokTestExtractedAttribute) {
+	indexes := g.regexp.FindStringSubmatchIndex(line)
+	if indexes == nil {
+		return false, nil
+	}
+
+	attributes := []logconfigurations.LogConfigurationsGrokTestExtractedAttribute{}
+	for i, group := range g.regexp.SubexpNames() {
+		capture, ok := g.captures[group]
+		if !ok || indexes[2*i] < 0 {
+			continue
+		}
+
+		attributes = append(attributes, capture.extract(line[indexes[2*i]:indexes[2*i+1]])...)
+	}
+
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Name < attributes[j].Name
+	})
+
+	return true, attributes
+}
+
+func (c grokCapture) extract(value string) []logconfigurations.LogConfigurationsGrokTestExtractedAttribute {
+	attribute := func(name string, value string) logconfigurations.LogConfigurationsGrokTestExtractedAttribute {
+		return logconfigurations.LogConfigurationsGrokTestExtractedAttribute{Name: name, Value: value}
+	}
+
+	switch c.AttributeType {
+	case grokAttributeTypes.Boolean:
+		b, err := strconv.ParseBool(strings.ToLower(value))
+		if err != nil {
+			return nil
+		}
+		return []logconfigurations.LogConfigurationsGrokTestExtractedAttribute{attribute(c.Name, strconv.FormatBool(b))}
+	case grokAttributeTypes.Int, grokAttributeTypes.Long:
+		bitSize := 32
+		if c.AttributeType == grokAttributeTypes.Long {
+			bitSize = 64
+		}
+		n, err := strconv.ParseInt(strings.TrimPrefix(value, "+"), 10, bitSize)
+		if err != nil {
+			return nil
+		}
+		return []logconfigurations.LogConfigurationsGrokTestExtractedAttribute{attribute(c.Name, strconv.FormatInt(n, 10))}
+	case grokAttributeTypes.Float, grokAttributeTypes.Double:
+		bitSize := 32
+		if c.AttributeType == grokAttributeTypes.Double {
+			bitSize = 64
+		}
+		f, err := strconv.ParseFloat(value, bitSize)
+		if err != nil {
+			return nil
+		}
+		return []logconfigurations.LogConfigurationsGrokTestExtractedAttribute{attribute(c.Name, strconv.FormatFloat(f, 'f', -1, bitSize))}
+	case grokAttributeTypes.JSON, grokAttributeTypes.KeyValue:
+		var nested map[string]string
+		if c.AttributeType == grokAttributeTypes.JSON {
+			nested = c.extractJSON(value)
+		} else {
+			nested = c.extractKeyValue(value)
+		}
+
+		var attributes []logconfigurations.LogConfigurationsGrokTestExtractedAttribute
+		if dropOriginal, _ := c.Parameters["dropOriginal"].(bool); !dropOriginal || nested == nil {
+			attributes = append(attributes, attribute(c.Name, value))
+		}
+		for k, v := range nested {
+			attributes = append(attributes, attribute(c.Name+"."+k, v))
+		}
+		return attributes
+	default:
+		return []logconfigurations.LogConfigurationsGrokTestExtractedAttribute{attribute(c.Name, value)}
+	}
+}
+
+// Flattens a JSON object, joining nested keys with dots. Arrays are kept as JSON.
+func (c grokCapture) extractJSON(value string) map[string]string {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil || object == nil {
+		return nil
+	}
+
+	out := map[string]string{}
+	var flatten func(prefix string, object map[string]interface{})
+	flatten = func(prefix string, object map[string]interface{}) {
+		for k, v := range object {
+			switch v := v.(type) {
+			case nil:
+			case map[string]interface{}:
+				flatten(prefix+k+".", v)
+			case string:
+				out[prefix+k] = v
+			case json.Number:
+				out[prefix+k] = v.String()
+			case bool:
+				out[prefix+k] = strconv.FormatBool(v)
+			default:
+				var buf bytes.Buffer
+				encoder := json.NewEncoder(&buf)
+				encoder.SetEscapeHTML(false)
+				if err := encoder.Encode(v); err == nil {
+					out[prefix+k] = strings.TrimSuffix(buf.String(), "\n")
+				}
+			}
+		}
+	}
+	flatten("", object)
+
+	return out
+}
+
+// Splits `key1=value1,key2=value2` pairs. The delimiter, separator and quote character may be
+// configured with the `delimiter`, `keyValueSeparator` and `quoteChar` parameters, and keys
+// and values may be trimmed with the `trimKeys` and `trimValues` parameters.
+func (c grokCapture) extractKeyValue(value string) map[string]string {
+	parameter := func(name string, defaultValue string) string {
+		if s, ok := c.Parameters[name].(string); ok && s != "" {
+			return s
+		}
+		return defaultValue
+	}
+	delimiter := parameter("delimiter", ",")
+	separator := parameter("keyValueSeparator", "=")
+	quote := parameter("quoteChar", `"`)
+	trimKeys, _ := c.Parameters["trimKeys"].(bool)
+	trimValues, _ := c.Parameters["trimValues"].(bool)
+
+	out := map[string]string{}
+	for _, pair := range strings.Split(value, delimiter) {
+		k, v, ok := strings.Cut(pair, separator)
+		if !ok {
+			continue
+		}
+
+		if trimKeys {
+			k = strings.TrimSpace(k)
+		}
+		if trimValues {
+			v = strings.TrimSpace(v)
+		}
+		if len(v) >= 2*len(quote) && strings.HasPrefix(v, quote) && strings.HasSuffix(v, quote) {
+			v = v[len(quote) : len(v)-len(quote)]
+		}
+
+		if k != "" {
+			out[k] = v
+		}
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+// Tests a Grok pattern against log lines offline, in the format of the NerdGraph test results.
+func testGrokPatternOffline(pattern string, logLines []string) (*[]logconfigurations.LogConfigurationsGrokTestResult, error) {
+	g, err := compileGrok(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Grok pattern %q: %w", pattern, err)
+	}
+
+	results := make([]logconfigurations.LogConfigurationsGrokTestResult, len(logLines))
+	for i, line := range logLines {
+		matched, attributes := g.match(line)
+		results[i] = logconfigurations.LogConfigurationsGrokTestResult{
+			LogLine:    line,
+			Matched:    matched,
+			Attributes: attributes,
+		}
+	}
+
+	return &results, nil
+}
@@ -0,0 +1,103 @@
+package newrelic
+
+// grokPatterns is the library of built-in Grok patterns available to parsing rules.
+// The definitions follow the patterns of New Relic log parsing, which are based on the
+// Logstash library, rewritten without lookarounds and atomic groups so they compile
+// with the regexp package. Definitions are always inserted in a group, so they may use
+// top-level alternations.
+var grokPatterns = map[string]string{
+	"USERNAME":       `[a-zA-Z0-9._-]+`,
+	"USER":           `%{USERNAME}`,
+	"EMAILLOCALPART": `[a-zA-Z0-9!#$&'*+/=?^_\x60{|}~-]+(?:\.[a-zA-Z0-9!#$&'*+/=?^_\x60{|}~-]+)*`,
+	"EMAILADDRESS":   `%{EMAILLOCALPART}@%{HOSTNAME}`,
+	"INT":            `[+-]?[0-9]+`,
+	"BASE10NUM":      `[+-]?(?:[0-9]+(?:\.[0-9]+)?|\.[0-9]+)`,
+	"NUMBER":         `%{BASE10NUM}`,
+	"BASE16NUM":      `[+-]?(?:0x)?[0-9A-Fa-f]+`,
+	"BASE16FLOAT":    `[+-]?(?:0x)?(?:[0-9A-Fa-f]+(?:\.[0-9A-Fa-f]*)?|\.[0-9A-Fa-f]+)`,
+	"POSINT":         `[1-9][0-9]*`,
+	"NONNEGINT":      `[0-9]+`,
+	"WORD":           `\b\w+\b`,
+	"NOTSPACE":       `\S+`,
+	"SPACE":          `\s*`,
+	"DATA":           `.*?`,
+	"GREEDYDATA":     `.*`,
+	"QUOTEDSTRING":   `"(?:\\.|[^\\"])*"|'(?:\\.|[^\\'])*'|\x60(?:\\.|[^\\\x60])*\x60`,
+	"QS":             `%{QUOTEDSTRING}`,
+	"UUID":           `[A-Fa-f0-9]{8}-(?:[A-Fa-f0-9]{4}-){3}[A-Fa-f0-9]{12}`,
+	"URN":            `urn:[0-9A-Za-z][0-9A-Za-z-]{0,31}:(?:%[0-9a-fA-F]{2}|[0-9A-Za-z()+,.:=@;$_!*'/?#-])+`,
+
+	// Networking
+	"CISCOMAC":   `(?:[A-Fa-f0-9]{4}\.){2}[A-Fa-f0-9]{4}`,
+	"WINDOWSMAC": `(?:[A-Fa-f0-9]{2}-){5}[A-Fa-f0-9]{2}`,
+	"COMMONMAC":  `(?:[A-Fa-f0-9]{2}:){5}[A-Fa-f0-9]{2}`,
+	"MAC":        `%{CISCOMAC}|%{WINDOWSMAC}|%{COMMONMAC}`,
+	"IPV6": `(?:(?:[0-9A-Fa-f]{1,4}:){7}(?:[0-9A-Fa-f]{1,4}|:))|` +
+		`(?:(?:[0-9A-Fa-f]{1,4}:){6}(?::[0-9A-Fa-f]{1,4}|%{IPV4}|:))|` +
+		`(?:(?:[0-9A-Fa-f]{1,4}:){5}(?:(?::[0-9A-Fa-f]{1,4}){1,2}|:%{IPV4}|:))|` +
+		`(?:(?:[0-9A-Fa-f]{1,4}:){4}(?:(?::[0-9A-Fa-f]{1,4}){1,3}|(?::[0-9A-Fa-f]{1,4})?:%{IPV4}|:))|` +
+		`(?:(?:[0-9A-Fa-f]{1,4}:){3}(?:(?::[0-9A-Fa-f]{1,4}){1,4}|(?::[0-9A-Fa-f]{1,4}){0,2}:%{IPV4}|:))|` +
+		`(?:(?:[0-9A-Fa-f]{1,4}:){2}(?:(?::[0-9A-Fa-f]{1,4}){1,5}|(?::[0-9A-Fa-f]{1,4}){0,3}:%{IPV4}|:))|` +
+		`(?:(?:[0-9A-Fa-f]{1,4}:){1}(?:(?::[0-9A-Fa-f]{1,4}){1,6}|(?::[0-9A-Fa-f]{1,4}){0,4}:%{IPV4}|:))|` +
+		`(?::(?:(?::[0-9A-Fa-f]{1,4}){1,7}|(?::[0-9A-Fa-f]{1,4}){0,5}:%{IPV4}|:))`,
+	"IPV4":      `(?:25[0-5]|2[0-4][0-9]|[0-1]?[0-9]{1,2})(?:\.(?:25[0-5]|2[0-4][0-9]|[0-1]?[0-9]{1,2})){3}`,
+	"IP":        `%{IPV6}|%{IPV4}`,
+	"HOSTNAME":  `\b[0-9A-Za-z][0-9A-Za-z-]{0,62}(?:\.[0-9A-Za-z][0-9A-Za-z-]{0,62})*\.?`,
+	"IPORHOST":  `%{IP}|%{HOSTNAME}`,
+	"HOSTPORT":  `%{IPORHOST}:%{POSINT}`,
+	"HTTPDUSER": `%{EMAILADDRESS}|%{USER}`,
+
+	// Paths and URIs
+	"PATH":         `%{UNIXPATH}|%{WINPATH}`,
+	"UNIXPATH":     `(?:/(?:[\w_%!$@:.,+~-]+|\\.)*)+`,
+	"WINPATH":      `(?:[A-Za-z]+:|\\)(?:\\[^\\?*]*)+`,
+	"TTY":          `/dev/(?:pts|tty[pq]?)(?:\w+)?/?[0-9]+`,
+	"URIPROTO":     `[A-Za-z][A-Za-z0-9+\-.]+`,
+	"URIHOST":      `%{IPORHOST}(?::%{POSINT})?`,
+	"URIPATH":      `(?:/[A-Za-z0-9$.+!*'(),~:;=@#%&_\-]*)+`,
+	"URIPARAM":     `\?[A-Za-z0-9$.+!*'|(),~@#%&/=:;_?\-\[\]<>]*`,
+	"URIPATHPARAM": `%{URIPATH}(?:%{URIPARAM})?`,
+	"URI":          `%{URIPROTO}://(?:%{USER}(?::[^@]*)?@)?(?:%{URIHOST})?(?:%{URIPATHPARAM})?`,
+
+	// Dates and times
+	"MONTH": `\b(?:[Jj]an(?:uary|uar)?|[Ff]eb(?:ruary|ruar)?|[Mm](?:a|ä)?r(?:ch|z)?|[Aa]pr(?:il)?|[Mm]a(?:y|i)?|` +
+		`[Jj]un(?:e|i)?|[Jj]ul(?:y|i)?|[Aa]ug(?:ust)?|[Ss]ep(?:tember)?|[Oo](?:c|k)?t(?:ober)?|[Nn]ov(?:ember)?|[Dd]e(?:c|z)(?:ember)?)\b`,
+	"MONTHNUM":           `1[0-2]|0?[1-9]`,
+	"MONTHNUM2":          `0[1-9]|1[0-2]`,
+	"MONTHDAY":           `0[1-9]|[12][0-9]|3[01]|[1-9]`,
+	"DAY":                `\b(?:Mon(?:day)?|Tue(?:sday)?|Wed(?:nesday)?|Thu(?:rsday)?|Fri(?:day)?|Sat(?:urday)?|Sun(?:day)?)\b`,
+	"YEAR":               `(?:\d\d){1,2}`,
+	"HOUR":               `2[0123]|[01]?[0-9]`,
+	"MINUTE":             `[0-5][0-9]`,
+	"SECOND":             `(?:60|[0-5]?[0-9])(?:[:.,][0-9]+)?`,
+	"TIME":               `%{HOUR}:%{MINUTE}(?::%{SECOND})?`,
+	"DATE_US":            `%{MONTHNUM}[/-]%{MONTHDAY}[/-]%{YEAR}`,
+	"DATE_EU":            `%{MONTHDAY}[./-]%{MONTHNUM}[./-]%{YEAR}`,
+	"ISO8601_TIMEZONE":   `Z|[+-]%{HOUR}(?::?%{MINUTE})`,
+	"ISO8601_SECOND":     `%{SECOND}`,
+	"TIMESTAMP_ISO8601":  `%{YEAR}-%{MONTHNUM}-%{MONTHDAY}[T ]%{HOUR}:?%{MINUTE}(?::?%{SECOND})?%{ISO8601_TIMEZONE}?`,
+	"DATE":               `%{DATE_US}|%{DATE_EU}`,
+	"DATESTAMP":          `%{DATE}[- ]%{TIME}`,
+	"TZ":                 `[A-Z]{3}`,
+	"DATESTAMP_RFC822":   `%{DAY} %{MONTH} %{MONTHDAY} %{YEAR} %{TIME} %{TZ}`,
+	"DATESTAMP_RFC2822":  `%{DAY}, %{MONTHDAY} %{MONTH} %{YEAR} %{TIME} %{ISO8601_TIMEZONE}`,
+	"DATESTAMP_OTHER":    `%{DAY} %{MONTH} %{MONTHDAY} %{TIME} %{TZ} %{YEAR}`,
+	"DATESTAMP_EVENTLOG": `%{YEAR}%{MONTHNUM2}%{MONTHDAY}%{HOUR}%{MINUTE}%{SECOND}`,
+	"HTTPDATE":           `%{MONTHDAY}/%{MONTH}/%{YEAR}:%{TIME} %{INT}`,
+
+	// Syslog
+	"SYSLOGTIMESTAMP": `%{MONTH} +%{MONTHDAY} %{TIME}`,
+	"PROG":            `[\x21-\x5a\x5c\x5e-\x7e]+`,
+	"SYSLOGPROG":      `%{PROG:program}(?:\[%{POSINT:pid}\])?`,
+	"SYSLOGHOST":      `%{IPORHOST}`,
+	"SYSLOGFACILITY":  `<%{NONNEGINT:facility}.%{NONNEGINT:priority}>`,
+
+	// Log levels
+	"LOGLEVEL": `[Aa]lert|ALERT|[Tt]race|TRACE|[Dd]ebug|DEBUG|[Nn]otice|NOTICE|[Ii]nfo|INFO|[Ww]arn?(?:ing)?|WARN?(?:ING)?|` +
+		`[Ee]rr?(?:or)?|ERR?(?:OR)?|[Cc]rit?(?:ical)?|CRIT?(?:ICAL)?|[Ff]atal|FATAL|[Ss]evere|SEVERE|EMERG(?:ENCY)?|[Ee]merg(?:ency)?`,
+
+	// Web server logs
+	"COMMONAPACHELOG": `%{IPORHOST:clientip} %{HTTPDUSER:ident} %{HTTPDUSER:auth} \[%{HTTPDATE:timestamp}\] ` +
+		`"(?:%{WORD:verb} %{NOTSPACE:request}(?: HTTP/%{NUMBER:httpversion})?|%{DATA:rawrequest})" %{NUMBER:response} (?:%{NUMBER:bytes}|-)`,
+	"COMBINEDAPACHELOG": `%{COMMONAPACHELOG} %{QS:referrer} %{QS:agent}`,
+}
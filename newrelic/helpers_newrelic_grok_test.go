@@ -0,0 +1,143 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/logconfigurations"
+	"github.com/stretchr/testify/require"
+)
+
+type grokTestFixture struct {
+	Grok     string                                              `json:"grok"`
+	LogLines []string                                            `json:"logLines"`
+	Results  []logconfigurations.LogConfigurationsGrokTestResult `json:"results"`
+}
+
+func TestCompileGrok_Library(t *testing.T) {
+	for name := range grokPatterns {
+		_, err := compileGrok("%{" + name + ":value}")
+		require.NoError(t, err, name)
+	}
+}
+
+func TestCompileGrok_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"%{IP:host}-%{IP:host}":      "captured more than once",
+		"%{IP:host} (?<host>\\w+)":   "captured more than once",
+		"%{INT:bytes:integer}":       "invalid attribute type",
+		"%{INT:bytes:int":            "unterminated",
+		"%{:bytes}":                  "missing pattern name",
+		"%{INT:by tes}":              "invalid attribute name",
+		"%{DATA:attrs:keyvalue(x)}":  "expected a JSON object",
+		"%{DATA:attrs:keyvalue({}}":  "unterminated",
+		"%{NOT_A_PATTERN:value}":     "unknown Grok pattern \"NOT_A_PATTERN\"",
+		"%{GREEDYDATA:message} a{2,": "",
+	}
+
+	for pattern, expected := range cases {
+		_, err := compileGrok(pattern)
+		if expected == "" {
+			require.NoError(t, err, pattern)
+			continue
+		}
+		require.ErrorContains(t, err, expected, pattern)
+
+		var unsupportedErr *grokUnsupportedError
+		require.False(t, errors.As(err, &unsupportedErr), pattern)
+	}
+}
+
+func TestCompileGrok_Unsupported(t *testing.T) {
+	for _, pattern := range []string{
+		"(?=foo)%{WORD:value}",
+		"(?<!foo)%{WORD:value}",
+		"(\\w)\\1 %{WORD:value}",
+		"%{WORD:value}a++",
+		"\\p{Alpha}+ %{WORD:value}",
+		"%{IP:host} (unbalanced",
+		"%{IP:host} [unterminated",
+	} {
+		_, err := compileGrok(pattern)
+
+		var unsupportedErr *grokUnsupportedError
+		require.ErrorAs(t, err, &unsupportedErr, pattern)
+		require.ErrorContains(t, err, "cannot be checked offline", pattern)
+	}
+}
+
+func TestGrokMatch_AttributeTypes(t *testing.T) {
+	g, err := compileGrok(`%{NUMBER:float:float} %{NUMBER:double:double} %{NUMBER:long:long} %{NUMBER:int:int} %{WORD:ok:boolean} (?<raw>\w+)`)
+	require.NoError(t, err)
+
+	matched, attributes := g.match("1.5 2.25 9000000000 9000000000 TRUE raw")
+	require.True(t, matched)
+	require.Equal(t, []logconfigurations.LogConfigurationsGrokTestExtractedAttribute{
+		{Name: "double", Value: "2.25"},
+		{Name: "float", Value: "1.5"},
+		{Name: "long", Value: "9000000000"},
+		{Name: "ok", Value: "true"},
+		{Name: "raw", Value: "raw"},
+	}, attributes, "values overflowing int are dropped")
+
+	matched, attributes = g.match("1.5 2.25 1 1 maybe raw")
+	require.True(t, matched)
+	require.NotContains(t, attributes, logconfigurations.LogConfigurationsGrokTestExtractedAttribute{Name: "ok", Value: "maybe"})
+
+	matched, _ = g.match("prefix 1.5 2.25 1 1 true raw")
+	require.False(t, matched, "patterns match whole lines")
+}
+
+func TestGrokMatch_KeyValueParameters(t *testing.T) {
+	g, err := compileGrok(`%{GREEDYDATA:attrs:keyvalue({"delimiter": ";", "keyValueSeparator": ":", "quoteChar": "'", "trimKeys": true, "trimValues": true})}`)
+	require.NoError(t, err)
+
+	matched, attributes := g.match("user: 'alice' ; status : ok;invalid")
+	require.True(t, matched)
+	require.Equal(t, []logconfigurations.LogConfigurationsGrokTestExtractedAttribute{
+		{Name: "attrs", Value: "user: 'alice' ; status : ok;invalid"},
+		{Name: "attrs.status", Value: "ok"},
+		{Name: "attrs.user", Value: "alice"},
+	}, attributes)
+}
+
+func TestGrokMatch_InvalidJSON(t *testing.T) {
+	g, err := compileGrok(`%{GREEDYDATA:payload:json({"dropOriginal": true})}`)
+	require.NoError(t, err)
+
+	// The original value is kept when nothing can be extracted from it.
+	matched, attributes := g.match("not json")
+	require.True(t, matched)
+	require.Equal(t, []logconfigurations.LogConfigurationsGrokTestExtractedAttribute{
+		{Name: "payload", Value: "not json"},
+	}, attributes)
+}
+
+// The fixtures hold the results of the NerdGraph Grok test endpoint for the same patterns and log lines.
+func TestTestGrokPatternOffline_Parity(t *testing.T) {
+	raw, err := os.ReadFile("testdata/grok_test_results.json")
+	require.NoError(t, err)
+
+	var fixtures []grokTestFixture
+	require.NoError(t, json.Unmarshal(raw, &fixtures))
+	require.NotEmpty(t, fixtures)
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.Grok, func(t *testing.T) {
+			results, err := testGrokPatternOffline(fixture.Grok, fixture.LogLines)
+			require.NoError(t, err)
+			require.Len(t, *results, len(fixture.Results))
+
+			for i, expected := range fixture.Results {
+				actual := (*results)[i]
+				require.Equal(t, expected.LogLine, actual.LogLine)
+				require.Equal(t, expected.Matched, actual.Matched, expected.LogLine)
+				require.ElementsMatch(t, expected.Attributes, actual.Attributes, expected.LogLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/logconfigurations"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrqldroprules"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/pipelinecontrol"
+)
+
+// A local simulation of the log ingest pipeline, applying the drop rules and pipeline cloud
+// rules, then the parsing, obfuscation and data partition rules of an account to sample log
+// records, in this order.
+
+var logPipelineStages = struct {
+	Drop      string
+	Parse     string
+	Obfuscate string
+	Partition string
+}{
+	Drop:      "DROP",
+	Parse:     "PARSE",
+	Obfuscate: "OBFUSCATE",
+	Partition: "PARTITION",
+}
+
+const logPipelineDefaultPartition = "Log"
+
+type logPipelineRule struct {
+	Stage string
+	ID    string
+	Name  string
+	Query *nrqlQuery
+
+	// Drop rules
+	DropAction nrqldroprules.NRQLDropRulesAction
+
+	// Parsing rules
+	Attribute string
+	Grok      *grok
+
+	// Obfuscation rules
+	ObfuscationActions []obfuscationAction
+
+	// Data partition rules
+	MatchingCriteria *logconfigurations.LogConfigurationsDataPartitionRuleMatchingCriteria
+	Partition        string
+}
+
+// logPipelineUnsupportedRule is a rule the simulation cannot evaluate, e.g. a NRQL condition using functions.
+type logPipelineUnsupportedRule struct {
+	Stage  string
+	ID     string
+	Name   string
+	Reason string
+}
+
+type logPipeline struct {
+	Rules       []logPipelineRule
+	Unsupported []logPipelineUnsupportedRule
+}
+
+type logPipelineRuleMatch struct {
+	Stage string
+	ID    string
+	Name  string
+}
+
+type logPipelineResult struct {
+	Dropped      bool
+	MatchedRules []logPipelineRuleMatch
+	Record       map[string]string
+	Partition    string
+}
+
+func (p *logPipeline) unsupported(stage string, id string, name string, err error) {
+	p.Unsupported = append(p.Unsupported, logPipelineUnsupportedRule{Stage: stage, ID: id, Name: name, Reason: err.Error()})
+}
+
+func (p *logPipeline) addDropRules(rules []nrqldroprules.NRQLDropRulesDropRule) {
+	for _, r := range rules {
+		name := r.Name
+		if name == "" {
+			name = r.Description
+		}
+
+		query, err := parseNRQLQuery(r.NRQL)
+		if err != nil {
+			p.unsupported(logPipelineStages.Drop, r.ID, name, err)
+			continue
+		}
+
+		// Only rules targeting logs shape the log pipeline.
+		if query.EventType != "Log" || r.Action == nrqldroprules.NRQLDropRulesActionTypes.DROP_ATTRIBUTES_FROM_METRIC_AGGREGATES {
+			continue
+		}
+
+		p.Rules = append(p.Rules, logPipelineRule{Stage: logPipelineStages.Drop, ID: r.ID, Name: name, Query: query, DropAction: r.Action})
+	}
+}
+
+// Pipeline cloud rules are the successor of drop rules, and are applied along with them.
+// `DELETE FROM` drops the records, while `DELETE attribute, ... FROM` drops attributes.
+func (p *logPipeline) addPipelineCloudRules(rules []*pipelinecontrol.EntityManagementPipelineCloudRuleEntity) {
+	for _, r := range rules {
+		name := r.Name
+		if name == "" {
+			name = r.Description
+		}
+
+		query, err := parseNRQLQuery(string(r.NRQL))
+		if err != nil {
+			p.unsupported(logPipelineStages.Drop, r.ID, name, err)
+			continue
+		}
+
+		if query.EventType != "Log" {
+			continue
+		}
+
+		action := nrqldroprules.NRQLDropRulesActionTypes.DROP_DATA
+		if len(query.Select) > 0 {
+			action = nrqldroprules.NRQLDropRulesActionTypes.DROP_ATTRIBUTES
+		}
+
+		p.Rules = append(p.Rules, logPipelineRule{Stage: logPipelineStages.Drop, ID: r.ID, Name: name, Query: query, DropAction: action})
+	}
+}
+
+func (p *logPipeline) addParsingRules(rules []*logconfigurations.LogConfigurationsParsingRule) {
+	for _, r := range rules {
+		if r == nil || r.Deleted || !r.Enabled {
+			continue
+		}
+
+		query, err := parseNRQLQuery(string(r.NRQL))
+		if err != nil {
+			p.unsupported(logPipelineStages.Parse, r.ID, r.Description, err)
+			continue
+		}
+
+		g, err := compileGrok(r.Grok)
+		if err != nil {
+			p.unsupported(logPipelineStages.Parse, r.ID, r.Description, err)
+			continue
+		}
+
+		attribute := r.Attribute
+		if attribute == "" {
+			attribute = "message"
+		}
+
+		p.Rules = append(p.Rules, logPipelineRule{Stage: logPipelineStages.Parse, ID: r.ID, Name: r.Description, Query: query, Attribute: attribute, Grok: g})
+	}
+}
+
+func (p *logPipeline) addObfuscationRules(rules []logconfigurations.LogConfigurationsObfuscationRule) {
+RULES:
+	for _, r := range rules {
+		if !r.Enabled {
+			continue
+		}
+
+		query, err := parseNRQLQuery(string(r.Filter))
+		if err != nil {
+			p.unsupported(logPipelineStages.Obfuscate, r.ID, r.Name, err)
+			continue
+		}
+
+		rule := logPipelineRule{Stage: logPipelineStages.Obfuscate, ID: r.ID, Name: r.Name, Query: query}
+		for _, a := range r.Actions {
+			action, err := newObfuscationAction(a.Expression.Regex, a.Method, a.Attributes)
+			if err != nil {
+				p.unsupported(logPipelineStages.Obfuscate, r.ID, r.Name, fmt.Errorf("expression %q: %w", a.Expression.Name, err))
+				continue RULES
+			}
+			rule.ObfuscationActions = append(rule.ObfuscationActions, *action)
+		}
+
+		p.Rules = append(p.Rules, rule)
+	}
+}
+
+func (p *logPipeline) addDataPartitionRules(rules []logconfigurations.LogConfigurationsDataPartitionRule) {
+	for _, r := range rules {
+		if r.Deleted || !r.Enabled {
+			continue
+		}
+
+		rule := logPipelineRule{Stage: logPipelineStages.Partition, ID: r.ID, Name: r.Description, Partition: string(r.TargetDataPartition)}
+
+		if r.NRQL != "" {
+			query, err := parseNRQLQuery(string(r.NRQL))
+			if err != nil {
+				p.unsupported(logPipelineStages.Partition, r.ID, r.Description, err)
+				continue
+			}
+			rule.Query = query
+		} else {
+			criteria := r.MatchingCriteria
+			rule.MatchingCriteria = &criteria
+		}
+
+		p.Rules = append(p.Rules, rule)
+	}
+}
+
+// Applies the rules to a record in the order of the ingest pipeline: drop, parse, obfuscate, partition.
+// The first matching data partition rule determines the partition.
+func (p *logPipeline) process(input map[string]string) logPipelineResult {
+	record := make(map[string]string, len(input))
+	for k, v := range input {
+		record[k] = v
+	}
+
+	result := logPipelineResult{Record: record, Partition: logPipelineDefaultPartition}
+	match := func(r logPipelineRule) {
+		result.MatchedRules = append(result.MatchedRules, logPipelineRuleMatch{Stage: r.Stage, ID: r.ID, Name: r.Name})
+	}
+
+	for _, stage := range []string{logPipelineStages.Drop, logPipelineStages.Parse, logPipelineStages.Obfuscate, logPipelineStages.Partition} {
+		for _, r := range p.Rules {
+			if r.Stage != stage {
+				continue
+			}
+
+			switch r.Stage {
+			case logPipelineStages.Drop:
+				if !r.Query.matches(record) {
+					continue
+				}
+				match(r)
+
+				if r.DropAction == nrqldroprules.NRQLDropRulesActionTypes.DROP_DATA {
+					result.Dropped = true
+					result.Record = nil
+					return result
+				}
+				for _, attribute := range r.Query.Select {
+					delete(record, attribute)
+				}
+
+			case logPipelineStages.Parse:
+				value, ok := record[r.Attribute]
+				if !ok || !r.Query.matches(record) {
+					continue
+				}
+				matched, attributes := r.Grok.match(value)
+				if !matched {
+					continue
+				}
+				match(r)
+
+				for _, a := range attributes {
+					record[a.Name] = a.Value
+				}
+
+			case logPipelineStages.Obfuscate:
+				if !r.Query.matches(record) {
+					continue
+				}
+				match(r)
+
+				for _, a := range r.ObfuscationActions {
+					a.apply(record)
+				}
+
+			case logPipelineStages.Partition:
+				if r.Query != nil && !r.Query.matches(record) {
+					continue
+				}
+				if r.MatchingCriteria != nil && !matchesDataPartitionCriteria(record, *r.MatchingCriteria) {
+					continue
+				}
+				match(r)
+
+				result.Partition = r.Partition
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+func matchesDataPartitionCriteria(record map[string]string, criteria logconfigurations.LogConfigurationsDataPartitionRuleMatchingCriteria) bool {
+	value, ok := record[criteria.AttributeName]
+	if !ok {
+		return false
+	}
+
+	// Matching expressions are NRQL string literals, e.g. 'nginx%'.
+	expression := strings.Trim(criteria.MatchingExpression, "'")
+	if criteria.MatchingOperator == logconfigurations.LogConfigurationsDataPartitionRuleMatchingOperatorTypes.LIKE {
+		return likePatternRegexp(expression).MatchString(value)
+	}
+
+	return value == expression
+}
+
+// Flattens a JSON log record, joining nested keys with dots.
+func expandLogPipelineRecord(in string) (map[string]string, error) {
+	decoder := json.NewDecoder(strings.NewReader(in))
+	decoder.UseNumber()
+
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("log records must be JSON objects: %w", err)
+	}
+
+	record := map[string]string{}
+	var flatten func(prefix string, object map[string]interface{})
+	flatten = func(prefix string, object map[string]interface{}) {
+		for k, v := range object {
+			switch v := v.(type) {
+			case nil:
+			case map[string]interface{}:
+				flatten(prefix+k+".", v)
+			case string:
+				record[prefix+k] = v
+			case json.Number:
+				record[prefix+k] = v.String()
+			case bool:
+				record[prefix+k] = strconv.FormatBool(v)
+			default:
+				b, _ := json.Marshal(v)
+				record[prefix+k] = string(b)
+			}
+		}
+	}
+	flatten("", object)
+
+	return record, nil
+}
+
+func flattenLogPipelineResult(input string, result logPipelineResult) (map[string]interface{}, error) {
+	matchedRules := make([]interface{}, len(result.MatchedRules))
+	for i, r := range result.MatchedRules {
+		matchedRules[i] = map[string]interface{}{
+			"stage": r.Stage,
+			"id":    r.ID,
+			"name":  r.Name,
+		}
+	}
+
+	record := ""
+	if result.Record != nil {
+		b, err := json.Marshal(result.Record)
+		if err != nil {
+			return nil, err
+		}
+		record = string(b)
+	}
+
+	return map[string]interface{}{
+		"input":         input,
+		"dropped":       result.Dropped,
+		"matched_rules": matchedRules,
+		"record":        record,
+		"partition":     result.Partition,
+	}, nil
+}
+
+func flattenLogPipelineUnsupportedRules(rules []logPipelineUnsupportedRule) []interface{} {
+	out := make([]interface{}, len(rules))
+	for i, r := range rules {
+		out[i] = map[string]interface{}{
+			"stage":  r.Stage,
+			"id":     r.ID,
+			"name":   r.Name,
+			"reason": r.Reason,
+		}
+	}
+
+	return out
+}
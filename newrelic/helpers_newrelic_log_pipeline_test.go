@@ -0,0 +1,154 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/logconfigurations"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrqldroprules"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/pipelinecontrol"
+	"github.com/stretchr/testify/require"
+)
+
+func testLogPipeline(t *testing.T) *logPipeline {
+	p := &logPipeline{}
+
+	p.addDropRules([]nrqldroprules.NRQLDropRulesDropRule{
+		{ID: "1", Name: "drop health checks", Action: nrqldroprules.NRQLDropRulesActionTypes.DROP_DATA, NRQL: "SELECT * FROM Log WHERE request LIKE '/health%'"},
+		{ID: "2", Description: "drop tokens", Action: nrqldroprules.NRQLDropRulesActionTypes.DROP_ATTRIBUTES, NRQL: "SELECT token FROM Log"},
+		{ID: "3", Name: "other event type", Action: nrqldroprules.NRQLDropRulesActionTypes.DROP_DATA, NRQL: "SELECT * FROM Transaction"},
+		{ID: "4", Name: "uses functions", Action: nrqldroprules.NRQLDropRulesActionTypes.DROP_DATA, NRQL: "SELECT * FROM Log WHERE numeric(duration) > 5"},
+	})
+
+	p.addParsingRules([]*logconfigurations.LogConfigurationsParsingRule{
+		{ID: "10", Description: "nginx", Enabled: true, NRQL: "SELECT * FROM Log WHERE logtype = 'nginx'", Grok: "%{IPORHOST:clientip} %{WORD:verb} %{NOTSPACE:request} %{NUMBER:status:int} user=%{EMAILADDRESS:email}"},
+		{ID: "11", Description: "disabled", Enabled: false, NRQL: "SELECT * FROM Log", Grok: "%{GREEDYDATA:everything}"},
+		{ID: "12", Description: "deleted", Enabled: true, Deleted: true, NRQL: "SELECT * FROM Log", Grok: "%{GREEDYDATA:everything}"},
+	})
+
+	p.addObfuscationRules([]logconfigurations.LogConfigurationsObfuscationRule{
+		{ID: "20", Name: "emails", Enabled: true, Filter: "logtype = 'nginx'", Actions: []logconfigurations.LogConfigurationsObfuscationAction{
+			{Attributes: []string{"email"}, Method: logconfigurations.LogConfigurationsObfuscationMethodTypes.MASK, Expression: logconfigurations.LogConfigurationsObfuscationExpression{Name: "email", Regex: `([^@]+)@`}},
+		}},
+		{ID: "21", Name: "client ips", Enabled: true, Filter: "clientip IS NOT NULL", Actions: []logconfigurations.LogConfigurationsObfuscationAction{
+			{Attributes: []string{"clientip"}, Method: logconfigurations.LogConfigurationsObfuscationMethodTypes.HASH_SHA256, Expression: logconfigurations.LogConfigurationsObfuscationExpression{Name: "ip", Regex: `.+`}},
+		}},
+		{ID: "22", Name: "lookahead", Enabled: true, Filter: "", Actions: []logconfigurations.LogConfigurationsObfuscationAction{
+			{Method: logconfigurations.LogConfigurationsObfuscationMethodTypes.MASK, Expression: logconfigurations.LogConfigurationsObfuscationExpression{Name: "lookahead", Regex: `secret(?=:)`}},
+		}},
+	})
+
+	p.addDataPartitionRules([]logconfigurations.LogConfigurationsDataPartitionRule{
+		{ID: "30", Description: "errors", Enabled: true, TargetDataPartition: "Log_Errors", NRQL: "status >= 500"},
+		{ID: "31", Description: "nginx", Enabled: true, TargetDataPartition: "Log_Nginx", MatchingCriteria: logconfigurations.LogConfigurationsDataPartitionRuleMatchingCriteria{
+			AttributeName:      "logtype",
+			MatchingExpression: "'ngi%'",
+			MatchingOperator:   logconfigurations.LogConfigurationsDataPartitionRuleMatchingOperatorTypes.LIKE,
+		}},
+	})
+
+	return p
+}
+
+func TestLogPipeline_Unsupported(t *testing.T) {
+	p := testLogPipeline(t)
+
+	require.Len(t, p.Unsupported, 2)
+	require.Equal(t, logPipelineStages.Drop, p.Unsupported[0].Stage)
+	require.Equal(t, "4", p.Unsupported[0].ID)
+	require.Equal(t, logPipelineStages.Obfuscate, p.Unsupported[1].Stage)
+	require.Equal(t, "22", p.Unsupported[1].ID)
+}
+
+func TestLogPipeline_Drop(t *testing.T) {
+	p := testLogPipeline(t)
+
+	result := p.process(map[string]string{"request": "/health/live", "logtype": "nginx"})
+	require.True(t, result.Dropped)
+	require.Nil(t, result.Record)
+	require.Equal(t, []logPipelineRuleMatch{{Stage: logPipelineStages.Drop, ID: "1", Name: "drop health checks"}}, result.MatchedRules)
+}
+
+func TestLogPipeline_Stages(t *testing.T) {
+	p := testLogPipeline(t)
+
+	input := map[string]string{
+		"logtype": "nginx",
+		"token":   "abc",
+		"message": "10.0.0.1 GET /checkout 503 user=jane@example.com",
+	}
+	result := p.process(input)
+
+	sum := sha256.Sum256([]byte("10.0.0.1"))
+	require.False(t, result.Dropped)
+	require.Equal(t, map[string]string{
+		"logtype":  "nginx",
+		"message":  "10.0.0.1 GET /checkout 503 user=jane@example.com",
+		"clientip": hex.EncodeToString(sum[:]),
+		"verb":     "GET",
+		"request":  "/checkout",
+		"status":   "503",
+		"email":    "XXXX@example.com",
+	}, result.Record)
+	require.Equal(t, "Log_Errors", result.Partition, "the first matching partition rule wins")
+	require.Equal(t, []logPipelineRuleMatch{
+		{Stage: logPipelineStages.Drop, ID: "2", Name: "drop tokens"},
+		{Stage: logPipelineStages.Parse, ID: "10", Name: "nginx"},
+		{Stage: logPipelineStages.Obfuscate, ID: "20", Name: "emails"},
+		{Stage: logPipelineStages.Obfuscate, ID: "21", Name: "client ips"},
+		{Stage: logPipelineStages.Partition, ID: "30", Name: "errors"},
+	}, result.MatchedRules)
+
+	require.Contains(t, input, "token", "the input record is not modified")
+}
+
+func TestLogPipeline_DefaultPartition(t *testing.T) {
+	p := testLogPipeline(t)
+
+	result := p.process(map[string]string{"logtype": "nginx", "message": "unparsed"})
+	require.Equal(t, "Log_Nginx", result.Partition)
+
+	result = p.process(map[string]string{"logtype": "apache"})
+	require.Equal(t, logPipelineDefaultPartition, result.Partition)
+	require.Equal(t, []logPipelineRuleMatch{{Stage: logPipelineStages.Drop, ID: "2", Name: "drop tokens"}}, result.MatchedRules)
+}
+
+func TestLogPipeline_PipelineCloudRules(t *testing.T) {
+	p := &logPipeline{}
+	p.addPipelineCloudRules([]*pipelinecontrol.EntityManagementPipelineCloudRuleEntity{
+		{ID: "c1", Name: "drop debug logs", NRQL: "DELETE FROM Log WHERE level = 'debug'"},
+		{ID: "c2", Description: "drop secrets", NRQL: "DELETE secret, `user.token` FROM Log"},
+		{ID: "c3", Name: "other event type", NRQL: "DELETE FROM Transaction"},
+		{ID: "c4", Name: "uses functions", NRQL: "DELETE FROM Log WHERE numeric(duration) > 5"},
+	})
+
+	require.Len(t, p.Rules, 2)
+	require.Equal(t, []logPipelineUnsupportedRule{{Stage: logPipelineStages.Drop, ID: "c4", Name: "uses functions", Reason: p.Unsupported[0].Reason}}, p.Unsupported)
+
+	result := p.process(map[string]string{"level": "debug", "secret": "s"})
+	require.True(t, result.Dropped)
+	require.Equal(t, []logPipelineRuleMatch{{Stage: logPipelineStages.Drop, ID: "c1", Name: "drop debug logs"}}, result.MatchedRules)
+
+	result = p.process(map[string]string{"level": "info", "secret": "s", "user.token": "t", "message": "hi"})
+	require.False(t, result.Dropped)
+	require.Equal(t, map[string]string{"level": "info", "message": "hi"}, result.Record)
+	require.Equal(t, []logPipelineRuleMatch{{Stage: logPipelineStages.Drop, ID: "c2", Name: "drop secrets"}}, result.MatchedRules)
+}
+
+func TestExpandLogPipelineRecord(t *testing.T) {
+	record, err := expandLogPipelineRecord(`{"message": "hi", "status": 200, "ok": true, "empty": null, "http": {"method": "GET"}, "tags": ["a", "b"]}`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"message":     "hi",
+		"status":      "200",
+		"ok":          "true",
+		"http.method": "GET",
+		"tags":        `["a","b"]`,
+	}, record)
+
+	_, err = expandLogPipelineRecord(`["not", "an", "object"]`)
+	require.Error(t, err)
+}
@@ -0,0 +1,370 @@
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A minimal evaluator of NRQL WHERE clauses against a single record, e.g.
+//
+//	SELECT * FROM Log WHERE logtype = 'nginx' AND (status >= 500 OR message LIKE '%timeout%')
+//
+// It supports comparisons, LIKE, RLIKE, IN, IS NULL, parentheses and boolean operators, and
+// shares its tokenizer with the entity search query parser. Functions are not supported.
+
+type nrqlQuery struct {
+	// Select is nil for `SELECT *` and DELETE queries without attributes.
+	Select    []string
+	EventType string
+	// Where is nil when the query has no WHERE clause.
+	Where nrqlCondition
+}
+
+type nrqlCondition interface {
+	evaluate(record map[string]string) bool
+}
+
+type nrqlAnd struct{ left, right nrqlCondition }
+
+func (c nrqlAnd) evaluate(record map[string]string) bool {
+	return c.left.evaluate(record) && c.right.evaluate(record)
+}
+
+type nrqlOr struct{ left, right nrqlCondition }
+
+func (c nrqlOr) evaluate(record map[string]string) bool {
+	return c.left.evaluate(record) || c.right.evaluate(record)
+}
+
+type nrqlNot struct{ condition nrqlCondition }
+
+func (c nrqlNot) evaluate(record map[string]string) bool {
+	return !c.condition.evaluate(record)
+}
+
+type nrqlComparison struct {
+	Attribute string
+	// Operator is a comparison operator, LIKE, RLIKE, IN or IS NULL.
+	Operator string
+	Negated  bool
+	Values   []entitySearchToken
+	Pattern  *regexp.Regexp
+}
+
+func (c nrqlComparison) evaluate(record map[string]string) bool {
+	value, ok := record[c.Attribute]
+
+	var result bool
+	switch c.Operator {
+	case "IS NULL":
+		result = !ok
+	case "LIKE", "RLIKE":
+		result = ok && c.Pattern.MatchString(value)
+	case "IN":
+		for _, v := range c.Values {
+			if ok && compareNRQLValue(value, v) == 0 {
+				result = true
+				break
+			}
+		}
+	default:
+		if !ok {
+			return false
+		}
+
+		cmp := compareNRQLValue(value, c.Values[0])
+		switch c.Operator {
+		case "=":
+			result = cmp == 0
+		case "!=", "<>":
+			result = cmp != 0 && cmp != nrqlIncomparable
+		case "<":
+			result = cmp == -1
+		case "<=":
+			result = cmp == -1 || cmp == 0
+		case ">":
+			result = cmp == 1
+		case ">=":
+			result = cmp == 1 || cmp == 0
+		}
+	}
+
+	if c.Negated {
+		return !result
+	}
+
+	return result
+}
+
+const nrqlIncomparable = 2
+
+// Compares a value of a record with a literal, numerically for number literals. Returns
+// nrqlIncomparable when a number literal is compared with a value which is not a number.
+func compareNRQLValue(value string, literal entitySearchToken) int {
+	switch literal.kind {
+	case entitySearchTokenNumber:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nrqlIncomparable
+		}
+		l, _ := strconv.ParseFloat(literal.value, 64)
+		switch {
+		case v < l:
+			return -1
+		case v > l:
+			return 1
+		}
+		return 0
+	case entitySearchTokenKeyword:
+		if strings.EqualFold(value, literal.value) {
+			return 0
+		}
+		return nrqlIncomparable
+	}
+
+	return strings.Compare(value, literal.value)
+}
+
+type nrqlConditionParser struct {
+	entitySearchQueryParser
+}
+
+// expression := term { OR term }
+func (p *nrqlConditionParser) parseExpression() (nrqlCondition, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") {
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = nrqlOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+// term := factor { AND factor }
+func (p *nrqlConditionParser) parseTerm() (nrqlCondition, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") {
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = nrqlAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+// factor := [NOT] ( "(" expression ")" | condition )
+func (p *nrqlConditionParser) parseFactor() (nrqlCondition, error) {
+	if p.acceptKeyword("NOT") {
+		condition, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return nrqlNot{condition: condition}, nil
+	}
+
+	if p.peek().kind == entitySearchTokenLeftParen {
+		p.next()
+		condition, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != entitySearchTokenRightParen {
+			return nil, fmt.Errorf("expected ')' but found %s", t)
+		}
+		return condition, nil
+	}
+
+	return p.parseCondition()
+}
+
+// condition := attribute ( operator value | [NOT] LIKE string | [NOT] RLIKE [r]string | [NOT] IN list | IS [NOT] NULL )
+func (p *nrqlConditionParser) parseCondition() (nrqlCondition, error) {
+	attribute := p.next()
+	if attribute.kind != entitySearchTokenIdentifier {
+		return nil, fmt.Errorf("expected an attribute name but found %s", attribute)
+	}
+
+	if p.peek().kind == entitySearchTokenLeftParen {
+		return nil, fmt.Errorf("functions are not supported, found %s", attribute)
+	}
+
+	c := nrqlComparison{Attribute: attribute.value}
+
+	t := p.next()
+	if t.kind == entitySearchTokenKeyword && t.value == "NOT" {
+		c.Negated = true
+		t = p.next()
+	}
+
+	switch {
+	case t.kind == entitySearchTokenOperator && !c.Negated:
+		c.Operator = t.value
+		v, err := p.parseValue(t)
+		if err != nil {
+			return nil, err
+		}
+		c.Values = []entitySearchToken{v}
+
+	case t.kind == entitySearchTokenKeyword && t.value == "IS" && !c.Negated:
+		c.Operator = "IS NULL"
+		c.Negated = p.acceptKeyword("NOT")
+		if !p.acceptKeyword("NULL") {
+			return nil, fmt.Errorf("expected NULL but found %s", p.peek())
+		}
+
+	case t.kind == entitySearchTokenKeyword && t.value == "LIKE":
+		c.Operator = "LIKE"
+		v := p.next()
+		if v.kind != entitySearchTokenString {
+			return nil, fmt.Errorf("expected a quoted string after LIKE but found %s", v)
+		}
+		c.Pattern = likePatternRegexp(v.value)
+
+	case t.kind == entitySearchTokenIdentifier && strings.EqualFold(t.value, "RLIKE"):
+		c.Operator = "RLIKE"
+		if v := p.peek(); v.kind == entitySearchTokenIdentifier && v.value == "r" {
+			p.next()
+		}
+		v := p.next()
+		if v.kind != entitySearchTokenString {
+			return nil, fmt.Errorf("expected a quoted regular expression after RLIKE but found %s", v)
+		}
+		pattern, err := regexp.Compile(`^(?:` + v.value + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression after RLIKE: %w", err)
+		}
+		c.Pattern = pattern
+
+	case t.kind == entitySearchTokenKeyword && t.value == "IN":
+		c.Operator = "IN"
+		values, err := p.parseList(t)
+		if err != nil {
+			return nil, err
+		}
+		c.Values = values
+
+	default:
+		return nil, fmt.Errorf("expected an operator after attribute %q but found %s", attribute.value, t)
+	}
+
+	return c, nil
+}
+
+func (p *nrqlConditionParser) parseValue(after entitySearchToken) (entitySearchToken, error) {
+	v := p.next()
+	switch {
+	case v.kind == entitySearchTokenString, v.kind == entitySearchTokenNumber:
+		return v, nil
+	case v.kind == entitySearchTokenKeyword && (v.value == "TRUE" || v.value == "FALSE"):
+		return v, nil
+	}
+
+	return v, fmt.Errorf("expected a value after %q but found %s", after.value, v)
+}
+
+// list := "(" value { "," value } ")"
+func (p *nrqlConditionParser) parseList(after entitySearchToken) ([]entitySearchToken, error) {
+	if t := p.next(); t.kind != entitySearchTokenLeftParen {
+		return nil, fmt.Errorf("expected '(' after IN but found %s", t)
+	}
+
+	var values []entitySearchToken
+	for {
+		v, err := p.parseValue(after)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+
+		t := p.next()
+		if t.kind == entitySearchTokenRightParen {
+			return values, nil
+		}
+		if t.kind != entitySearchTokenComma {
+			return nil, fmt.Errorf("expected ',' or ')' but found %s", t)
+		}
+	}
+}
+
+// Converts a LIKE pattern, where % matches any sequence of characters, to a regular expression.
+func likePatternRegexp(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "%")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+
+	return regexp.MustCompile(`(?s)^` + strings.Join(parts, ".*") + `$`)
+}
+
+// parseNRQLCondition parses the condition of a WHERE clause. An empty condition matches every record.
+func parseNRQLCondition(condition string) (nrqlCondition, error) {
+	if strings.TrimSpace(condition) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenizeEntitySearchQuery(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &nrqlConditionParser{entitySearchQueryParser{tokens: tokens}}
+	c, err := parser.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := parser.peek(); t.kind != entitySearchTokenEOF {
+		return nil, fmt.Errorf("unexpected %s, expected AND, OR or end of condition", t)
+	}
+
+	return c, nil
+}
+
+var nrqlQueryRegexp = regexp.MustCompile("(?is)^\\s*(?:SELECT\\s+(.+?)|DELETE(?:\\s+(.+?))?)\\s+FROM\\s+([\\w.`]+)(?:\\s+WHERE\\s+(.*?))?\\s*$")
+
+// parseNRQLQuery parses a `SELECT ... FROM ... WHERE ...` or `DELETE [...] FROM ... WHERE ...` query,
+// or a bare condition, as used by obfuscation and data partition rules.
+func parseNRQLQuery(nrql string) (*nrqlQuery, error) {
+	query := &nrqlQuery{}
+	condition := nrql
+
+	if m := nrqlQueryRegexp.FindStringSubmatch(nrql); m != nil {
+		query.EventType = strings.Trim(m[3], "`")
+		condition = m[4]
+
+		if selected := strings.TrimSpace(m[1] + m[2]); selected != "" && selected != "*" {
+			for _, attribute := range strings.Split(selected, ",") {
+				if strings.ContainsAny(attribute, "()") {
+					return nil, fmt.Errorf("functions are not supported, found %q", strings.TrimSpace(attribute))
+				}
+				query.Select = append(query.Select, strings.Trim(strings.TrimSpace(attribute), "`"))
+			}
+		}
+	} else if fields := strings.Fields(nrql); len(fields) > 0 && (strings.EqualFold(fields[0], "SELECT") || strings.EqualFold(fields[0], "DELETE")) {
+		return nil, fmt.Errorf("unsupported NRQL query %q", nrql)
+	}
+
+	where, err := parseNRQLCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+	query.Where = where
+
+	return query, nil
+}
+
+// matches reports whether the record matches the WHERE clause of the query.
+func (q *nrqlQuery) matches(record map[string]string) bool {
+	return q.Where == nil || q.Where.evaluate(record)
+}
@@ -0,0 +1,89 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNRQLQuery(t *testing.T) {
+	query, err := parseNRQLQuery("SELECT * FROM Log WHERE logtype = 'nginx'")
+	require.NoError(t, err)
+	require.Equal(t, "Log", query.EventType)
+	require.Nil(t, query.Select)
+	require.NotNil(t, query.Where)
+
+	query, err = parseNRQLQuery("select password, `user.token` from Log where env = 'dev'")
+	require.NoError(t, err)
+	require.Equal(t, []string{"password", "user.token"}, query.Select)
+
+	query, err = parseNRQLQuery("DELETE FROM Log")
+	require.NoError(t, err)
+	require.Nil(t, query.Where)
+	require.True(t, query.matches(map[string]string{}))
+
+	query, err = parseNRQLQuery("DELETE password, `user.token` FROM Log WHERE env = 'dev'")
+	require.NoError(t, err)
+	require.Equal(t, "Log", query.EventType)
+	require.Equal(t, []string{"password", "user.token"}, query.Select)
+
+	// Conditions of obfuscation and data partition rules
+	query, err = parseNRQLQuery("logtype='node'")
+	require.NoError(t, err)
+	require.Empty(t, query.EventType)
+	require.True(t, query.matches(map[string]string{"logtype": "node"}))
+
+	for _, unsupported := range []string{
+		"SELECT count(*) FROM Log",
+		"SELECT * FROM Log WHERE numeric(duration) > 5",
+		"SELECT * FROM Log WHERE hostStatus = running",
+		"SELECT * FROM Log WHERE status = 'ok' SINCE 1 day ago",
+	} {
+		_, err := parseNRQLQuery(unsupported)
+		require.Error(t, err, unsupported)
+	}
+}
+
+func TestNRQLConditionEvaluate(t *testing.T) {
+	record := map[string]string{
+		"logtype":  "nginx",
+		"status":   "503",
+		"message":  "upstream timeout after 30s",
+		"hostname": "web-01.example.com",
+		"debug":    "true",
+	}
+
+	cases := map[string]bool{
+		"logtype = 'nginx'":                 true,
+		"logtype != 'nginx'":                false,
+		"logtype <> 'apache'":               true,
+		"status >= 500":                     true,
+		"status < 500":                      false,
+		"status = 503.0":                    true,
+		"logtype > 5":                       false,
+		"message LIKE '%timeout%'":          true,
+		"message NOT LIKE '%timeout%'":      false,
+		"message LIKE 'timeout%'":           false,
+		"hostname RLIKE r'web-[0-9]+\\..*'": true,
+		"hostname NOT RLIKE 'db-.*'":        true,
+		"logtype IN ('apache', 'nginx')":    true,
+		"status IN (500, 502)":              false,
+		"logtype NOT IN ('apache')":         true,
+		"missing IS NULL":                   true,
+		"logtype IS NOT NULL":               true,
+		"missing = 'x'":                     false,
+		"debug = TRUE":                      true,
+		"NOT logtype = 'nginx'":             false,
+		"logtype = 'apache' OR (status >= 500 AND debug = true)": true,
+		"logtype = 'apache' OR status >= 500 AND debug = false":  false,
+		"`logtype` = 'nginx'": true,
+	}
+
+	for condition, expected := range cases {
+		c, err := parseNRQLCondition(condition)
+		require.NoError(t, err, condition)
+		require.Equal(t, expected, c.evaluate(record), condition)
+	}
+}
@@ -0,0 +1,119 @@
+package newrelic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/logconfigurations"
+)
+
+// Obfuscation expressions are RE2 regular expressions, the syntax of the regexp package,
+// so lookarounds, backreferences and possessive quantifiers are rejected.
+func compileObfuscationExpression(regex string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RE2 regular expression: %w", err)
+	}
+
+	return re, nil
+}
+
+func validateObfuscationExpressionRegex(val interface{}, key string) (warns []string, errs []error) {
+	re, err := compileObfuscationExpression(val.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", key, err)}
+	}
+
+	if re.NumSubexp() == 0 {
+		warns = append(warns, fmt.Sprintf("%s: %q has no capture group, New Relic obfuscates the capture groups of the matches, e.g. (%s)", key, re.String(), re.String()))
+	}
+	if re.MatchString("") {
+		warns = append(warns, fmt.Sprintf("%s: %q matches the empty string, so it matches every value of the attributes it is applied to", key, re.String()))
+	}
+
+	return warns, nil
+}
+
+type obfuscationAction struct {
+	// Attributes lists the attributes the action applies to, all of them when empty.
+	Attributes []string
+	Regexp     *regexp.Regexp
+	Method     logconfigurations.LogConfigurationsObfuscationMethod
+}
+
+func newObfuscationAction(regex string, method logconfigurations.LogConfigurationsObfuscationMethod, attributes []string) (*obfuscationAction, error) {
+	re, err := compileObfuscationExpression(regex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &obfuscationAction{Attributes: attributes, Regexp: re, Method: method}, nil
+}
+
+// apply obfuscates the attributes of the record and returns the names of the modified attributes, sorted.
+func (a obfuscationAction) apply(record map[string]string) []string {
+	attributes := a.Attributes
+	if len(attributes) == 0 {
+		for k := range record {
+			attributes = append(attributes, k)
+		}
+	}
+
+	var modified []string
+	for _, attribute := range attributes {
+		value, ok := record[attribute]
+		if !ok {
+			continue
+		}
+		if obfuscated := obfuscateLogValue(value, a.Regexp, a.Method); obfuscated != value {
+			record[attribute] = obfuscated
+			modified = append(modified, attribute)
+		}
+	}
+	sort.Strings(modified)
+
+	return modified
+}
+
+// Replaces the capture groups of the matches of the expression, or the whole matches when the
+// expression has no capture group.
+func obfuscateLogValue(value string, re *regexp.Regexp, method logconfigurations.LogConfigurationsObfuscationMethod) string {
+	obfuscate := func(s string) string {
+		if method == logconfigurations.LogConfigurationsObfuscationMethodTypes.HASH_SHA256 {
+			sum := sha256.Sum256([]byte(s))
+			return hex.EncodeToString(sum[:])
+		}
+		return strings.Repeat("X", len([]rune(s)))
+	}
+
+	var out strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringSubmatchIndex(value, -1) {
+		groups := [][2]int{}
+		for i := 1; i < len(m)/2; i++ {
+			if m[2*i] >= 0 {
+				groups = append(groups, [2]int{m[2*i], m[2*i+1]})
+			}
+		}
+		if re.NumSubexp() == 0 {
+			groups = append(groups, [2]int{m[0], m[1]})
+		}
+
+		for _, g := range groups {
+			// Nested groups are obfuscated with their outermost group.
+			if g[0] < last {
+				continue
+			}
+			out.WriteString(value[last:g[0]])
+			out.WriteString(obfuscate(value[g[0]:g[1]]))
+			last = g[1]
+		}
+	}
+	out.WriteString(value[last:])
+
+	return out.String()
+}
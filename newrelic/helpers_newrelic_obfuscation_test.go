@@ -0,0 +1,125 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/logconfigurations"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateObfuscationExpressionRegex(t *testing.T) {
+	warns, errs := validateObfuscationExpressionRegex(`(\d{3})-\d{2}-\d{4}`, "regex")
+	require.Empty(t, warns)
+	require.Empty(t, errs)
+
+	for _, regex := range []string{`(?<=ssn=)\d+`, `(\w)\1`, `[a-`, `\d++`} {
+		_, errs := validateObfuscationExpressionRegex(regex, "regex")
+		require.Len(t, errs, 1, regex)
+		require.ErrorContains(t, errs[0], "invalid RE2 regular expression", regex)
+	}
+
+	warns, errs = validateObfuscationExpressionRegex(`(\d*)`, "regex")
+	require.Empty(t, errs)
+	require.Len(t, warns, 1)
+	require.Contains(t, warns[0], "matches the empty string")
+
+	warns, errs = validateObfuscationExpressionRegex(`\d{16}`, "regex")
+	require.Empty(t, errs)
+	require.Len(t, warns, 1)
+	require.Contains(t, warns[0], "no capture group")
+}
+
+func TestObfuscateLogValue(t *testing.T) {
+	mask := logconfigurations.LogConfigurationsObfuscationMethodTypes.MASK
+
+	require.Equal(t, "card XXXXXXXXX ok", obfuscateLogValue("card 1234-5678 ok", regexp.MustCompile(`\d{4}-\d{4}`), mask))
+	require.Equal(t, "user=XXXX pass=XX", obfuscateLogValue("user=jane pass=pw", regexp.MustCompile(`=(\w+)`), mask))
+	require.Equal(t, "XXé", obfuscateLogValue("näé", regexp.MustCompile(`(nä)`), mask), "masks one character per rune")
+	require.Equal(t, "XXXXXX-1234", obfuscateLogValue("123-45-1234", regexp.MustCompile(`((\d{3})-(\d{2}))-\d{4}`), mask), "nested groups are obfuscated with the outermost group")
+	require.Equal(t, "unchanged", obfuscateLogValue("unchanged", regexp.MustCompile(`\d+`), mask))
+
+	sum := sha256.Sum256([]byte("jane"))
+	require.Equal(t, "user="+hex.EncodeToString(sum[:]), obfuscateLogValue("user=jane", regexp.MustCompile(`user=(\w+)`), logconfigurations.LogConfigurationsObfuscationMethodTypes.HASH_SHA256))
+}
+
+func TestObfuscationActionApply(t *testing.T) {
+	action, err := newObfuscationAction(`\d{4}`, logconfigurations.LogConfigurationsObfuscationMethodTypes.MASK, nil)
+	require.NoError(t, err)
+
+	record := map[string]string{"card": "4111", "pin": "1234", "name": "jane"}
+	require.Equal(t, []string{"card", "pin"}, action.apply(record), "applies to all attributes when none are listed")
+	require.Equal(t, map[string]string{"card": "XXXX", "pin": "XXXX", "name": "jane"}, record)
+
+	action.Attributes = []string{"card", "missing"}
+	record = map[string]string{"card": "4111", "pin": "1234"}
+	require.Equal(t, []string{"card"}, action.apply(record))
+	require.Equal(t, "1234", record["pin"])
+
+	_, err = newObfuscationAction(`(?=x)`, logconfigurations.LogConfigurationsObfuscationMethodTypes.MASK, nil)
+	require.Error(t, err)
+}
+
+func TestDataSourceNewRelicTestObfuscationRead(t *testing.T) {
+	sum := sha256.Sum256([]byte("jane@example.com"))
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicTestObfuscation().Schema, map[string]interface{}{
+		"filter": "logtype = 'app'",
+		"action": []interface{}{
+			map[string]interface{}{
+				"attribute": []interface{}{"email"},
+				"regex":     `.+`,
+				"method":    "HASH_SHA256",
+			},
+			map[string]interface{}{
+				"regex":  `ssn=(\d{3}-\d{2}-\d{4})`,
+				"method": "MASK",
+			},
+		},
+		"records": []interface{}{
+			`{"logtype": "app", "email": "jane@example.com", "message": "ssn=123-45-6789 ok"}`,
+			`{"logtype": "web", "email": "jane@example.com"}`,
+		},
+	})
+
+	diags := dataSourceNewRelicTestObfuscationRead(nil, d, nil)
+	require.False(t, diags.HasError(), diags)
+
+	require.Equal(t, true, d.Get("results.0.matched"))
+	require.Equal(t, `{"email":"`+hex.EncodeToString(sum[:])+`","logtype":"app","message":"ssn=XXXXXXXXXXX ok"}`, d.Get("results.0.output"))
+	require.Equal(t, []interface{}{"email", "message"}, d.Get("results.0.obfuscated_attributes"))
+
+	require.Equal(t, false, d.Get("results.1.matched"))
+	require.Equal(t, `{"email":"jane@example.com","logtype":"web"}`, d.Get("results.1.output"))
+	require.Empty(t, d.Get("results.1.obfuscated_attributes"))
+
+	d = schema.TestResourceDataRaw(t, dataSourceNewRelicTestObfuscation().Schema, map[string]interface{}{
+		"action": []interface{}{
+			map[string]interface{}{
+				"attribute": []interface{}{"message"},
+				"regex":     `\b\d{16}\b`,
+				"method":    "MASK",
+			},
+		},
+		"log_lines": []interface{}{"paid with 4111111111111111", "no card"},
+	})
+
+	diags = dataSourceNewRelicTestObfuscationRead(nil, d, nil)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "paid with XXXXXXXXXXXXXXXX", d.Get("results.0.output"))
+	require.Equal(t, "no card", d.Get("results.1.output"))
+	require.Equal(t, true, d.Get("results.1.matched"))
+
+	d = schema.TestResourceDataRaw(t, dataSourceNewRelicTestObfuscation().Schema, map[string]interface{}{
+		"filter":    "lower(logtype) = 'app'",
+		"action":    []interface{}{map[string]interface{}{"regex": `.+`, "method": "MASK"}},
+		"log_lines": []interface{}{"x"},
+	})
+	diags = dataSourceNewRelicTestObfuscationRead(nil, d, nil)
+	require.True(t, diags.HasError())
+}
@@ -0,0 +1,374 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type serviceLevelTemplateName string
+
+var serviceLevelTemplateNames = struct {
+	apmLatency           serviceLevelTemplateName
+	apmErrorRate         serviceLevelTemplateName
+	browserCoreWebVitals serviceLevelTemplateName
+	syntheticsSuccess    serviceLevelTemplateName
+	kafkaLag             serviceLevelTemplateName
+}{
+	apmLatency:           "apm_latency",
+	apmErrorRate:         "apm_error_rate",
+	browserCoreWebVitals: "browser_core_web_vitals",
+	syntheticsSuccess:    "synthetics_success",
+	kafkaLag:             "kafka_lag",
+}
+
+const (
+	serviceLevelWebVitalLargestContentfulPaint = "largest_contentful_paint"
+	serviceLevelWebVitalInteractionToNextPaint = "interaction_to_next_paint"
+)
+
+// Default "good" thresholds (in seconds) for the Core Web Vitals supported by the
+// browser_core_web_vitals template, as recommended by web.dev.
+var serviceLevelTemplateWebVitals = map[string]struct {
+	timingName       string
+	defaultThreshold float64
+}{
+	serviceLevelWebVitalLargestContentfulPaint: {timingName: "largestContentfulPaint", defaultThreshold: 2.5},
+	serviceLevelWebVitalInteractionToNextPaint: {timingName: "interactionToNextPaint", defaultThreshold: 0.2},
+}
+
+// serviceLevelTemplateKeys lists the attributes of the template block which must be
+// known at plan time for the template to be expanded into events.
+var serviceLevelTemplateKeys = []string{
+	"account_id",
+	"entity_guid",
+	"threshold",
+	"transaction_type",
+	"web_vital",
+	"consumer_group",
+	"topic",
+}
+
+func serviceLevelTemplateSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(serviceLevelTemplateNames.apmLatency),
+					string(serviceLevelTemplateNames.apmErrorRate),
+					string(serviceLevelTemplateNames.browserCoreWebVitals),
+					string(serviceLevelTemplateNames.syntheticsSuccess),
+					string(serviceLevelTemplateNames.kafkaLag),
+				}, false),
+				Description: "The name of the SLI template to expand into events.",
+			},
+			"account_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The ID of the account that contains the NRDB data for the SLI/SLO calculations.",
+			},
+			"entity_guid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The GUID of the entity whose data is queried. Defaults to the resource's guid.",
+			},
+			"threshold": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "The limit under which an event is considered good (seconds for latency templates, messages for kafka_lag).",
+			},
+			"transaction_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Web", "Other"}, false),
+				Description:  "The APM transaction type to consider. Only valid for the apm_latency template.",
+			},
+			"web_vital": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					serviceLevelWebVitalLargestContentfulPaint,
+					serviceLevelWebVitalInteractionToNextPaint,
+				}, false),
+				Description: "The Core Web Vital to measure. Only valid for the browser_core_web_vitals template.",
+			},
+			"consumer_group": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Kafka consumer group to consider. Only valid for the kafka_lag template.",
+			},
+			"topic": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Kafka topic to consider. Only valid for the kafka_lag template.",
+			},
+		},
+	}
+}
+
+// serviceLevelTemplate holds the arguments of a `template` block.
+type serviceLevelTemplate struct {
+	Name            serviceLevelTemplateName
+	AccountID       int
+	EntityGUID      string
+	Threshold       *float64
+	TransactionType string
+	WebVital        string
+	ConsumerGroup   string
+	Topic           string
+}
+
+// serviceLevelTemplateEvents holds the NRQL fragments a template expands into.
+// Exactly one of good or bad is set.
+type serviceLevelTemplateEvents struct {
+	validFrom  string
+	validWhere string
+	goodFrom   string
+	goodWhere  string
+	badFrom    string
+	badWhere   string
+}
+
+func expandServiceLevelTemplate(cfg map[string]interface{}, defaultEntityGUID string, thresholdConfigured bool) serviceLevelTemplate {
+	template := serviceLevelTemplate{
+		Name:            serviceLevelTemplateName(cfg["name"].(string)),
+		AccountID:       cfg["account_id"].(int),
+		EntityGUID:      cfg["entity_guid"].(string),
+		TransactionType: cfg["transaction_type"].(string),
+		WebVital:        cfg["web_vital"].(string),
+		ConsumerGroup:   cfg["consumer_group"].(string),
+		Topic:           cfg["topic"].(string),
+	}
+
+	if template.EntityGUID == "" {
+		template.EntityGUID = defaultEntityGUID
+	}
+
+	if threshold, ok := cfg["threshold"].(float64); ok && thresholdConfigured {
+		template.Threshold = &threshold
+	}
+
+	return template
+}
+
+// The SDK does not distinguish an unset float from zero, so whether the
+// threshold is set is read from the raw configuration. Without one, e.g. when
+// refreshing, a zero threshold is treated as "not provided".
+func serviceLevelTemplateThresholdConfigured(d interface {
+	Get(key string) interface{}
+	GetRawConfig() cty.Value
+}) bool {
+	rawConfiguration := d.GetRawConfig()
+	if rawConfiguration.IsNull() || !rawConfiguration.IsKnown() {
+		return d.Get("template.0.threshold").(float64) != 0
+	}
+
+	templates := rawConfiguration.GetAttr("template")
+	if templates.IsNull() || !templates.IsKnown() || templates.LengthInt() == 0 {
+		return false
+	}
+
+	return !templates.Index(cty.NumberIntVal(0)).GetAttr("threshold").IsNull()
+}
+
+// Validates the template arguments and builds the NRQL fragments for it.
+func (t serviceLevelTemplate) events() (*serviceLevelTemplateEvents, error) {
+	if t.EntityGUID == "" {
+		return nil, fmt.Errorf("template %q requires an entity GUID", t.Name)
+	}
+
+	if t.TransactionType != "" && t.Name != serviceLevelTemplateNames.apmLatency {
+		return nil, fmt.Errorf("transaction_type is only supported by the %q template", serviceLevelTemplateNames.apmLatency)
+	}
+	if t.WebVital != "" && t.Name != serviceLevelTemplateNames.browserCoreWebVitals {
+		return nil, fmt.Errorf("web_vital is only supported by the %q template", serviceLevelTemplateNames.browserCoreWebVitals)
+	}
+	if (t.ConsumerGroup != "" || t.Topic != "") && t.Name != serviceLevelTemplateNames.kafkaLag {
+		return nil, fmt.Errorf("consumer_group and topic are only supported by the %q template", serviceLevelTemplateNames.kafkaLag)
+	}
+
+	entityFilter := fmt.Sprintf("entityGuid = '%s'", escapeSingleQuote(t.EntityGUID))
+
+	switch t.Name {
+	case serviceLevelTemplateNames.apmLatency:
+		if t.Threshold == nil {
+			return nil, fmt.Errorf("template %q requires a threshold", t.Name)
+		}
+
+		transactionType := t.TransactionType
+		if transactionType == "" {
+			transactionType = "Web"
+		}
+
+		valid := fmt.Sprintf("%s AND transactionType = '%s'", entityFilter, transactionType)
+		return &serviceLevelTemplateEvents{
+			validFrom:  "Transaction",
+			validWhere: valid,
+			goodFrom:   "Transaction",
+			goodWhere:  fmt.Sprintf("%s AND duration < %s", valid, formatServiceLevelThreshold(*t.Threshold)),
+		}, nil
+
+	case serviceLevelTemplateNames.apmErrorRate:
+		if t.Threshold != nil {
+			return nil, fmt.Errorf("template %q does not support a threshold", t.Name)
+		}
+
+		return &serviceLevelTemplateEvents{
+			validFrom:  "Transaction",
+			validWhere: entityFilter,
+			badFrom:    "TransactionError",
+			badWhere:   fmt.Sprintf("%s AND error.expected IS FALSE", entityFilter),
+		}, nil
+
+	case serviceLevelTemplateNames.browserCoreWebVitals:
+		webVital := t.WebVital
+		if webVital == "" {
+			webVital = serviceLevelWebVitalLargestContentfulPaint
+		}
+
+		vital := serviceLevelTemplateWebVitals[webVital]
+		threshold := vital.defaultThreshold
+		if t.Threshold != nil {
+			threshold = *t.Threshold
+		}
+
+		valid := fmt.Sprintf("%s AND timingName = '%s'", entityFilter, vital.timingName)
+		return &serviceLevelTemplateEvents{
+			validFrom:  "PageViewTiming",
+			validWhere: valid,
+			goodFrom:   "PageViewTiming",
+			goodWhere:  fmt.Sprintf("%s AND %s < %s", valid, vital.timingName, formatServiceLevelThreshold(threshold)),
+		}, nil
+
+	case serviceLevelTemplateNames.syntheticsSuccess:
+		if t.Threshold != nil {
+			return nil, fmt.Errorf("template %q does not support a threshold", t.Name)
+		}
+
+		return &serviceLevelTemplateEvents{
+			validFrom:  "SyntheticCheck",
+			validWhere: entityFilter,
+			goodFrom:   "SyntheticCheck",
+			goodWhere:  fmt.Sprintf("%s AND result = 'SUCCESS'", entityFilter),
+		}, nil
+
+	case serviceLevelTemplateNames.kafkaLag:
+		if t.Threshold == nil {
+			return nil, fmt.Errorf("template %q requires a threshold", t.Name)
+		}
+
+		valid := entityFilter
+		if t.ConsumerGroup != "" {
+			valid = fmt.Sprintf("%s AND consumerGroup = '%s'", valid, escapeSingleQuote(t.ConsumerGroup))
+		}
+		if t.Topic != "" {
+			valid = fmt.Sprintf("%s AND topic = '%s'", valid, escapeSingleQuote(t.Topic))
+		}
+
+		return &serviceLevelTemplateEvents{
+			validFrom:  "KafkaOffsetSample",
+			validWhere: valid,
+			goodFrom:   "KafkaOffsetSample",
+			goodWhere:  fmt.Sprintf("%s AND consumer.lag <= %s", valid, formatServiceLevelThreshold(*t.Threshold)),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown service level template %q", t.Name)
+}
+
+// Expands the template into the same shape as the `events` block, so that the
+// result can be stored in state and consumed by the existing expand functions.
+func (t serviceLevelTemplate) flattenEvents() ([]interface{}, error) {
+	events, err := t.events()
+	if err != nil {
+		return nil, err
+	}
+
+	eventsMap := map[string]interface{}{
+		"account_id":   t.AccountID,
+		"valid_events": flattenServiceLevelTemplateEventsQuery(events.validFrom, events.validWhere),
+	}
+
+	if events.goodFrom != "" {
+		eventsMap["good_events"] = flattenServiceLevelTemplateEventsQuery(events.goodFrom, events.goodWhere)
+	}
+
+	if events.badFrom != "" {
+		eventsMap["bad_events"] = flattenServiceLevelTemplateEventsQuery(events.badFrom, events.badWhere)
+	}
+
+	return []interface{}{eventsMap}, nil
+}
+
+func flattenServiceLevelTemplateEventsQuery(from string, where string) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"from":  from,
+			"where": where,
+			"select": []interface{}{
+				map[string]interface{}{
+					"attribute": "",
+					"function":  "COUNT",
+					"threshold": float64(0),
+				},
+			},
+		},
+	}
+}
+
+func formatServiceLevelThreshold(threshold float64) string {
+	return strconv.FormatFloat(threshold, 'f', -1, 64)
+}
+
+// resourceNewRelicServiceLevelCustomizeDiff expands the `template` block (if any)
+// into `events` at plan time, so the generated NRQL is visible in the plan.
+func resourceNewRelicServiceLevelCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	templates, ok := d.GetOk("template")
+	if !ok || len(templates.([]interface{})) == 0 {
+		return nil
+	}
+
+	keys := []string{"guid"}
+	for _, key := range serviceLevelTemplateKeys {
+		keys = append(keys, fmt.Sprintf("template.0.%s", key))
+	}
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("events")
+		}
+	}
+
+	template := expandServiceLevelTemplate(templates.([]interface{})[0].(map[string]interface{}), d.Get("guid").(string), serviceLevelTemplateThresholdConfigured(d))
+	events, err := template.flattenEvents()
+	if err != nil {
+		return err
+	}
+
+	return d.SetNew("events", events)
+}
+
+// Writes the events generated from the `template` block into the resource data,
+// so that the regular events expanders can be used on create and update.
+func setServiceLevelTemplateEvents(d *schema.ResourceData) error {
+	templates, ok := d.GetOk("template")
+	if !ok || len(templates.([]interface{})) == 0 {
+		return nil
+	}
+
+	template := expandServiceLevelTemplate(templates.([]interface{})[0].(map[string]interface{}), d.Get("guid").(string), serviceLevelTemplateThresholdConfigured(d))
+	events, err := template.flattenEvents()
+	if err != nil {
+		return err
+	}
+
+	return d.Set("events", events)
+}
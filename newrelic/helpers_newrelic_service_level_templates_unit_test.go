@@ -0,0 +1,223 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceLevelTemplateEvents(t *testing.T) {
+	threshold := 0.5
+
+	tests := []struct {
+		name     string
+		template serviceLevelTemplate
+		expected serviceLevelTemplateEvents
+	}{
+		{
+			name:     "apm_latency defaults to web transactions",
+			template: serviceLevelTemplate{Name: "apm_latency", EntityGUID: "MXxBUE18QVBQTElDQVRJT058MQ", Threshold: &threshold},
+			expected: serviceLevelTemplateEvents{
+				validFrom:  "Transaction",
+				validWhere: "entityGuid = 'MXxBUE18QVBQTElDQVRJT058MQ' AND transactionType = 'Web'",
+				goodFrom:   "Transaction",
+				goodWhere:  "entityGuid = 'MXxBUE18QVBQTElDQVRJT058MQ' AND transactionType = 'Web' AND duration < 0.5",
+			},
+		},
+		{
+			name:     "apm_latency with transaction type",
+			template: serviceLevelTemplate{Name: "apm_latency", EntityGUID: "abc", Threshold: &threshold, TransactionType: "Other"},
+			expected: serviceLevelTemplateEvents{
+				validFrom:  "Transaction",
+				validWhere: "entityGuid = 'abc' AND transactionType = 'Other'",
+				goodFrom:   "Transaction",
+				goodWhere:  "entityGuid = 'abc' AND transactionType = 'Other' AND duration < 0.5",
+			},
+		},
+		{
+			name:     "apm_error_rate",
+			template: serviceLevelTemplate{Name: "apm_error_rate", EntityGUID: "abc"},
+			expected: serviceLevelTemplateEvents{
+				validFrom:  "Transaction",
+				validWhere: "entityGuid = 'abc'",
+				badFrom:    "TransactionError",
+				badWhere:   "entityGuid = 'abc' AND error.expected IS FALSE",
+			},
+		},
+		{
+			name:     "browser_core_web_vitals defaults to LCP",
+			template: serviceLevelTemplate{Name: "browser_core_web_vitals", EntityGUID: "abc"},
+			expected: serviceLevelTemplateEvents{
+				validFrom:  "PageViewTiming",
+				validWhere: "entityGuid = 'abc' AND timingName = 'largestContentfulPaint'",
+				goodFrom:   "PageViewTiming",
+				goodWhere:  "entityGuid = 'abc' AND timingName = 'largestContentfulPaint' AND largestContentfulPaint < 2.5",
+			},
+		},
+		{
+			name:     "browser_core_web_vitals INP with custom threshold",
+			template: serviceLevelTemplate{Name: "browser_core_web_vitals", EntityGUID: "abc", WebVital: "interaction_to_next_paint", Threshold: &threshold},
+			expected: serviceLevelTemplateEvents{
+				validFrom:  "PageViewTiming",
+				validWhere: "entityGuid = 'abc' AND timingName = 'interactionToNextPaint'",
+				goodFrom:   "PageViewTiming",
+				goodWhere:  "entityGuid = 'abc' AND timingName = 'interactionToNextPaint' AND interactionToNextPaint < 0.5",
+			},
+		},
+		{
+			name:     "synthetics_success",
+			template: serviceLevelTemplate{Name: "synthetics_success", EntityGUID: "abc"},
+			expected: serviceLevelTemplateEvents{
+				validFrom:  "SyntheticCheck",
+				validWhere: "entityGuid = 'abc'",
+				goodFrom:   "SyntheticCheck",
+				goodWhere:  "entityGuid = 'abc' AND result = 'SUCCESS'",
+			},
+		},
+		{
+			name:     "kafka_lag with consumer group and topic",
+			template: serviceLevelTemplate{Name: "kafka_lag", EntityGUID: "abc", Threshold: &threshold, ConsumerGroup: "billing's", Topic: "orders"},
+			expected: serviceLevelTemplateEvents{
+				validFrom:  "KafkaOffsetSample",
+				validWhere: "entityGuid = 'abc' AND consumerGroup = 'billing\\'s' AND topic = 'orders'",
+				goodFrom:   "KafkaOffsetSample",
+				goodWhere:  "entityGuid = 'abc' AND consumerGroup = 'billing\\'s' AND topic = 'orders' AND consumer.lag <= 0.5",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := tt.template.events()
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, *events)
+		})
+	}
+}
+
+func TestServiceLevelTemplateEvents_Errors(t *testing.T) {
+	threshold := 1.0
+
+	tests := []struct {
+		name     string
+		template serviceLevelTemplate
+		expected string
+	}{
+		{
+			name:     "missing entity guid",
+			template: serviceLevelTemplate{Name: "synthetics_success"},
+			expected: "requires an entity GUID",
+		},
+		{
+			name:     "apm_latency without threshold",
+			template: serviceLevelTemplate{Name: "apm_latency", EntityGUID: "abc"},
+			expected: "requires a threshold",
+		},
+		{
+			name:     "kafka_lag without threshold",
+			template: serviceLevelTemplate{Name: "kafka_lag", EntityGUID: "abc"},
+			expected: "requires a threshold",
+		},
+		{
+			name:     "apm_error_rate with threshold",
+			template: serviceLevelTemplate{Name: "apm_error_rate", EntityGUID: "abc", Threshold: &threshold},
+			expected: "does not support a threshold",
+		},
+		{
+			name:     "topic outside kafka_lag",
+			template: serviceLevelTemplate{Name: "synthetics_success", EntityGUID: "abc", Topic: "orders"},
+			expected: "only supported by the \"kafka_lag\" template",
+		},
+		{
+			name:     "transaction type outside apm_latency",
+			template: serviceLevelTemplate{Name: "apm_error_rate", EntityGUID: "abc", TransactionType: "Web"},
+			expected: "only supported by the \"apm_latency\" template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.template.events()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestSetServiceLevelTemplateEvents(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNewRelicServiceLevel().Schema, map[string]interface{}{
+		"guid": "MXxBUE18QVBQTElDQVRJT058MQ",
+		"name": "Latency",
+		"template": []interface{}{
+			map[string]interface{}{
+				"name":       "apm_latency",
+				"account_id": 12345678,
+				"threshold":  0.25,
+			},
+		},
+	})
+
+	require.NoError(t, setServiceLevelTemplateEvents(d))
+
+	createInput := expandServiceLevelCreateInput(d)
+	require.Equal(t, 12345678, createInput.Events.AccountID)
+	require.Equal(t, "Transaction", string(createInput.Events.ValidEvents.From))
+	require.Equal(t, "entityGuid = 'MXxBUE18QVBQTElDQVRJT058MQ' AND transactionType = 'Web'", string(createInput.Events.ValidEvents.Where))
+	require.Equal(t, "entityGuid = 'MXxBUE18QVBQTElDQVRJT058MQ' AND transactionType = 'Web' AND duration < 0.25", string(createInput.Events.GoodEvents.Where))
+	require.Equal(t, "COUNT", string(createInput.Events.GoodEvents.Select.Function))
+	require.Nil(t, createInput.Events.BadEvents)
+}
+
+func TestSetServiceLevelTemplateEvents_ZeroThreshold(t *testing.T) {
+	template := cty.ObjectVal(map[string]cty.Value{
+		"name":      cty.StringVal("kafka_lag"),
+		"threshold": cty.NumberIntVal(0),
+	})
+
+	d := resourceNewRelicServiceLevel().Data(&terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"guid":                  "MXxBUE18QVBQTElDQVRJT058MQ",
+			"name":                  "Lag",
+			"template.#":            "1",
+			"template.0.name":       "kafka_lag",
+			"template.0.account_id": "12345678",
+			"template.0.threshold":  "0",
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"template": cty.ListVal([]cty.Value{template}),
+		}),
+	})
+
+	require.True(t, serviceLevelTemplateThresholdConfigured(d))
+	require.NoError(t, setServiceLevelTemplateEvents(d))
+
+	createInput := expandServiceLevelCreateInput(d)
+	require.Equal(t, "entityGuid = 'MXxBUE18QVBQTElDQVRJT058MQ' AND consumer.lag <= 0", string(createInput.Events.GoodEvents.Where))
+}
+
+func TestServiceLevelTemplateThresholdConfigured_Unset(t *testing.T) {
+	d := resourceNewRelicServiceLevel().Data(&terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"guid":                  "MXxBUE18QVBQTElDQVRJT058MQ",
+			"template.#":            "1",
+			"template.0.name":       "kafka_lag",
+			"template.0.account_id": "12345678",
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"template": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"name":      cty.StringVal("kafka_lag"),
+				"threshold": cty.NullVal(cty.Number),
+			})}),
+		}),
+	})
+
+	require.False(t, serviceLevelTemplateThresholdConfigured(d))
+	require.ErrorContains(t, setServiceLevelTemplateEvents(d), "requires a threshold")
+}
@@ -0,0 +1,227 @@
+package newrelic
+
+import (
+	"sort"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/workloads"
+)
+
+// The workload status values, as computed by New Relic. UNKNOWN is used for
+// entities that are not configured for alerting and is ignored by rollups.
+const (
+	workloadStatusUnknown     = "UNKNOWN"
+	workloadStatusOperational = "OPERATIONAL"
+	workloadStatusDegraded    = "DEGRADED"
+	workloadStatusDisrupted   = "DISRUPTED"
+)
+
+var workloadStatusSeverity = map[string]int{
+	workloadStatusUnknown:     0,
+	workloadStatusOperational: 1,
+	workloadStatusDegraded:    2,
+	workloadStatusDisrupted:   3,
+}
+
+// workloadStatusEntity is an entity of a workload along with its current alert severity.
+type workloadStatusEntity struct {
+	GUID          string
+	Name          string
+	Type          string
+	AccountID     int
+	AlertSeverity string
+}
+
+// Maps the alert severity of an entity to the status it contributes to a workload.
+func (e workloadStatusEntity) status() string {
+	switch entities.EntityAlertSeverity(e.AlertSeverity) {
+	case entities.EntityAlertSeverityTypes.NOT_ALERTING:
+		return workloadStatusOperational
+	case entities.EntityAlertSeverityTypes.WARNING:
+		return workloadStatusDegraded
+	case entities.EntityAlertSeverityTypes.CRITICAL:
+		return workloadStatusDisrupted
+	default:
+		return workloadStatusUnknown
+	}
+}
+
+// workloadStatusRollup mirrors the rollup strategy of a status_config_automatic rule.
+type workloadStatusRollup struct {
+	Strategy       string
+	ThresholdType  string
+	ThresholdValue int
+}
+
+// Computes the status of a group of entities. Entities with an unknown status
+// are ignored; when no entity has a known status the result is UNKNOWN.
+//
+// With WORST_STATUS_WINS and a threshold, the worst status is only rolled up
+// once the number (FIXED) or percentage (PERCENTAGE) of non-operational
+// entities reaches the threshold value; otherwise the group is operational.
+func (r workloadStatusRollup) evaluate(groupEntities []workloadStatusEntity) string {
+	known := 0
+	nonOperational := 0
+	best := workloadStatusUnknown
+	worst := workloadStatusUnknown
+
+	for _, e := range groupEntities {
+		status := e.status()
+		if status == workloadStatusUnknown {
+			continue
+		}
+
+		known++
+		if status != workloadStatusOperational {
+			nonOperational++
+		}
+		if best == workloadStatusUnknown || workloadStatusSeverity[status] < workloadStatusSeverity[best] {
+			best = status
+		}
+		if workloadStatusSeverity[status] > workloadStatusSeverity[worst] {
+			worst = status
+		}
+	}
+
+	if known == 0 {
+		return workloadStatusUnknown
+	}
+
+	if workloads.WorkloadRollupStrategy(r.Strategy) == workloads.WorkloadRollupStrategyTypes.BEST_STATUS_WINS {
+		return best
+	}
+
+	switch workloads.WorkloadRuleThresholdType(r.ThresholdType) {
+	case workloads.WorkloadRuleThresholdTypeTypes.FIXED:
+		if nonOperational < r.ThresholdValue {
+			return workloadStatusOperational
+		}
+	case workloads.WorkloadRuleThresholdTypeTypes.PERCENTAGE:
+		if nonOperational*100 < r.ThresholdValue*known {
+			return workloadStatusOperational
+		}
+	}
+
+	return worst
+}
+
+// workloadStatusRule is a status_config_automatic rule along with the entities it resolves to.
+type workloadStatusRule struct {
+	Rollup   workloadStatusRollup
+	Entities []workloadStatusEntity
+}
+
+// workloadStatusRemainingRule is the remaining_entities_rule of a status_config_automatic block.
+type workloadStatusRemainingRule struct {
+	Rollup  workloadStatusRollup
+	GroupBy string
+}
+
+type workloadStatusRuleResult struct {
+	EntityGUIDs []string
+	Status      string
+}
+
+type workloadStatusGroupResult struct {
+	Group       string
+	EntityGUIDs []string
+	Status      string
+}
+
+type workloadStatusSimulation struct {
+	Status          string
+	RuleResults     []workloadStatusRuleResult
+	RemainingGroups []workloadStatusGroupResult
+}
+
+// Simulates the automatic status of a workload: every rule is rolled up on its
+// own, the remaining entities rule is applied (per group) to the workload
+// entities not covered by any rule, and the workload takes the worst of them.
+func simulateWorkloadAutomaticStatus(workloadEntities []workloadStatusEntity, rules []workloadStatusRule, remaining *workloadStatusRemainingRule) workloadStatusSimulation {
+	simulation := workloadStatusSimulation{Status: workloadStatusUnknown}
+	covered := map[string]bool{}
+
+	for _, rule := range rules {
+		status := rule.Rollup.evaluate(rule.Entities)
+		simulation.Status = worstWorkloadStatus(simulation.Status, status)
+		simulation.RuleResults = append(simulation.RuleResults, workloadStatusRuleResult{
+			EntityGUIDs: workloadStatusEntityGUIDs(rule.Entities),
+			Status:      status,
+		})
+
+		for _, e := range rule.Entities {
+			covered[e.GUID] = true
+		}
+	}
+
+	if remaining == nil {
+		return simulation
+	}
+
+	groups := map[string][]workloadStatusEntity{}
+	for _, e := range workloadEntities {
+		if covered[e.GUID] {
+			continue
+		}
+
+		group := "ALL"
+		if workloads.WorkloadGroupRemainingEntitiesRuleBy(remaining.GroupBy) == workloads.WorkloadGroupRemainingEntitiesRuleByTypes.ENTITY_TYPE {
+			group = e.Type
+		}
+		groups[group] = append(groups[group], e)
+	}
+
+	groupNames := make([]string, 0, len(groups))
+	for name := range groups {
+		groupNames = append(groupNames, name)
+	}
+	sort.Strings(groupNames)
+
+	for _, name := range groupNames {
+		status := remaining.Rollup.evaluate(groups[name])
+		simulation.Status = worstWorkloadStatus(simulation.Status, status)
+		simulation.RemainingGroups = append(simulation.RemainingGroups, workloadStatusGroupResult{
+			Group:       name,
+			EntityGUIDs: workloadStatusEntityGUIDs(groups[name]),
+			Status:      status,
+		})
+	}
+
+	return simulation
+}
+
+func worstWorkloadStatus(a string, b string) string {
+	if workloadStatusSeverity[b] > workloadStatusSeverity[a] {
+		return b
+	}
+
+	return a
+}
+
+func workloadStatusEntityGUIDs(in []workloadStatusEntity) []string {
+	out := make([]string, len(in))
+	for i, e := range in {
+		out[i] = e.GUID
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+// Merges entity lists, dropping duplicated GUIDs and keeping the first occurrence.
+func mergeWorkloadStatusEntities(lists ...[]workloadStatusEntity) []workloadStatusEntity {
+	seen := map[string]bool{}
+	var out []workloadStatusEntity
+
+	for _, list := range lists {
+		for _, e := range list {
+			if seen[e.GUID] {
+				continue
+			}
+			seen[e.GUID] = true
+			out = append(out, e)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,140 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testWorkloadStatusEntity(guid string, entityType string, severity string) workloadStatusEntity {
+	return workloadStatusEntity{GUID: guid, Type: entityType, AlertSeverity: severity}
+}
+
+func TestWorkloadStatusRollupEvaluate(t *testing.T) {
+	group := []workloadStatusEntity{
+		testWorkloadStatusEntity("a", "APM-APPLICATION", "NOT_ALERTING"),
+		testWorkloadStatusEntity("b", "APM-APPLICATION", "NOT_ALERTING"),
+		testWorkloadStatusEntity("c", "APM-APPLICATION", "WARNING"),
+		testWorkloadStatusEntity("d", "APM-APPLICATION", "CRITICAL"),
+		testWorkloadStatusEntity("e", "APM-APPLICATION", "NOT_CONFIGURED"),
+	}
+
+	tests := []struct {
+		name     string
+		rollup   workloadStatusRollup
+		entities []workloadStatusEntity
+		expected string
+	}{
+		{
+			name:     "worst status wins",
+			rollup:   workloadStatusRollup{Strategy: "WORST_STATUS_WINS"},
+			entities: group,
+			expected: workloadStatusDisrupted,
+		},
+		{
+			name:     "best status wins",
+			rollup:   workloadStatusRollup{Strategy: "BEST_STATUS_WINS"},
+			entities: group,
+			expected: workloadStatusOperational,
+		},
+		{
+			name:     "fixed threshold reached",
+			rollup:   workloadStatusRollup{Strategy: "WORST_STATUS_WINS", ThresholdType: "FIXED", ThresholdValue: 2},
+			entities: group,
+			expected: workloadStatusDisrupted,
+		},
+		{
+			name:     "fixed threshold not reached",
+			rollup:   workloadStatusRollup{Strategy: "WORST_STATUS_WINS", ThresholdType: "FIXED", ThresholdValue: 3},
+			entities: group,
+			expected: workloadStatusOperational,
+		},
+		{
+			// Unknown entities are ignored, so 2 out of 4 entities are non-operational.
+			name:     "percentage threshold reached",
+			rollup:   workloadStatusRollup{Strategy: "WORST_STATUS_WINS", ThresholdType: "PERCENTAGE", ThresholdValue: 50},
+			entities: group,
+			expected: workloadStatusDisrupted,
+		},
+		{
+			name:     "percentage threshold not reached",
+			rollup:   workloadStatusRollup{Strategy: "WORST_STATUS_WINS", ThresholdType: "PERCENTAGE", ThresholdValue: 51},
+			entities: group,
+			expected: workloadStatusOperational,
+		},
+		{
+			name:     "only unknown entities",
+			rollup:   workloadStatusRollup{Strategy: "WORST_STATUS_WINS"},
+			entities: []workloadStatusEntity{testWorkloadStatusEntity("e", "APM-APPLICATION", "NOT_CONFIGURED")},
+			expected: workloadStatusUnknown,
+		},
+		{
+			name:     "no entities",
+			rollup:   workloadStatusRollup{Strategy: "BEST_STATUS_WINS"},
+			expected: workloadStatusUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.rollup.evaluate(tt.entities))
+		})
+	}
+}
+
+func TestSimulateWorkloadAutomaticStatus(t *testing.T) {
+	apmOk := testWorkloadStatusEntity("apm-ok", "APM-APPLICATION", "NOT_ALERTING")
+	apmWarning := testWorkloadStatusEntity("apm-warning", "APM-APPLICATION", "WARNING")
+	hostCritical := testWorkloadStatusEntity("host-critical", "INFRA-HOST", "CRITICAL")
+	hostOk := testWorkloadStatusEntity("host-ok", "INFRA-HOST", "NOT_ALERTING")
+	workloadEntities := []workloadStatusEntity{apmOk, apmWarning, hostCritical, hostOk}
+
+	rules := []workloadStatusRule{
+		{
+			Rollup:   workloadStatusRollup{Strategy: "WORST_STATUS_WINS"},
+			Entities: []workloadStatusEntity{apmOk, apmWarning},
+		},
+	}
+
+	t.Run("remaining entities grouped by entity type", func(t *testing.T) {
+		simulation := simulateWorkloadAutomaticStatus(workloadEntities, rules, &workloadStatusRemainingRule{
+			Rollup:  workloadStatusRollup{Strategy: "BEST_STATUS_WINS"},
+			GroupBy: "ENTITY_TYPE",
+		})
+
+		require.Equal(t, workloadStatusDegraded, simulation.Status)
+		require.Equal(t, []workloadStatusRuleResult{
+			{EntityGUIDs: []string{"apm-ok", "apm-warning"}, Status: workloadStatusDegraded},
+		}, simulation.RuleResults)
+		require.Equal(t, []workloadStatusGroupResult{
+			{Group: "INFRA-HOST", EntityGUIDs: []string{"host-critical", "host-ok"}, Status: workloadStatusOperational},
+		}, simulation.RemainingGroups)
+	})
+
+	t.Run("remaining entities without grouping", func(t *testing.T) {
+		simulation := simulateWorkloadAutomaticStatus(workloadEntities, rules, &workloadStatusRemainingRule{
+			Rollup:  workloadStatusRollup{Strategy: "WORST_STATUS_WINS"},
+			GroupBy: "NONE",
+		})
+
+		require.Equal(t, workloadStatusDisrupted, simulation.Status)
+		require.Equal(t, "ALL", simulation.RemainingGroups[0].Group)
+	})
+
+	t.Run("without remaining entities rule", func(t *testing.T) {
+		simulation := simulateWorkloadAutomaticStatus(workloadEntities, rules, nil)
+
+		require.Equal(t, workloadStatusDegraded, simulation.Status)
+		require.Empty(t, simulation.RemainingGroups)
+	})
+}
+
+func TestMergeWorkloadStatusEntities(t *testing.T) {
+	a := testWorkloadStatusEntity("a", "APM-APPLICATION", "NOT_ALERTING")
+	b := testWorkloadStatusEntity("b", "APM-APPLICATION", "WARNING")
+
+	merged := mergeWorkloadStatusEntities([]workloadStatusEntity{a, b}, []workloadStatusEntity{b, a})
+	require.Equal(t, []workloadStatusEntity{a, b}, merged)
+}
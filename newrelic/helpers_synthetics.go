@@ -107,7 +107,7 @@ func syntheticsMonitorLocationsAsStringsSchema() map[string]*schema.Schema {
 		},
 		"locations_public": {
 			Type:         schema.TypeSet,
-			Elem:         &schema.Schema{Type: schema.TypeString},
+			Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validateSyntheticsPublicLocation},
 			Description:  "Publicly available location names in which the monitor will run.",
 			Optional:     true,
 			AtLeastOneOf: []string{"locations_public", "locations_private"},
@@ -456,28 +456,14 @@ var syntheticsPublicLocations = struct {
 	AP_SOUTHEAST_2: "AP_SOUTHEAST_2",
 }
 
-var syntheticsPublicLocationsMap = map[string]SyntheticsPublicLocation{
-	"Columbus, OH, USA":      syntheticsPublicLocations.US_EAST_2,
-	"Montreal, Québec, CA":   syntheticsPublicLocations.CA_CENTRAL_1,
-	"Portland, OR, USA":      syntheticsPublicLocations.US_WEST_2,
-	"San Francisco, CA, USA": syntheticsPublicLocations.US_WEST_1,
-	"Washington, DC, USA":    syntheticsPublicLocations.US_EAST_1,
-	"São Paulo, BR":          syntheticsPublicLocations.SA_EAST_1,
-	"Hong Kong, HK":          syntheticsPublicLocations.AP_EAST_1,
-	"Manama, BH":             syntheticsPublicLocations.ME_SOUTH_1,
-	"Mumbai, IN":             syntheticsPublicLocations.AP_SOUTH_1,
-	"Seoul, KR":              syntheticsPublicLocations.AP_NORTHEAST_2,
-	"Singapore, SG":          syntheticsPublicLocations.AP_SOUTHEAST_1,
-	"Tokyo, JP":              syntheticsPublicLocations.AP_NORTHEAST_1,
-	"Dublin, IE":             syntheticsPublicLocations.EU_WEST_1,
-	"Frankfurt, DE":          syntheticsPublicLocations.EU_CENTRAL_1,
-	"London, England, UK":    syntheticsPublicLocations.EU_WEST_2,
-	"Milan, IT":              syntheticsPublicLocations.EU_SOUTH_1,
-	"Paris, FR":              syntheticsPublicLocations.EU_WEST_3,
-	"Stockholm, SE":          syntheticsPublicLocations.EU_NORTH_1,
-	"Sydney, AU":             syntheticsPublicLocations.AP_SOUTHEAST_2,
-	"Cape Town, ZA":          syntheticsPublicLocations.AF_SOUTH_1,
-}
+// The public locations by the label monitor entities are tagged with.
+var syntheticsPublicLocationsMap = func() map[string]SyntheticsPublicLocation {
+	m := make(map[string]SyntheticsPublicLocation, len(syntheticsPublicLocationCatalog))
+	for _, l := range syntheticsPublicLocationCatalog {
+		m[l.label] = l.id
+	}
+	return m
+}()
 
 func getPublicLocationsFromEntityTags(tags []entities.EntityTag) []string {
 	out := []string{}
@@ -3,7 +3,9 @@ package newrelic
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -120,24 +122,35 @@ func Provider() *schema.Provider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"newrelic_account":                      dataSourceNewRelicAccount(),
-			"newrelic_alert_channel":                dataSourceNewRelicAlertChannel(),
-			"newrelic_alert_policy":                 dataSourceNewRelicAlertPolicy(),
-			"newrelic_application":                  dataSourceNewRelicApplication(),
-			"newrelic_authentication_domain":        dataSourceNewRelicAuthenticationDomain(),
-			"newrelic_cloud_account":                dataSourceNewRelicCloudAccount(),
-			"newrelic_entity":                       dataSourceNewRelicEntity(),
-			"newrelic_group":                        dataSourceNewRelicGroup(),
-			"newrelic_key_transaction":              dataSourceNewRelicKeyTransaction(),
-			"newrelic_notification_destination":     dataSourceNewRelicNotificationDestination(),
-			"newrelic_obfuscation_expression":       dataSourceNewRelicObfuscationExpression(),
-			"newrelic_synthetics_private_location":  dataSourceNewRelicSyntheticsPrivateLocation(),
-			"newrelic_synthetics_secure_credential": dataSourceNewRelicSyntheticsSecureCredential(),
-			"newrelic_test_grok_pattern":            dataSourceNewRelicTestGrokPattern(),
-			"newrelic_service_level_alert_helper":   dataSourceNewRelicServiceLevelAlertHelper(),
-			"newrelic_user":                         dataSourceNewRelicUser(),
-			"newrelic_fleet_configuration":          dataSourceNewRelicFleetConfiguration(),
-			"newrelic_fleet_members":                dataSourceNewRelicFleetMembers(),
+			"newrelic_account":                            dataSourceNewRelicAccount(),
+			"newrelic_alert_channel":                      dataSourceNewRelicAlertChannel(),
+			"newrelic_alert_policy":                       dataSourceNewRelicAlertPolicy(),
+			"newrelic_alert_policy_migration":             dataSourceNewRelicAlertPolicyMigration(),
+			"newrelic_application":                        dataSourceNewRelicApplication(),
+			"newrelic_authentication_domain":              dataSourceNewRelicAuthenticationDomain(),
+			"newrelic_cardinality_usage":                  dataSourceNewRelicCardinalityUsage(),
+			"newrelic_change_tracking_events":             dataSourceNewRelicChangeTrackingEvents(),
+			"newrelic_cloud_account":                      dataSourceNewRelicCloudAccount(),
+			"newrelic_cloud_aws_iam_policy":               dataSourceNewRelicCloudAwsIAMPolicy(),
+			"newrelic_cloud_integrations_coverage":        dataSourceNewRelicCloudIntegrationsCoverage(),
+			"newrelic_entity":                             dataSourceNewRelicEntity(),
+			"newrelic_group":                              dataSourceNewRelicGroup(),
+			"newrelic_key_transaction":                    dataSourceNewRelicKeyTransaction(),
+			"newrelic_log_pipeline_simulation":            dataSourceNewRelicLogPipelineSimulation(),
+			"newrelic_notification_destination":           dataSourceNewRelicNotificationDestination(),
+			"newrelic_obfuscation_expression":             dataSourceNewRelicObfuscationExpression(),
+			"newrelic_synthetics_private_location":        dataSourceNewRelicSyntheticsPrivateLocation(),
+			"newrelic_synthetics_private_location_health": dataSourceNewRelicSyntheticsPrivateLocationHealth(),
+			"newrelic_synthetics_public_locations":        dataSourceNewRelicSyntheticsPublicLocations(),
+			"newrelic_synthetics_secure_credential":       dataSourceNewRelicSyntheticsSecureCredential(),
+			"newrelic_test_grok_pattern":                  dataSourceNewRelicTestGrokPattern(),
+			"newrelic_test_obfuscation":                   dataSourceNewRelicTestObfuscation(),
+			"newrelic_service_level_alert_helper":         dataSourceNewRelicServiceLevelAlertHelper(),
+			"newrelic_roles":                              dataSourceNewRelicRoles(),
+			"newrelic_user":                               dataSourceNewRelicUser(),
+			"newrelic_fleet_configuration":                dataSourceNewRelicFleetConfiguration(),
+			"newrelic_fleet_members":                      dataSourceNewRelicFleetMembers(),
+			"newrelic_workload_status_simulation":         dataSourceNewRelicWorkloadStatusSimulation(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -146,10 +159,13 @@ func Provider() *schema.Provider {
 			"newrelic_alert_condition":                          resourceNewRelicAlertCondition(),
 			"newrelic_alert_muting_rule":                        resourceNewRelicAlertMutingRule(),
 			"newrelic_alert_policy":                             resourceNewRelicAlertPolicy(),
+			"newrelic_alert_policy_bundle":                      resourceNewRelicAlertPolicyBundle(),
 			"newrelic_alert_policy_channel":                     resourceNewRelicAlertPolicyChannel(),
 			"newrelic_api_access_key":                           resourceNewRelicAPIAccessKey(),
 			"newrelic_application_settings":                     resourceNewRelicApplicationSettings(),
+			"newrelic_authentication_domain":                    resourceNewRelicAuthenticationDomain(),
 			"newrelic_browser_application":                      resourceNewRelicBrowserApplication(),
+			"newrelic_change_tracking_event":                    resourceNewRelicChangeTrackingEvent(),
 			"newrelic_cloud_aws_eu_sovereign_link_account":      resourceNewRelicCloudAwsEuSovereignLinkAccount(),
 			"newrelic_cloud_aws_eu_sovereign_integrations":      resourceNewRelicCloudAwsEuSovereignIntegrations(),
 			"newrelic_cloud_aws_govcloud_link_account":          resourceNewRelicAwsGovCloudLinkAccount(),
@@ -160,21 +176,29 @@ func Provider() *schema.Provider {
 			"newrelic_cloud_azure_integrations":                 resourceNewRelicCloudAzureIntegrations(),
 			"newrelic_cloud_gcp_integrations":                   resourceNewrelicCloudGcpIntegrations(),
 			"newrelic_cloud_gcp_link_account":                   resourceNewRelicCloudGcpLinkAccount(),
+			"newrelic_cloud_oci_integrations":                   resourceNewRelicCloudOciIntegrations(),
 			"newrelic_cloud_oci_link_account":                   resourceNewRelicCloudOciAccountLinkAccount(),
 			"newrelic_alert_compound_condition":                 resourceNewRelicAlertCompoundCondition(),
 			"newrelic_data_partition_rule":                      resourceNewRelicDataPartition(),
+			"newrelic_entity_tag":                               resourceNewRelicEntityTag(),
 			"newrelic_entity_tags":                              resourceNewRelicEntityTags(),
 			"newrelic_events_to_metrics_rule":                   resourceNewRelicEventsToMetricsRule(),
 			"newrelic_group":                                    resourceNewRelicGroup(),
+			"newrelic_group_access_grant":                       resourceNewRelicGroupAccessGrant(),
 			"newrelic_infra_alert_condition":                    resourceNewRelicInfraAlertCondition(),
+			"newrelic_ingest_logs":                              resourceNewRelicIngestLogs(),
+			"newrelic_ingest_metrics":                           resourceNewRelicIngestMetrics(),
 			"newrelic_insights_event":                           resourceNewRelicInsightsEvent(),
 			"newrelic_key_transaction":                          resourceNewRelicKeyTransaction(),
 			"newrelic_log_parsing_rule":                         resourceNewRelicLogParsingRule(),
+			"newrelic_lookup_table":                             resourceNewRelicLookupTable(),
 			"newrelic_monitor_downtime":                         resourceNewRelicMonitorDowntime(),
 			"newrelic_notification_channel":                     resourceNewRelicNotificationChannel(),
 			"newrelic_notification_destination":                 resourceNewRelicNotificationDestination(),
 			"newrelic_nrql_alert_condition":                     resourceNewRelicNrqlAlertCondition(),
+			"newrelic_nrql_alert_condition_set":                 resourceNewRelicNrqlAlertConditionSet(),
 			"newrelic_cardinality_management":                   resourceNewRelicCardinalityManagement(),
+			"newrelic_cardinality_limits":                       resourceNewRelicCardinalityLimits(),
 			"newrelic_metric_pruning_rule":                      resourceNewRelicMetricPruningRule(),
 			"newrelic_nrql_drop_rule":                           resourceNewRelicNRQLDropRule(),
 			"newrelic_pipeline_cloud_rule":                      resourceNewRelicPipelineCloudRule(),
@@ -195,6 +219,7 @@ func Provider() *schema.Provider {
 			"newrelic_synthetics_step_monitor":                  resourceNewRelicSyntheticsStepMonitor(),
 			"newrelic_workflow":                                 resourceNewRelicWorkflow(),
 			"newrelic_workload":                                 resourceNewRelicWorkload(),
+			"newrelic_role":                                     resourceNewRelicRole(),
 			"newrelic_user":                                     resourceNewRelicUser(),
 			"newrelic_fleet":                                    resourceNewRelicFleet(),
 			"newrelic_fleet_configuration":                      resourceNewRelicFleetConfiguration(),
@@ -281,12 +306,21 @@ func providerConfigure(data *schema.ResourceData, terraformVersion string) (inte
 		return nil, fmt.Errorf("error initializing New Relic Insights insert client: %w", err)
 	}
 
+	// Used for the APIs not covered by newrelic-client-go
+	transport, err := cfg.HTTPTransport()
+	if err != nil {
+		return nil, err
+	}
+
 	providerConfig := ProviderConfig{
 		NewClient:            client,
 		InsightsInsertClient: clientInsightsInsert,
 		PersonalAPIKey:       personalAPIKey,
 		AccountID:            accountID,
+		Region:               cfg.Region,
 		userAgent:            cfg.userAgent,
+		httpClient:           &http.Client{Transport: transport, Timeout: 60 * time.Second},
+		insightsInsertKey:    insightsInsertConfig.InsightsInsertKey,
 	}
 
 	return &providerConfig, nil
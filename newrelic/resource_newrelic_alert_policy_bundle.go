@@ -0,0 +1,368 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+	nrErrors "github.com/newrelic/newrelic-client-go/v2/pkg/errors"
+)
+
+func resourceNewRelicAlertPolicyBundle() *schema.Resource {
+	conditionSchema := embeddedNrqlAlertConditionSchema()
+	conditionSchema["key"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "A unique key for the condition within the bundle, used to track it across changes.",
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+	conditionSchema["type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "static",
+		Description:  "The type of the NRQL alert condition. Valid values are: 'static', 'baseline', 'outlier'. Changing the type replaces the condition.",
+		ValidateFunc: validation.StringInSlice([]string{"static", "baseline", "outlier"}, false),
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceNewRelicAlertPolicyBundleCreate,
+		ReadContext:   resourceNewRelicAlertPolicyBundleRead,
+		UpdateContext: resourceNewRelicAlertPolicyBundleUpdate,
+		DeleteContext: resourceNewRelicAlertPolicyBundleDelete,
+		CustomizeDiff: resourceNewRelicAlertPolicyBundleCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the policy.",
+			},
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on.",
+			},
+			"incident_preference": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(alerts.AlertsIncidentPreferenceTypes.PER_POLICY),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(alerts.AlertsIncidentPreferenceTypes.PER_POLICY),
+					string(alerts.AlertsIncidentPreferenceTypes.PER_CONDITION),
+					string(alerts.AlertsIncidentPreferenceTypes.PER_CONDITION_AND_TARGET),
+				},
+					false,
+				),
+				Description: "The rollup strategy for the policy. Options include: PER_POLICY, PER_CONDITION, or PER_CONDITION_AND_TARGET. The default is PER_POLICY.",
+			},
+			"condition": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The NRQL alert conditions of the policy.",
+				Elem: &schema.Resource{
+					Schema: conditionSchema,
+				},
+			},
+			"entity_guid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The entity GUID of the alert policy.",
+			},
+			"condition_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The IDs of the NRQL alert conditions, keyed by condition key.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"condition_entity_guids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The entity GUIDs of the NRQL alert conditions, keyed by condition key.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"changeset": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The changes made to the conditions by the last apply, in the order they were applied.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Computes the changeset of the conditions at plan time, so the plan shows which
+// conditions are created, updated, replaced or deleted.
+func resourceNewRelicAlertPolicyBundleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfiguration := d.GetRawConfig()
+	if rawConfiguration.IsNull() {
+		return nil
+	}
+
+	if !rawConfiguration.GetAttr("condition").IsWhollyKnown() {
+		for _, k := range []string{"changeset", "condition_ids", "condition_entity_guids"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, c := range d.Get("condition").([]interface{}) {
+		m := c.(map[string]interface{})
+		if m["type"].(string) != "baseline" && m["signal_seasonality"].(string) != "" {
+			return fmt.Errorf("condition %q: 'signal_seasonality' is only valid on baseline conditions. Please remove this field or change the condition type", m["key"])
+		}
+	}
+
+	conditions, err := expandAlertPolicyBundleConditions(d.Get("condition").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	var oldConditions map[string]embeddedNrqlAlertCondition
+	var conditionIDs map[string]string
+
+	if d.Id() != "" {
+		o, _ := d.GetChange("condition")
+		// When the previous configuration can't be expanded, every condition is updated.
+		oldConditions, _ = expandAlertPolicyBundleConditions(o.([]interface{}))
+
+		ids, _ := d.GetChange("condition_ids")
+		conditionIDs = expandEmbeddedNrqlAlertConditionIDs(ids)
+	}
+
+	changeset := computeAlertPolicyBundleChangeset(oldConditions, conditions, conditionIDs)
+	if len(changeset) == 0 {
+		return nil
+	}
+
+	if err := d.SetNew("changeset", flattenAlertPolicyBundleChangeset(changeset)); err != nil {
+		return err
+	}
+
+	if err := d.SetNewComputed("condition_ids"); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("condition_entity_guids")
+}
+
+func alertPolicyBundleOperationsFor(client *newrelic.NewRelic, accountID int, policyID string) alertPolicyBundleOperations {
+	return alertPolicyBundleOperations{
+		get: func(ctx context.Context, conditionID string) (*alerts.NrqlAlertCondition, error) {
+			return client.Alerts.GetNrqlConditionQueryWithContext(ctx, accountID, conditionID)
+		},
+		create: func(ctx context.Context, conditionType string, input alerts.NrqlConditionCreateInput) (*alerts.NrqlAlertCondition, error) {
+			return createNrqlAlertConditionOfType(ctx, client, accountID, policyID, conditionType, input)
+		},
+		update: func(ctx context.Context, conditionID string, conditionType string, input alerts.NrqlConditionUpdateInput) (*alerts.NrqlAlertCondition, error) {
+			return updateNrqlAlertConditionOfType(ctx, client, accountID, conditionID, conditionType, input)
+		},
+		delete: func(ctx context.Context, conditionID string) error {
+			_, err := client.Alerts.DeleteNrqlConditionMutationWithContext(ctx, accountID, conditionID)
+			return err
+		},
+	}
+}
+
+func resourceNewRelicAlertPolicyBundleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	conditions, err := expandAlertPolicyBundleConditions(d.Get("condition").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policy := alerts.AlertsPolicyInput{
+		Name:               d.Get("name").(string),
+		IncidentPreference: alerts.AlertsIncidentPreference(d.Get("incident_preference").(string)),
+	}
+
+	log.Printf("[INFO] Creating New Relic alert policy bundle %s", policy.Name)
+
+	createResult, err := client.Alerts.CreatePolicyMutationWithContext(ctx, accountID, policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	changeset := computeAlertPolicyBundleChangeset(nil, conditions, nil)
+	journal := newAlertPolicyBundleJournal(alertPolicyBundleOperationsFor(client, accountID, createResult.ID), nil, nil)
+
+	if err := journal.apply(ctx, changeset, conditions); err != nil {
+		// A half-created bundle is removed altogether; deleting the policy also
+		// deletes any condition the rollback could not delete.
+		diags := diag.FromErr(err)
+		if rollbackErr := journal.rollback(ctx); rollbackErr != nil {
+			log.Printf("[WARN] %s", rollbackErr)
+		}
+
+		if _, deleteErr := client.Alerts.DeletePolicyMutationWithContext(ctx, accountID, createResult.ID); deleteErr != nil {
+			d.SetId(createResult.ID)
+			_ = d.Set("account_id", accountID)
+			_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(journal.ConditionIDs))
+			return append(diags, diag.Errorf("error deleting alert policy %s after a failed create: %s", createResult.ID, deleteErr)...)
+		}
+
+		return diags
+	}
+
+	d.SetId(createResult.ID)
+	if err := flattenAlertPolicy(createResult, d, accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(journal.ConditionIDs))
+	_ = d.Set("condition_entity_guids", flattenEmbeddedNrqlAlertConditionIDs(journal.EntityGUIDs))
+	_ = d.Set("changeset", flattenAlertPolicyBundleChangeset(changeset))
+
+	return nil
+}
+
+func resourceNewRelicAlertPolicyBundleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic alert policy bundle %s from account %d", d.Id(), accountID)
+
+	policy, err := client.Alerts.QueryPolicyWithContext(ctx, accountID, d.Id())
+	if err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := flattenAlertPolicy(policy, d, accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Conditions deleted outside of Terraform are dropped from state, and
+	// recreated by the next apply.
+	conditionIDs := expandEmbeddedNrqlAlertConditionIDs(d.Get("condition_ids"))
+	entityGUIDs := map[string]string{}
+
+	for _, key := range alertPolicyBundleConditionKeys(conditionIDs) {
+		condition, err := client.Alerts.GetNrqlConditionQueryWithContext(ctx, accountID, conditionIDs[key])
+		if err != nil {
+			if _, ok := err.(*nrErrors.NotFound); ok {
+				delete(conditionIDs, key)
+				continue
+			}
+			return diag.FromErr(err)
+		}
+
+		entityGUIDs[key] = string(condition.EntityGUID)
+	}
+
+	_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(conditionIDs))
+	_ = d.Set("condition_entity_guids", flattenEmbeddedNrqlAlertConditionIDs(entityGUIDs))
+
+	return nil
+}
+
+func resourceNewRelicAlertPolicyBundleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	conditions, err := expandAlertPolicyBundleConditions(d.Get("condition").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldCondition, _ := d.GetChange("condition")
+	oldConditions, _ := expandAlertPolicyBundleConditions(oldCondition.([]interface{}))
+
+	conditionIDs := expandEmbeddedNrqlAlertConditionIDs(d.Get("condition_ids"))
+	changeset := computeAlertPolicyBundleChangeset(oldConditions, conditions, conditionIDs)
+
+	log.Printf("[INFO] Updating New Relic alert policy bundle %s: %d condition changes", d.Id(), len(changeset))
+
+	oldName, _ := d.GetChange("name")
+	oldIncidentPreference, _ := d.GetChange("incident_preference")
+	policyChanged := d.HasChanges("name", "incident_preference")
+
+	if policyChanged {
+		_, err := client.Alerts.UpdatePolicyMutationWithContext(ctx, accountID, d.Id(), alerts.AlertsPolicyUpdateInput{
+			Name:               d.Get("name").(string),
+			IncidentPreference: alerts.AlertsIncidentPreference(d.Get("incident_preference").(string)),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	journal := newAlertPolicyBundleJournal(alertPolicyBundleOperationsFor(client, accountID, d.Id()), conditionIDs, expandEmbeddedNrqlAlertConditionIDs(d.Get("condition_entity_guids")))
+
+	if err := journal.apply(ctx, changeset, conditions); err != nil {
+		diags := diag.FromErr(err)
+
+		if rollbackErr := journal.rollback(ctx); rollbackErr != nil {
+			diags = append(diags, diag.FromErr(rollbackErr)...)
+		}
+
+		if policyChanged {
+			_, revertErr := client.Alerts.UpdatePolicyMutationWithContext(ctx, accountID, d.Id(), alerts.AlertsPolicyUpdateInput{
+				Name:               oldName.(string),
+				IncidentPreference: alerts.AlertsIncidentPreference(oldIncidentPreference.(string)),
+			})
+			if revertErr != nil {
+				diags = append(diags, diag.Errorf("could not revert alert policy %s: %s", d.Id(), revertErr)...)
+			}
+		}
+
+		// Keep the previous configuration in state, along with the conditions
+		// as they are after the rollback.
+		d.Partial(true)
+		_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(journal.ConditionIDs))
+		_ = d.Set("condition_entity_guids", flattenEmbeddedNrqlAlertConditionIDs(journal.EntityGUIDs))
+
+		return diags
+	}
+
+	_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(journal.ConditionIDs))
+	_ = d.Set("condition_entity_guids", flattenEmbeddedNrqlAlertConditionIDs(journal.EntityGUIDs))
+	if len(changeset) > 0 {
+		_ = d.Set("changeset", flattenAlertPolicyBundleChangeset(changeset))
+	}
+
+	return resourceNewRelicAlertPolicyBundleRead(ctx, d, meta)
+}
+
+func resourceNewRelicAlertPolicyBundleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Deleting New Relic alert policy bundle %s from account %d", d.Id(), accountID)
+
+	// Deleting a policy deletes its conditions.
+	_, err := client.Alerts.DeletePolicyMutationWithContext(ctx, accountID, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+//go:build integration || ALERTS
+
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNewRelicAlertPolicyBundle_Basic(t *testing.T) {
+	resourceName := "newrelic_alert_policy_bundle.foo"
+	rName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEnvVars(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertPolicyBundleDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertPolicyBundleConfig(rName, "static", "5"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertPolicyBundleExists(resourceName, 2),
+					resource.TestCheckResourceAttrSet(resourceName, "entity_guid"),
+				),
+			},
+			// Test: Update one condition and replace the other
+			{
+				Config: testAccNewRelicAlertPolicyBundleConfig(rName, "baseline", "10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertPolicyBundleExists(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "changeset.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "changeset.0.key", "throughput"),
+					resource.TestCheckResourceAttr(resourceName, "changeset.0.action", "replace"),
+					resource.TestCheckResourceAttr(resourceName, "changeset.1.key", "errors"),
+					resource.TestCheckResourceAttr(resourceName, "changeset.1.action", "update"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicAlertPolicyBundleDestroy(s *terraform.State) error {
+	providerConfig := testAccProvider.Meta().(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_alert_policy_bundle" {
+			continue
+		}
+
+		accountID, err := strconv.Atoi(r.Primary.Attributes["account_id"])
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.Alerts.QueryPolicy(accountID, r.Primary.ID); err == nil {
+			return fmt.Errorf("policy %s still exists", r.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckNewRelicAlertPolicyBundleExists(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		providerConfig := testAccProvider.Meta().(*ProviderConfig)
+		client := providerConfig.NewClient
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no policy ID is set")
+		}
+
+		if rs.Primary.Attributes["condition_ids.%"] != strconv.Itoa(count) {
+			return fmt.Errorf("expected %d conditions, found %s", count, rs.Primary.Attributes["condition_ids.%"])
+		}
+
+		accountID, err := strconv.Atoi(rs.Primary.Attributes["account_id"])
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.Alerts.QueryPolicy(accountID, rs.Primary.ID); err != nil {
+			return err
+		}
+
+		for k, conditionID := range rs.Primary.Attributes {
+			if !strings.HasPrefix(k, "condition_ids.") || k == "condition_ids.%" {
+				continue
+			}
+
+			condition, err := client.Alerts.GetNrqlConditionQuery(accountID, conditionID)
+			if err != nil {
+				return err
+			}
+
+			if condition.PolicyID != rs.Primary.ID {
+				return fmt.Errorf("condition %s belongs to policy %s, expected %s", conditionID, condition.PolicyID, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicAlertPolicyBundleConfig(name string, throughputType string, threshold string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy_bundle" "foo" {
+  name                = "tf-test-%[1]s"
+  incident_preference = "PER_CONDITION"
+
+  condition {
+    key  = "errors"
+    name = "tf-test-%[1]s errors"
+
+    nrql {
+      query = "SELECT count(*) FROM TransactionError"
+    }
+
+    critical {
+      operator              = "above"
+      threshold             = %[3]s
+      threshold_duration    = 120
+      threshold_occurrences = "ALL"
+    }
+  }
+
+  condition {
+    key                = "throughput"
+    type               = "%[2]s"
+    name               = "tf-test-%[1]s throughput"
+    baseline_direction = "lower_only"
+
+    nrql {
+      query = "SELECT rate(count(*), 1 minute) FROM Transaction"
+    }
+
+    critical {
+      operator              = "below"
+      threshold             = 3
+      threshold_duration    = 300
+      threshold_occurrences = "ALL"
+    }
+  }
+}
+`, name, throughputType, threshold)
+}
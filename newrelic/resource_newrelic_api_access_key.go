@@ -3,10 +3,13 @@ package newrelic
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
 	"github.com/newrelic/newrelic-client-go/v2/pkg/apiaccess"
 )
 
@@ -20,13 +23,38 @@ func resourceNewRelicAPIAccessKey() *schema.Resource {
 			StateContext: resourceNewrelicAPIAccessKeyImport,
 		},
 		Schema:        resourceNewRelicAPIAccessKeySchema(),
-		CustomizeDiff: validateAPIAccessKeyAttributes,
+		CustomizeDiff: customizeAPIAccessKeyDiff,
 	}
 }
 
 func resourceNewRelicAPIAccessKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderConfig).NewClient
 
+	key, err := createAPIAccessKey(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Set the resource ID to be a composite of the key ID and the key type in order to lookup the newly created key
+	d.SetId(key.ID)
+
+	// Expose the API key only at creation time; subsequent reads will not refresh/overwrite it.
+	if err := d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.Key, key.Key); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// In rotation mode, the rotation clock starts with the creation of the key.
+	if _, ok := d.GetOk(ResourceNewRelicAPIAccessKeyAttributeLabels.RotationPeriod); ok {
+		_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKey, key.Key)
+		_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKeyID, key.ID)
+		_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.RotatedAt, time.Now().UTC().Format(time.RFC3339))
+	}
+
+	return resourceNewRelicAPIAccessKeyRead(ctx, d, meta)
+}
+
+// Creates a key with the type, name and notes of the resource.
+func createAPIAccessKey(ctx context.Context, client *newrelic.NewRelic, d *schema.ResourceData) (*apiaccess.APIKey, error) {
 	accountID := getAPIAccessKeyAccountID(d)
 	keyType := getAPIAccessKeyType(d)
 
@@ -52,30 +80,20 @@ func resourceNewRelicAPIAccessKeyCreate(ctx context.Context, d *schema.ResourceD
 
 		opts.User = []apiaccess.APIAccessCreateUserKeyInput{userKeyOpts}
 	default:
-		err := fmt.Errorf("unknown api access key type: %s", keyType)
-		return diag.FromErr(err)
+		return nil, fmt.Errorf("unknown api access key type: %s", keyType)
 	}
 
 	keys, createErr := client.APIAccess.CreateAPIAccessKeysWithContext(ctx, opts)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return nil, createErr
 	}
 
 	// Validate to make sure we only created one key.
 	if len(keys) != 1 {
-		err := fmt.Errorf("expected 1 new key, got %d", len(keys))
-		return diag.FromErr(err)
-	}
-
-	// Set the resource ID to be a composite of the key ID and the key type in order to lookup the newly created key
-	d.SetId(keys[0].ID)
-
-	// Expose the API key only at creation time; subsequent reads will not refresh/overwrite it.
-	if err := d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.Key, keys[0].Key); err != nil {
-		return diag.FromErr(err)
+		return nil, fmt.Errorf("expected 1 new key, got %d", len(keys))
 	}
 
-	return resourceNewRelicAPIAccessKeyRead(ctx, d, meta)
+	return &keys[0], nil
 }
 
 func resourceNewRelicAPIAccessKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -93,6 +111,18 @@ func resourceNewRelicAPIAccessKeyRead(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(fmt.Errorf("no New Relic API Access Key found with given id %s", d.Id()))
 	}
 
+	if previousKeyID := d.Get(ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID).(string); previousKeyID != "" {
+		_, previousErr := client.APIAccess.GetAPIAccessKeyWithContext(ctx, previousKeyID, apiaccess.APIAccessKeyType(getAPIAccessKeyType(d)))
+		if previousErr != nil {
+			if !strings.Contains(previousErr.Error(), "Key not found") {
+				return diag.FromErr(previousErr)
+			}
+			log.Printf("[WARN] previous api access key %s not found, removing from state", previousKeyID)
+			_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKey, "")
+			_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID, "")
+		}
+	}
+
 	var setErr error
 	setErr = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.AccountID, key.AccountID)
 	if setErr != nil {
@@ -162,6 +192,30 @@ func resourceNewRelicAPIAccessKeyRead(ctx context.Context, d *schema.ResourceDat
 func resourceNewRelicAPIAccessKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderConfig).NewClient
 
+	// The start of the rotation clock, and a rotation are planned by leaving
+	// rotated_at unknown, see customizeAPIAccessKeyRotationDiff.
+	if !d.GetRawPlan().GetAttr(ResourceNewRelicAPIAccessKeyAttributeLabels.RotatedAt).IsKnown() {
+		if rotatedAt, _ := d.GetChange(ResourceNewRelicAPIAccessKeyAttributeLabels.RotatedAt); rotatedAt.(string) == "" {
+			_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKey, d.Get(ResourceNewRelicAPIAccessKeyAttributeLabels.Key))
+			_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKeyID, d.Id())
+			_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.RotatedAt, time.Now().UTC().Format(time.RFC3339))
+		} else if err := rotateAPIAccessKey(ctx, client, d); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if d.HasChange(ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID) {
+		oldPreviousKeyID, newPreviousKeyID := d.GetChange(ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID)
+		if oldPreviousKeyID.(string) != "" && newPreviousKeyID.(string) == "" {
+			log.Printf("[INFO] Revoking previous api access key %s", oldPreviousKeyID)
+			if err := deleteAPIAccessKey(ctx, client, getAPIAccessKeyType(d), oldPreviousKeyID.(string)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if !d.HasChanges(ResourceNewRelicAPIAccessKeyAttributeLabels.Name, ResourceNewRelicAPIAccessKeyAttributeLabels.Notes) {
+		return resourceNewRelicAPIAccessKeyRead(ctx, d, meta)
+	}
+
 	opts := apiaccess.APIAccessUpdateInput{}
 
 	keyType := getAPIAccessKeyType(d)
@@ -202,26 +256,73 @@ func resourceNewRelicAPIAccessKeyUpdate(ctx context.Context, d *schema.ResourceD
 func resourceNewRelicAPIAccessKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderConfig).NewClient
 
-	opts := apiaccess.APIAccessDeleteInput{}
+	keyIDs := []string{d.Id()}
 
-	keyType := getAPIAccessKeyType(d)
+	// In rotation mode, the previous key is revoked along with the current one.
+	if previousKeyID := d.Get(ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID).(string); previousKeyID != "" {
+		keyIDs = append(keyIDs, previousKeyID)
+	}
+
+	if err := deleteAPIAccessKey(ctx, client, getAPIAccessKeyType(d), keyIDs...); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func deleteAPIAccessKey(ctx context.Context, client *newrelic.NewRelic, keyType string, keyIDs ...string) error {
+	opts := apiaccess.APIAccessDeleteInput{}
 
 	// Construct the key type specific delete opts.
 	switch keyType {
 	case keyTypeIngest:
-		opts.IngestKeyIDs = []string{d.Id()}
+		opts.IngestKeyIDs = keyIDs
 	case keyTypeUser:
-		opts.UserKeyIDs = []string{d.Id()}
+		opts.UserKeyIDs = keyIDs
 	default:
-		return diag.Errorf("unknown api access key type: %s", keyType)
+		return fmt.Errorf("unknown api access key type: %s", keyType)
 	}
 
 	_, deleteErr := client.APIAccess.DeleteAPIAccessKeyWithContext(ctx, opts)
-	if deleteErr != nil {
-		return diag.FromErr(deleteErr)
+
+	return deleteErr
+}
+
+// Creates the next key before revoking the one preceding the current key, so
+// both the current and the new key remain valid during the overlap period.
+func rotateAPIAccessKey(ctx context.Context, client *newrelic.NewRelic, d *schema.ResourceData) error {
+	currentKeyID, _ := d.GetChange(ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKeyID)
+	currentKey, _ := d.GetChange(ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKey)
+	previousKeyID, _ := d.GetChange(ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID)
+
+	if currentKeyID.(string) == "" {
+		currentKeyID = d.Id()
+		currentKey, _ = d.GetChange(ResourceNewRelicAPIAccessKeyAttributeLabels.Key)
 	}
 
-	d.SetId("")
+	log.Printf("[INFO] Rotating api access key %s", currentKeyID)
+
+	key, err := createAPIAccessKey(ctx, client, d)
+	if err != nil {
+		return fmt.Errorf("error creating the next api access key: %w", err)
+	}
+
+	d.SetId(key.ID)
+	_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.Key, key.Key)
+	_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKey, key.Key)
+	_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKeyID, key.ID)
+	_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKey, currentKey)
+	_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID, currentKeyID)
+	_ = d.Set(ResourceNewRelicAPIAccessKeyAttributeLabels.RotatedAt, time.Now().UTC().Format(time.RFC3339))
+
+	if previousKeyID.(string) != "" {
+		log.Printf("[INFO] Revoking previous api access key %s", previousKeyID)
+		if err := deleteAPIAccessKey(ctx, client, getAPIAccessKeyType(d), previousKeyID.(string)); err != nil {
+			return fmt.Errorf("rotated api access key %s, but failed to revoke the key %s preceding it: %w", currentKeyID, previousKeyID, err)
+		}
+	}
 
 	return nil
 }
@@ -5,6 +5,7 @@ package newrelic
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -133,6 +134,48 @@ func TestAccNewRelicAPIAccessKey_BasicIngestBrowserNoNotesNames(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicAPIAccessKey_Rotation(t *testing.T) {
+	resourceName := "newrelic_api_access_key.foobar"
+	keyName := fmt.Sprintf("tftest-keyname-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicAPIAccessKeyIngestRotation(testSubAccountID, keyName, "2m", "1m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "current_key_id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "current_key", resourceName, "key"),
+					resource.TestCheckResourceAttr(resourceName, "previous_key_id", ""),
+					resource.TestCheckResourceAttrSet(resourceName, "rotated_at"),
+				),
+			},
+			{
+				// Rotate on a regular apply once the rotation period elapsed
+				PreConfig: func() { time.Sleep(2 * time.Minute) },
+				Config:    testAccCheckNewRelicAPIAccessKeyIngestRotation(testSubAccountID, keyName, "2m", "1m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "current_key_id", resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "previous_key_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "previous_key"),
+				),
+			},
+			{
+				// Revoke the previous key once the overlap period elapsed
+				PreConfig: func() { time.Sleep(1 * time.Minute) },
+				Config:    testAccCheckNewRelicAPIAccessKeyIngestRotation(testSubAccountID, keyName, "2m", "1m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "previous_key_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "previous_key", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccNewRelicAPIAccessKey_ImportBasic(t *testing.T) {
 	keyName := fmt.Sprintf("tftest-keyname-%s", acctest.RandString(10))
 	keyNotes := fmt.Sprintf("tftest-keynotes-%s", acctest.RandString(10))
@@ -204,3 +247,16 @@ resource "newrelic_api_access_key" "foobar" {
 }
 `, accountID, userID, name, notes)
 }
+
+func testAccCheckNewRelicAPIAccessKeyIngestRotation(accountID int, name, rotationPeriod, overlapPeriod string) string {
+	return fmt.Sprintf(`
+resource "newrelic_api_access_key" "foobar" {
+	account_id      = %d
+	key_type        = "INGEST"
+	ingest_type     = "LICENSE"
+	name            = "%s"
+	rotation_period = "%s"
+	overlap_period  = "%s"
+}
+`, accountID, name, rotationPeriod, overlapPeriod)
+}
@@ -0,0 +1,315 @@
+package newrelic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/usermanagement"
+)
+
+func resourceNewRelicAuthenticationDomain() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicAuthenticationDomainCreate,
+		ReadContext:   resourceNewRelicAuthenticationDomainRead,
+		UpdateContext: resourceNewRelicAuthenticationDomainUpdate,
+		DeleteContext: resourceNewRelicAuthenticationDomainDelete,
+		CustomizeDiff: resourceNewRelicAuthenticationDomainCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the authentication domain.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"authentication_type": {
+				Type:        schema.TypeString,
+				Description: "The method of authenticating users. Valid values are PASSWORD and SAML_SSO.",
+				Optional:    true,
+				Default:     string(usermanagement.OrganizationAuthenticationTypeEnumTypes.PASSWORD),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(usermanagement.OrganizationAuthenticationTypeEnumTypes.PASSWORD),
+					string(usermanagement.OrganizationAuthenticationTypeEnumTypes.SAML_SSO),
+				}, false),
+			},
+			"provisioning_type": {
+				Type:        schema.TypeString,
+				Description: "The method of provisioning users. Valid values are MANUAL and SCIM.",
+				Optional:    true,
+				Default:     string(usermanagement.OrganizationProvisioningTypeEnumTypes.MANUAL),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(usermanagement.OrganizationProvisioningTypeEnumTypes.MANUAL),
+					string(usermanagement.OrganizationProvisioningTypeEnumTypes.SCIM),
+				}, false),
+			},
+			"user_upgrade_request_policy": {
+				Type:        schema.TypeString,
+				Description: "How requests of users to upgrade their user type are handled. Valid values are MANUAL_REVIEW and AUTOMATIC_APPROVAL.",
+				Optional:    true,
+				Default:     authenticationDomainUserUpgradeRequestPolicies.ManualReview,
+				ValidateFunc: validation.StringInSlice([]string{
+					authenticationDomainUserUpgradeRequestPolicies.ManualReview,
+					authenticationDomainUserUpgradeRequestPolicies.AutomaticApproval,
+				}, false),
+			},
+			"session_idle_timeout_minutes": {
+				Type:         schema.TypeInt,
+				Description:  "The number of minutes of inactivity after which users are logged out. Sessions do not time out on inactivity if omitted.",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(5, 10080),
+			},
+			"session_max_duration_hours": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum duration of a session in hours, after which users must log in again. Sessions have no maximum duration if omitted.",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 720),
+			},
+			"saml": {
+				Type:        schema.TypeList,
+				Description: "The SAML SSO configuration, required when authentication_type is SAML_SSO.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"metadata_xml": {
+							Type:          schema.TypeString,
+							Description:   "The SAML metadata XML of the identity provider, from which the other arguments are extracted.",
+							Optional:      true,
+							ValidateFunc:  validateSamlMetadataXML,
+							ConflictsWith: []string{"saml.0.sso_url", "saml.0.certificate"},
+						},
+						"entity_id": {
+							Type:        schema.TypeString,
+							Description: "The entity ID of the identity provider.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"sso_url": {
+							Type:        schema.TypeString,
+							Description: "The URL of the identity provider users are redirected to when logging in.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"logout_url": {
+							Type:        schema.TypeString,
+							Description: "The URL users are redirected to when logging out.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"certificate": {
+							Type:             schema.TypeString,
+							Description:      "The PEM encoded certificate the identity provider signs SAML assertions with.",
+							Optional:         true,
+							Computed:         true,
+							ValidateFunc:     validateSamlCertificatePEM,
+							DiffSuppressFunc: suppressEquivalentSamlCertificate,
+						},
+					},
+				},
+			},
+			"saml_certificate_expires_at": {
+				Type:        schema.TypeString,
+				Description: "The expiration date of the SAML signing certificate, in RFC3339 format.",
+				Computed:    true,
+			},
+			"scim_bearer_token": {
+				Type:        schema.TypeString,
+				Description: "The bearer token the identity provider authenticates SCIM requests with. Only available after the resource is created, or provisioning_type is changed to SCIM.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// Certificates may be configured in PEM or base64 encoded DER format, with any line wrapping.
+func suppressEquivalentSamlCertificate(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+
+	oldCert, err := parseSamlCertificate(old)
+	if err != nil {
+		return false
+	}
+
+	newCert, err := parseSamlCertificate(new)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(oldCert.Raw, newCert.Raw)
+}
+
+func resourceNewRelicAuthenticationDomainCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	authenticationType := d.Get("authentication_type").(string)
+	saml := d.Get("saml").([]interface{})
+
+	if authenticationType == string(usermanagement.OrganizationAuthenticationTypeEnumTypes.SAML_SSO) && len(saml) == 0 {
+		return fmt.Errorf("a saml block is required when authentication_type is %s", authenticationType)
+	}
+
+	if authenticationType != string(usermanagement.OrganizationAuthenticationTypeEnumTypes.SAML_SSO) && len(saml) > 0 {
+		return fmt.Errorf("the saml block can only be set when authentication_type is %s", usermanagement.OrganizationAuthenticationTypeEnumTypes.SAML_SSO)
+	}
+
+	if d.HasChange("provisioning_type") {
+		if err := d.SetNewComputed("scim_bearer_token"); err != nil {
+			return err
+		}
+	}
+
+	rawConfiguration := d.GetRawConfig()
+	if rawConfiguration.IsNull() || !rawConfiguration.GetAttr("saml").IsWhollyKnown() {
+		return d.SetNewComputed("saml_certificate_expires_at")
+	}
+
+	config, err := expandAuthenticationDomainSaml(saml)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := ""
+	if config != nil && config.Certificate != nil {
+		expiresAt = config.Certificate.NotAfter.UTC().Format(time.RFC3339)
+	}
+
+	if expiresAt != d.Get("saml_certificate_expires_at").(string) {
+		return d.SetNew("saml_certificate_expires_at", expiresAt)
+	}
+
+	return nil
+}
+
+func resourceNewRelicAuthenticationDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	domain, err := expandAuthenticationDomain(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating New Relic authentication domain %s", domain.Name)
+
+	created, scimBearerToken, err := createAuthenticationDomain(ctx, client, domain)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(created.ID)
+
+	diags := samlCertificateExpiryDiagnostics(d.Get("saml").([]interface{}), time.Now())
+
+	// The token is only returned when the domain is created, so it is kept in state from then on.
+	if domain.ProvisioningType == string(usermanagement.OrganizationProvisioningTypeEnumTypes.SCIM) {
+		_ = d.Set("scim_bearer_token", scimBearerToken)
+	}
+
+	return append(diags, resourceNewRelicAuthenticationDomainRead(ctx, d, meta)...)
+}
+
+func resourceNewRelicAuthenticationDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	log.Printf("[INFO] Reading New Relic authentication domain %s", d.Id())
+
+	domain, err := getAuthenticationDomain(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if domain == nil {
+		log.Printf("[WARN] Authentication domain %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("name", domain.Name)
+	_ = d.Set("authentication_type", domain.AuthenticationType)
+	_ = d.Set("provisioning_type", domain.ProvisioningType)
+	_ = d.Set("user_upgrade_request_policy", domain.UserUpgradeRequestPolicy)
+
+	if domain.Session.IdleTimeoutMinutes != nil {
+		_ = d.Set("session_idle_timeout_minutes", *domain.Session.IdleTimeoutMinutes)
+	} else {
+		_ = d.Set("session_idle_timeout_minutes", nil)
+	}
+
+	if domain.Session.MaxDurationHours != nil {
+		_ = d.Set("session_max_duration_hours", *domain.Session.MaxDurationHours)
+	} else {
+		_ = d.Set("session_max_duration_hours", nil)
+	}
+
+	metadataXML := d.Get("saml.0.metadata_xml").(string)
+	if err := d.Set("saml", flattenAuthenticationDomainSaml(domain.Saml, metadataXML)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	expiresAt := ""
+	if domain.Saml != nil && domain.Saml.Certificate != "" {
+		if cert, err := parseSamlCertificate(domain.Saml.Certificate); err == nil {
+			expiresAt = cert.NotAfter.UTC().Format(time.RFC3339)
+		}
+	}
+	_ = d.Set("saml_certificate_expires_at", expiresAt)
+
+	return nil
+}
+
+func resourceNewRelicAuthenticationDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	domain, err := expandAuthenticationDomain(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Updating New Relic authentication domain %s", d.Id())
+
+	if err := updateAuthenticationDomain(ctx, client, d.Id(), domain); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	if d.HasChange("saml") {
+		diags = samlCertificateExpiryDiagnostics(d.Get("saml").([]interface{}), time.Now())
+	}
+
+	if d.HasChange("provisioning_type") {
+		scimBearerToken := ""
+		if domain.ProvisioningType == string(usermanagement.OrganizationProvisioningTypeEnumTypes.SCIM) {
+			scimBearerToken, err = generateAuthenticationDomainScimBearerToken(ctx, client, d.Id())
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error generating SCIM bearer token for authentication domain %s: %w", d.Id(), err))
+			}
+		}
+		_ = d.Set("scim_bearer_token", scimBearerToken)
+	}
+
+	return append(diags, resourceNewRelicAuthenticationDomainRead(ctx, d, meta)...)
+}
+
+func resourceNewRelicAuthenticationDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	log.Printf("[INFO] Deleting New Relic authentication domain %s", d.Id())
+
+	if err := deleteAuthenticationDomain(ctx, client, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+//go:build integration || AUTH
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/testhelpers"
+)
+
+var authenticationDomainResourceName string = "newrelic_authentication_domain.foo"
+
+func TestAccNewRelicAuthenticationDomainResource_Basic(t *testing.T) {
+	name := fmt.Sprintf("terraform-provider-newrelic-integration-test-domain-%s", testhelpers.RandSeq(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEnvVars(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccNewRelicCheckAuthenticationDomainResourceDestroy,
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccNewRelicAuthenticationDomainResourceConfiguration(name, "MANUAL", 30),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNewRelicCheckAuthenticationDomainResourceExists(authenticationDomainResourceName),
+					resource.TestCheckResourceAttr(authenticationDomainResourceName, "session_idle_timeout_minutes", "30"),
+					resource.TestCheckResourceAttr(authenticationDomainResourceName, "scim_bearer_token", ""),
+				),
+			},
+			// Update: enable SCIM provisioning
+			{
+				Config: testAccNewRelicAuthenticationDomainResourceConfiguration(name+"-updated", "SCIM", 60),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNewRelicCheckAuthenticationDomainResourceExists(authenticationDomainResourceName),
+					resource.TestCheckResourceAttr(authenticationDomainResourceName, "provisioning_type", "SCIM"),
+					resource.TestCheckResourceAttrSet(authenticationDomainResourceName, "scim_bearer_token"),
+				),
+			},
+			// Import
+			{
+				ResourceName:            authenticationDomainResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"scim_bearer_token"},
+			},
+		},
+	})
+}
+
+func testAccNewRelicCheckAuthenticationDomainResourceExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no authentication domain ID found")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+		domain, err := getAuthenticationDomain(context.Background(), client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if domain == nil {
+			return fmt.Errorf("authentication domain %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicCheckAuthenticationDomainResourceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_authentication_domain" {
+			continue
+		}
+
+		domain, err := getAuthenticationDomain(context.Background(), client, r.Primary.ID)
+		if err == nil && domain != nil {
+			return fmt.Errorf("authentication domain %s still exists", r.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccNewRelicAuthenticationDomainResourceConfiguration(name string, provisioningType string, idleTimeout int) string {
+	return fmt.Sprintf(`
+resource "newrelic_authentication_domain" "foo" {
+  name                         = "%s"
+  provisioning_type            = "%s"
+  session_idle_timeout_minutes = %d
+}
+`, name, provisioningType, idleTimeout)
+}
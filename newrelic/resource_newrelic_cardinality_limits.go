@@ -0,0 +1,155 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNewRelicCardinalityLimits() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicCardinalityLimitsCreate,
+		ReadContext:   resourceNewRelicCardinalityLimitsRead,
+		UpdateContext: resourceNewRelicCardinalityLimitsUpdate,
+		DeleteContext: resourceNewRelicCardinalityLimitsDelete,
+		CustomizeDiff: resourceNewRelicCardinalityLimitsDiff,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the New Relic account the limits are set in.",
+			},
+			"limits": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The cardinality limits by metric name: the maximum number of unique dimension-value combinations allowed per day for each metric.",
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      25,
+				ValidateFunc: validation.IntBetween(1, 100),
+				Description:  "The number of limits set per request.",
+			},
+		},
+	}
+}
+
+func resourceNewRelicCardinalityLimitsDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for metric, limit := range d.Get("limits").(map[string]interface{}) {
+		if metric == "" {
+			return fmt.Errorf("limits must not contain an empty metric name")
+		}
+		if limit.(int) < 1 {
+			return fmt.Errorf("the cardinality limit of metric %q must be at least 1, got %d", metric, limit.(int))
+		}
+	}
+
+	return nil
+}
+
+func resourceNewRelicCardinalityLimitsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	accountID := selectAccountID(providerConfig, d)
+
+	d.SetId(strconv.Itoa(accountID))
+	_ = d.Set("account_id", accountID)
+
+	return resourceNewRelicCardinalityLimitsApply(ctx, d, providerConfig, accountID, map[string]interface{}{})
+}
+
+// Per-metric limits are write-only, so the state always reflects the last apply.
+func resourceNewRelicCardinalityLimitsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("account_id", accountID))
+}
+
+func resourceNewRelicCardinalityLimitsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	accountID := d.Get("account_id").(int)
+
+	oldLimits, _ := d.GetChange("limits")
+
+	return resourceNewRelicCardinalityLimitsApply(ctx, d, providerConfig, accountID, oldLimits.(map[string]interface{}))
+}
+
+// resourceNewRelicCardinalityLimitsApply sets the limits which changed since oldLimits. When a batch fails,
+// the state keeps the limits set by the previous batches, so that the next apply only retries the rest.
+func resourceNewRelicCardinalityLimitsApply(ctx context.Context, d *schema.ResourceData, providerConfig *ProviderConfig, accountID int, oldLimits map[string]interface{}) diag.Diagnostics {
+	changes := diffCardinalityLimits(oldLimits, d.Get("limits").(map[string]interface{}))
+
+	log.Printf("[INFO] Setting %d cardinality limit(s) in account %d", len(changes), accountID)
+
+	state := map[string]interface{}{}
+	for metric, limit := range oldLimits {
+		state[metric] = limit
+	}
+
+	err := applyCardinalityLimitChanges(ctx, providerConfig.NewClient, accountID, changes, d.Get("batch_size").(int), func(batch []cardinalityLimitChange) {
+		for _, c := range batch {
+			if c.removed {
+				delete(state, c.metric)
+			} else {
+				state[c.metric] = c.limit
+			}
+		}
+	})
+	if err != nil {
+		_ = d.Set("limits", state)
+		return diag.FromErr(err)
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Metric cardinality limit override(s) applied",
+			Detail: fmt.Sprintf(
+				"Cardinality limit overrides have been set for %d metric(s) in account %d.\n\n%s",
+				len(changes), accountID, cardinalityUILagNotice,
+			),
+		},
+	}
+}
+
+// The API has no delete operation, so the limits are reset to the platform default. When a batch fails,
+// Terraform keeps the whole prior state, and the next destroy resets every limit again.
+func resourceNewRelicCardinalityLimitsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	accountID := d.Get("account_id").(int)
+
+	changes := diffCardinalityLimits(d.Get("limits").(map[string]interface{}), map[string]interface{}{})
+
+	log.Printf("[INFO] Resetting %d cardinality limit(s) to platform default in account %d", len(changes), accountID)
+
+	err := applyCardinalityLimitChanges(ctx, providerConfig.NewClient, accountID, changes, d.Get("batch_size").(int), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Per-metric cardinality limit overrides removed",
+			Detail: fmt.Sprintf(
+				"Cardinality limit overrides for %d metric(s) in account %d have been reset to the platform default of %d.\n\n%s",
+				len(changes), accountID, cardinalityLimitPlatformDefault, cardinalityUILagNotice,
+			),
+		},
+	}
+}
@@ -0,0 +1,69 @@
+//go:build integration || INGEST
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicCardinalityLimits_Basic(t *testing.T) {
+	resourceName := "newrelic_cardinality_limits.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicCardinalityLimitsConfig(`
+    "test.cardinality.bulk.a.tf" = 150000
+    "test.cardinality.bulk.b.tf" = 160000
+    "test.cardinality.bulk.c.tf" = 170000`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "limits.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "limits.test.cardinality.bulk.b.tf", "160000"),
+				),
+			},
+			// One limit changed, one removed and one added.
+			{
+				Config: testAccNewRelicCardinalityLimitsConfig(`
+    "test.cardinality.bulk.a.tf" = 150000
+    "test.cardinality.bulk.b.tf" = 180000
+    "test.cardinality.bulk.d.tf" = 190000`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "limits.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "limits.test.cardinality.bulk.b.tf", "180000"),
+					resource.TestCheckNoResourceAttr(resourceName, "limits.test.cardinality.bulk.c.tf"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicCardinalityLimits_InvalidLimit(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNewRelicCardinalityLimitsConfig(`"test.cardinality.bulk.a.tf" = 0`),
+				ExpectError: regexp.MustCompile(`the cardinality limit of metric "test.cardinality.bulk.a.tf" must be at least 1`),
+			},
+		},
+	})
+}
+
+func testAccNewRelicCardinalityLimitsConfig(limits string) string {
+	return fmt.Sprintf(`
+resource "newrelic_cardinality_limits" "test" {
+  batch_size = 2
+
+  limits = {
+    %s
+  }
+}
+`, limits)
+}
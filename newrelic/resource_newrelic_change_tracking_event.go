@@ -0,0 +1,318 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/changetracking"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrtime"
+)
+
+const (
+	changeTrackingCategoryDeployment  = "DEPLOYMENT"
+	changeTrackingCategoryFeatureFlag = "FEATURE_FLAG"
+)
+
+// Entity GUIDs are base64 encoded, and are quoted in entity search queries.
+var changeTrackingEntityGUIDPattern = regexp.MustCompile(`^[A-Za-z0-9+/=_-]+$`)
+
+// The arguments of the category specific fields of change tracking events.
+var changeTrackingDeploymentArguments = []string{"version", "changelog", "commit", "deep_link"}
+
+func resourceNewRelicChangeTrackingEvent() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicChangeTrackingEventCreate,
+		ReadContext:   resourceNewRelicChangeTrackingEventRead,
+		DeleteContext: resourceNewRelicChangeTrackingEventDelete,
+		CustomizeDiff: resourceNewRelicChangeTrackingEventDiff,
+		// Change tracking events cannot be changed once recorded, so every argument records a new event when changed.
+		Schema: map[string]*schema.Schema{
+			"entity_guid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"entity_guid", "entity_search"},
+				ValidateFunc: validation.StringMatch(changeTrackingEntityGUIDPattern, "must be an entity GUID"),
+				Description:  "The GUID of the entity the change is recorded for.",
+			},
+			"entity_search": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"entity_guid", "entity_search"},
+				Description:  "An entity search query matching exactly the entity the change is recorded for, e.g. `name = 'checkout' AND domain = 'APM'`.",
+			},
+			"category": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     changeTrackingCategoryDeployment,
+				Description: "The category of the change, e.g. DEPLOYMENT, FEATURE_FLAG or OPERATIONAL.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "BASIC",
+				Description: "The type of the change within its category, e.g. BASIC, CANARY or ROLLING for deployments.",
+			},
+			"allow_custom_category_or_type": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Whether a category and type other than the ones supported by New Relic are allowed.",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The version of the deployed software. Required for the DEPLOYMENT category.",
+			},
+			"changelog": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "A URL to the changelog or, if not linkable, a list of changes of the deployment.",
+			},
+			"commit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The commit identifier of the deployment, e.g. a Git commit SHA.",
+			},
+			"deep_link": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "A link to the system that generated the deployment.",
+			},
+			"feature_flag_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The identifier of the feature flag. Required for the FEATURE_FLAG category.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "A description of the change.",
+			},
+			"short_description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "A concise description of the change, shown in change markers on charts.",
+			},
+			"user": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name or identifier of the person responsible for the change.",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "An identifier correlating changes across entities.",
+			},
+			"custom_attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Custom attributes of the change.",
+			},
+			"timestamp": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The start time of the change, in milliseconds since the Unix epoch. Defaults to the time the event is recorded.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values which record a new change tracking event when changed, e.g. the ID of a deployed resource.",
+			},
+			"change_tracking_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique identifier of the change tracking event.",
+			},
+			"recorded_entity_guid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The GUID of the entity the change was recorded for.",
+			},
+		},
+	}
+}
+
+// Values unknown until apply, e.g. the version of a deployed resource, are only checked once known.
+func resourceNewRelicChangeTrackingEventDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	category := d.Get("category").(string)
+
+	switch category {
+	case changeTrackingCategoryDeployment:
+		if d.NewValueKnown("version") && d.Get("version").(string) == "" {
+			return fmt.Errorf("version is required when category is %q", changeTrackingCategoryDeployment)
+		}
+	case changeTrackingCategoryFeatureFlag:
+		if d.NewValueKnown("feature_flag_id") && d.Get("feature_flag_id").(string) == "" {
+			return fmt.Errorf("feature_flag_id is required when category is %q", changeTrackingCategoryFeatureFlag)
+		}
+	}
+
+	if category != changeTrackingCategoryDeployment {
+		for _, a := range changeTrackingDeploymentArguments {
+			if d.Get(a).(string) != "" {
+				return fmt.Errorf("%s must not be set when category is not %q", a, changeTrackingCategoryDeployment)
+			}
+		}
+	}
+	if category != changeTrackingCategoryFeatureFlag && d.Get("feature_flag_id").(string) != "" {
+		return fmt.Errorf("feature_flag_id must not be set when category is not %q", changeTrackingCategoryFeatureFlag)
+	}
+
+	return nil
+}
+
+func resourceNewRelicChangeTrackingEventCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	input, rules := expandChangeTrackingEvent(d, time.Now())
+
+	log.Printf("[INFO] Creating New Relic change tracking event")
+
+	// The context aware variant does not format the milliseconds of the timestamp the way the API expects.
+	created, err := client.ChangeTracking.ChangeTrackingCreateEvent(input, rules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, m := range created.Messages {
+		log.Printf("[WARN] Change tracking event: %s", m)
+	}
+
+	if created.ChangeTrackingEvent == nil {
+		return diag.Errorf("no change tracking event was recorded")
+	}
+
+	return diag.FromErr(flattenChangeTrackingEvent(created.ChangeTrackingEvent, d))
+}
+
+// Change tracking events cannot be read back by ID, so the state is kept as recorded.
+func resourceNewRelicChangeTrackingEventRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// Change tracking events cannot be deleted, so the event is only removed from the state.
+func resourceNewRelicChangeTrackingEventDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Removing New Relic change tracking event %s from state, recorded events are kept", d.Id())
+
+	d.SetId("")
+
+	return nil
+}
+
+func expandChangeTrackingEvent(d *schema.ResourceData, now time.Time) (changetracking.ChangeTrackingCreateEventInput, changetracking.ChangeTrackingDataHandlingRules) {
+	category := d.Get("category").(string)
+
+	input := changetracking.ChangeTrackingCreateEventInput{
+		CategoryAndTypeData: &changetracking.ChangeTrackingCategoryRelatedInput{
+			Kind: &changetracking.ChangeTrackingCategoryAndTypeInput{
+				Category: category,
+				Type:     d.Get("type").(string),
+			},
+		},
+		Description:      d.Get("description").(string),
+		GroupId:          d.Get("group_id").(string),
+		ShortDescription: d.Get("short_description").(string),
+		User:             d.Get("user").(string),
+		Timestamp:        nrtime.EpochMilliseconds(now),
+	}
+
+	if v, ok := d.GetOk("entity_guid"); ok {
+		input.EntitySearch.Query = fmt.Sprintf("id = '%s'", v.(string))
+	} else {
+		input.EntitySearch.Query = d.Get("entity_search").(string)
+	}
+
+	switch category {
+	case changeTrackingCategoryDeployment:
+		input.CategoryAndTypeData.CategoryFields = &changetracking.ChangeTrackingCategoryFieldsInput{
+			Deployment: &changetracking.ChangeTrackingDeploymentFieldsInput{
+				Version:   d.Get("version").(string),
+				Changelog: d.Get("changelog").(string),
+				Commit:    d.Get("commit").(string),
+				DeepLink:  d.Get("deep_link").(string),
+			},
+		}
+	case changeTrackingCategoryFeatureFlag:
+		input.CategoryAndTypeData.CategoryFields = &changetracking.ChangeTrackingCategoryFieldsInput{
+			FeatureFlag: &changetracking.ChangeTrackingFeatureFlagFieldsInput{
+				FeatureFlagId: d.Get("feature_flag_id").(string),
+			},
+		}
+	}
+
+	if v, ok := d.GetOk("custom_attributes"); ok {
+		input.CustomAttributes = changetracking.ChangeTrackingRawCustomAttributesMap(v.(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("timestamp"); ok {
+		input.Timestamp = nrtime.EpochMilliseconds(time.UnixMilli(int64(v.(int))))
+	}
+
+	rules := changetracking.ChangeTrackingDataHandlingRules{
+		ValidationFlags: []changetracking.ChangeTrackingValidationFlag{
+			changetracking.ChangeTrackingValidationFlagTypes.FAIL_ON_FIELD_LENGTH,
+		},
+	}
+	if d.Get("allow_custom_category_or_type").(bool) {
+		rules.ValidationFlags = append(rules.ValidationFlags, changetracking.ChangeTrackingValidationFlagTypes.ALLOW_CUSTOM_CATEGORY_OR_TYPE)
+	}
+
+	return input, rules
+}
+
+// The events of each category are distinct types sharing the fields read here.
+func flattenChangeTrackingEvent(event changetracking.ChangeTrackingEventInterface, d *schema.ResourceData) error {
+	e := reflect.Indirect(reflect.ValueOf(event))
+
+	id := e.FieldByName("ChangeTrackingId").String()
+	if id == "" {
+		return fmt.Errorf("the change tracking event was recorded without an ID")
+	}
+	d.SetId(id)
+
+	if err := d.Set("change_tracking_id", id); err != nil {
+		return err
+	}
+
+	timestamp := time.Time(e.FieldByName("Timestamp").Interface().(nrtime.EpochMilliseconds))
+	if err := d.Set("timestamp", int(timestamp.UnixMilli())); err != nil {
+		return err
+	}
+
+	if entity, ok := e.FieldByName("Entity").Interface().(changetracking.EntityOutlineInterface); ok && entity != nil {
+		if guid := reflect.Indirect(reflect.ValueOf(entity)).FieldByName("GUID"); guid.IsValid() {
+			return d.Set("recorded_entity_guid", string(guid.Interface().(common.EntityGUID)))
+		}
+	}
+
+	return nil
+}
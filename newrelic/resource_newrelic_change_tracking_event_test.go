@@ -0,0 +1,96 @@
+//go:build integration || APM
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicChangeTrackingEvent_Basic(t *testing.T) {
+	resourceName := "newrelic_change_tracking_event.foo"
+	version := fmt.Sprintf("1.0.%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicChangeTrackingEventConfig(version),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "change_tracking_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "timestamp"),
+					resource.TestCheckResourceAttrPair(resourceName, "recorded_entity_guid", "data.newrelic_entity.app", "guid"),
+				),
+			},
+			// A changed version records a new event
+			{
+				Config: testAccNewRelicChangeTrackingEventConfig(version + "-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "version", version+"-1"),
+					resource.TestCheckResourceAttrSet(resourceName, "change_tracking_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicChangeTrackingEventsDataSource_Basic(t *testing.T) {
+	resourceName := "data.newrelic_change_tracking_events.events"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicChangeTrackingEventsDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "data.newrelic_entity.app", "guid"),
+					resource.TestCheckResourceAttrSet(resourceName, "events.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicChangeTrackingEventConfig(version string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "app" {
+	name   = "%s"
+	type   = "APPLICATION"
+	domain = "APM"
+}
+
+resource "newrelic_change_tracking_event" "foo" {
+	entity_guid = data.newrelic_entity.app.guid
+	version     = "%s"
+	user        = "terraform-acceptance-tests"
+	description = "Recorded by the Terraform provider acceptance tests"
+
+	custom_attributes = {
+		test = "true"
+	}
+}
+`, testAccExpectedApplicationName, version)
+}
+
+func testAccNewRelicChangeTrackingEventsDataSourceConfig() string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "app" {
+	name   = "%s"
+	type   = "APPLICATION"
+	domain = "APM"
+}
+
+data "newrelic_change_tracking_events" "events" {
+	account_id  = %d
+	entity_guid = data.newrelic_entity.app.guid
+	category    = "DEPLOYMENT"
+	since       = "1 week ago"
+	limit       = 10
+}
+`, testAccExpectedApplicationName, testAccountID)
+}
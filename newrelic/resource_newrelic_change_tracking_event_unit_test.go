@@ -0,0 +1,183 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/changetracking"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrdb"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrtime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandChangeTrackingEvent_Deployment(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := schema.TestResourceDataRaw(t, resourceNewRelicChangeTrackingEvent().Schema, map[string]interface{}{
+		"entity_guid":       "MXxBUE18QVBQTElDQVRJT058MQ",
+		"version":           "1.2.3",
+		"commit":            "abc123",
+		"user":              "ci",
+		"custom_attributes": map[string]interface{}{"team": "checkout"},
+	})
+
+	input, rules := expandChangeTrackingEvent(d, now)
+
+	require.Equal(t, "id = 'MXxBUE18QVBQTElDQVRJT058MQ'", input.EntitySearch.Query)
+	require.Equal(t, "DEPLOYMENT", input.CategoryAndTypeData.Kind.Category)
+	require.Equal(t, "BASIC", input.CategoryAndTypeData.Kind.Type)
+	require.Equal(t, &changetracking.ChangeTrackingDeploymentFieldsInput{Version: "1.2.3", Commit: "abc123"}, input.CategoryAndTypeData.CategoryFields.Deployment)
+	require.Nil(t, input.CategoryAndTypeData.CategoryFields.FeatureFlag)
+	require.Equal(t, "ci", input.User)
+	require.Equal(t, changetracking.ChangeTrackingRawCustomAttributesMap{"team": "checkout"}, input.CustomAttributes)
+	require.Equal(t, now, time.Time(input.Timestamp))
+	require.Equal(t, []changetracking.ChangeTrackingValidationFlag{
+		changetracking.ChangeTrackingValidationFlagTypes.FAIL_ON_FIELD_LENGTH,
+	}, rules.ValidationFlags)
+}
+
+func TestExpandChangeTrackingEvent_FeatureFlag(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceNewRelicChangeTrackingEvent().Schema, map[string]interface{}{
+		"entity_search":                 "name = 'checkout' AND domain = 'APM'",
+		"category":                      "FEATURE_FLAG",
+		"type":                          "TOGGLE",
+		"feature_flag_id":               "new-cart",
+		"timestamp":                     1767323045000,
+		"allow_custom_category_or_type": true,
+	})
+
+	input, rules := expandChangeTrackingEvent(d, time.Now())
+
+	require.Equal(t, "name = 'checkout' AND domain = 'APM'", input.EntitySearch.Query)
+	require.Equal(t, &changetracking.ChangeTrackingFeatureFlagFieldsInput{FeatureFlagId: "new-cart"}, input.CategoryAndTypeData.CategoryFields.FeatureFlag)
+	require.Nil(t, input.CategoryAndTypeData.CategoryFields.Deployment)
+	require.Nil(t, input.CustomAttributes)
+	require.Equal(t, int64(1767323045000), time.Time(input.Timestamp).UnixMilli())
+	require.Equal(t, []changetracking.ChangeTrackingValidationFlag{
+		changetracking.ChangeTrackingValidationFlagTypes.FAIL_ON_FIELD_LENGTH,
+		changetracking.ChangeTrackingValidationFlagTypes.ALLOW_CUSTOM_CATEGORY_OR_TYPE,
+	}, rules.ValidationFlags)
+}
+
+func TestResourceNewRelicChangeTrackingEventDiff(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		raw map[string]interface{}
+		err string
+	}{
+		"deployment": {
+			raw: map[string]interface{}{"entity_guid": "MQ", "version": "1.2.3"},
+		},
+		"deployment without version": {
+			raw: map[string]interface{}{"entity_guid": "MQ"},
+			err: `version is required when category is "DEPLOYMENT"`,
+		},
+		"deployment with feature flag": {
+			raw: map[string]interface{}{"entity_guid": "MQ", "version": "1.2.3", "feature_flag_id": "new-cart"},
+			err: `feature_flag_id must not be set when category is not "FEATURE_FLAG"`,
+		},
+		"feature flag": {
+			raw: map[string]interface{}{"entity_guid": "MQ", "category": "FEATURE_FLAG", "feature_flag_id": "new-cart"},
+		},
+		"feature flag without id": {
+			raw: map[string]interface{}{"entity_guid": "MQ", "category": "FEATURE_FLAG"},
+			err: `feature_flag_id is required when category is "FEATURE_FLAG"`,
+		},
+		"operational with commit": {
+			raw: map[string]interface{}{"entity_guid": "MQ", "category": "OPERATIONAL", "commit": "abc123"},
+			err: `commit must not be set when category is not "DEPLOYMENT"`,
+		},
+		"operational": {
+			raw: map[string]interface{}{"entity_guid": "MQ", "category": "OPERATIONAL", "type": "CRASH"},
+		},
+	}
+
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := resourceNewRelicChangeTrackingEvent().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(c.raw), nil)
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.err)
+			}
+		})
+	}
+}
+
+func TestFlattenChangeTrackingEvent(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceNewRelicChangeTrackingEvent().Schema, map[string]interface{}{})
+	event := &changetracking.ChangeTrackingDeploymentEvent{
+		ChangeTrackingId: "9c7a8c1e-0000-4000-8000-000000000000",
+		Timestamp:        nrtime.EpochMilliseconds(time.UnixMilli(1767323045000)),
+		Entity:           &changetracking.GenericEntityOutline{GUID: common.EntityGUID("MXxBUE18QVBQTElDQVRJT058MQ")},
+	}
+
+	require.NoError(t, flattenChangeTrackingEvent(event, d))
+	require.Equal(t, "9c7a8c1e-0000-4000-8000-000000000000", d.Id())
+	require.Equal(t, "9c7a8c1e-0000-4000-8000-000000000000", d.Get("change_tracking_id"))
+	require.Equal(t, 1767323045000, d.Get("timestamp"))
+	require.Equal(t, "MXxBUE18QVBQTElDQVRJT058MQ", d.Get("recorded_entity_guid"))
+
+	require.Error(t, flattenChangeTrackingEvent(&changetracking.ChangeTrackingDeploymentEvent{}, d))
+}
+
+func TestChangeTrackingEventsNRQL(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "SELECT changeTrackingId, category, type, version, changelog, commit, deepLink, featureFlagId, "+
+		"description, shortDescription, user, groupId, timestamp FROM ChangeTrackingEvent "+
+		"WHERE entity.guid = 'MQ' AND category = 'DEPLOYMENT' SINCE 3 days ago LIMIT 10",
+		changeTrackingEventsNRQL("MQ", "deployment", "3 days ago", 10))
+	require.Equal(t, "SELECT changeTrackingId, category, type, version, changelog, commit, deepLink, featureFlagId, "+
+		"description, shortDescription, user, groupId, timestamp FROM ChangeTrackingEvent "+
+		"WHERE entity.guid = 'MQ' SINCE 1 week ago LIMIT 100",
+		changeTrackingEventsNRQL("MQ", "", "1 week ago", 100))
+}
+
+func TestFlattenChangeTrackingEvents(t *testing.T) {
+	t.Parallel()
+
+	events := flattenChangeTrackingEvents([]nrdb.NRDBResult{
+		{
+			"changeTrackingId": "9c7a8c1e",
+			"category":         "DEPLOYMENT",
+			"type":             "BASIC",
+			"version":          "1.2.3",
+			"commit":           nil,
+			"timestamp":        float64(1767323045000),
+		},
+	})
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"change_tracking_id": "9c7a8c1e",
+			"category":           "DEPLOYMENT",
+			"type":               "BASIC",
+			"version":            "1.2.3",
+			"changelog":          "",
+			"commit":             "",
+			"deep_link":          "",
+			"feature_flag_id":    "",
+			"description":        "",
+			"short_description":  "",
+			"user":               "",
+			"group_id":           "",
+			"timestamp":          1767323045000,
+		},
+	}, events)
+}
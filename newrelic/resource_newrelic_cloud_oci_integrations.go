@@ -0,0 +1,266 @@
+package newrelic
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+)
+
+func resourceNewRelicCloudOciIntegrations() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicCloudOciIntegrationsCreate,
+		ReadContext:   resourceNewRelicCloudOciIntegrationsRead,
+		UpdateContext: resourceNewRelicCloudOciIntegrationsUpdate,
+		DeleteContext: resourceNewRelicCloudOciIntegrationsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the New Relic account.",
+				Computed:    true,
+				Optional:    true,
+			},
+			"linked_account_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the linked OCI tenancy in New Relic.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"oci_metadata_and_tags": {
+				Type:        schema.TypeList,
+				Description: "OCI metadata and tags integration.",
+				Optional:    true,
+				Elem:        &schema.Resource{Schema: cloudOciIntegrationSchema()},
+				MaxItems:    1,
+			},
+			"oci_logs": {
+				Type:        schema.TypeList,
+				Description: "OCI logs integration.",
+				Optional:    true,
+				Elem:        &schema.Resource{Schema: cloudOciIntegrationSchema()},
+				MaxItems:    1,
+			},
+		},
+	}
+}
+
+// The OCI integrations are configured by the stacks of the linked tenancy, see newrelic_cloud_oci_link_account,
+// so their settings are only read.
+func cloudOciIntegrationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"metrics_polling_interval": {
+			Type:        schema.TypeInt,
+			Description: "The data polling interval in seconds.",
+			Computed:    true,
+		},
+		"instrumentation_type": {
+			Type:        schema.TypeString,
+			Description: "The type of the integration, such as metrics, logs, or a combination of logs and metrics.",
+			Computed:    true,
+		},
+		"metric_stacks": {
+			Type:        schema.TypeList,
+			Description: "The OCIDs of the metric stacks of the integration.",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"logging_stacks": {
+			Type:        schema.TypeList,
+			Description: "The OCIDs of the logging stacks of the integration.",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func resourceNewRelicCloudOciIntegrationsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	configureInput, _ := expandCloudOciIntegrationsInputs(d)
+
+	payload, err := client.Cloud.CloudConfigureIntegrationWithContext(ctx, accountID, configureInput)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+
+	if len(payload.Errors) > 0 {
+		for _, err := range payload.Errors {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  err.Type + " " + err.Message,
+			})
+		}
+		return diags
+	}
+
+	if len(payload.Integrations) > 0 {
+		d.SetId(strconv.Itoa(d.Get("linked_account_id").(int)))
+	}
+
+	return resourceNewRelicCloudOciIntegrationsRead(ctx, d, meta)
+}
+
+// Expands the integration blocks to the integrations to configure, and those removed from the configuration to disable.
+func expandCloudOciIntegrationsInputs(d *schema.ResourceData) (cloud.CloudIntegrationsInput, cloud.CloudDisableIntegrationsInput) {
+	ociCloudIntegrations := cloud.CloudOciIntegrationsInput{}
+	ociDisableIntegrations := cloud.CloudOciDisableIntegrationsInput{}
+
+	linkedAccountID := d.Get("linked_account_id").(int)
+
+	if _, ok := d.GetOk("oci_metadata_and_tags"); ok {
+		ociCloudIntegrations.OciMetadataAndTags = []cloud.CloudOciMetadataAndTagsIntegrationInput{{LinkedAccountId: linkedAccountID}}
+	} else if o, n := d.GetChange("oci_metadata_and_tags"); len(n.([]interface{})) < len(o.([]interface{})) {
+		ociDisableIntegrations.OciMetadataAndTags = []cloud.CloudDisableAccountIntegrationInput{{LinkedAccountId: linkedAccountID}}
+	}
+
+	if _, ok := d.GetOk("oci_logs"); ok {
+		ociCloudIntegrations.OciLogs = []cloud.CloudOciLogsIntegrationInput{{LinkedAccountId: linkedAccountID}}
+	} else if o, n := d.GetChange("oci_logs"); len(n.([]interface{})) < len(o.([]interface{})) {
+		ociDisableIntegrations.OciLogs = []cloud.CloudDisableAccountIntegrationInput{{LinkedAccountId: linkedAccountID}}
+	}
+
+	return cloud.CloudIntegrationsInput{Oci: ociCloudIntegrations}, cloud.CloudDisableIntegrationsInput{Oci: ociDisableIntegrations}
+}
+
+func resourceNewRelicCloudOciIntegrationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	linkedAccountID, convErr := strconv.Atoi(d.Id())
+	if convErr != nil {
+		return diag.FromErr(convErr)
+	}
+
+	linkedAccount, err := client.Cloud.GetLinkedAccountWithContext(ctx, accountID, linkedAccountID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	flattenCloudOciLinkedAccount(d, linkedAccount)
+
+	return nil
+}
+
+func flattenCloudOciLinkedAccount(d *schema.ResourceData, linkedAccount *cloud.CloudLinkedAccount) {
+	_ = d.Set("account_id", linkedAccount.NrAccountId)
+	_ = d.Set("linked_account_id", linkedAccount.ID)
+
+	for _, i := range linkedAccount.Integrations {
+		switch t := i.(type) {
+		case *cloud.CloudOciMetadataAndTagsIntegration:
+			_ = d.Set("oci_metadata_and_tags", flattenCloudOciIntegration(t.MetricsPollingInterval, t.InstrumentationType, t.MetricStacks, t.LoggingStacks))
+		case *cloud.CloudOciLogsIntegration:
+			_ = d.Set("oci_logs", flattenCloudOciIntegration(t.MetricsPollingInterval, t.InstrumentationType, t.MetricStacks, t.LoggingStacks))
+		}
+	}
+}
+
+func flattenCloudOciIntegration(metricsPollingInterval int, instrumentationType string, metricStacks []string, loggingStacks []string) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"metrics_polling_interval": metricsPollingInterval,
+			"instrumentation_type":     instrumentationType,
+			"metric_stacks":            metricStacks,
+			"logging_stacks":           loggingStacks,
+		},
+	}
+}
+
+func resourceNewRelicCloudOciIntegrationsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	configureInput, disableInput := expandCloudOciIntegrationsInputs(d)
+
+	disablePayload, err := client.Cloud.CloudDisableIntegrationWithContext(ctx, accountID, disableInput)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+
+	if len(disablePayload.Errors) > 0 {
+		for _, err := range disablePayload.Errors {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  err.Type + " " + err.Message,
+			})
+		}
+		return diags
+	}
+
+	configurePayload, err := client.Cloud.CloudConfigureIntegrationWithContext(ctx, accountID, configureInput)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(configurePayload.Errors) > 0 {
+		for _, err := range configurePayload.Errors {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  err.Type + " " + err.Message,
+			})
+		}
+		return diags
+	}
+
+	return resourceNewRelicCloudOciIntegrationsRead(ctx, d, meta)
+}
+
+func resourceNewRelicCloudOciIntegrationsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	payload, err := client.Cloud.CloudDisableIntegrationWithContext(ctx, accountID, expandCloudOciDisableInputs(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+
+	if len(payload.Errors) > 0 {
+		for _, err := range payload.Errors {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  err.Type + " " + err.Message,
+			})
+		}
+		return diags
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// Expands the integration blocks to the integrations to disable when the resource is destroyed.
+func expandCloudOciDisableInputs(d *schema.ResourceData) cloud.CloudDisableIntegrationsInput {
+	ociDisableIntegrations := cloud.CloudOciDisableIntegrationsInput{}
+	linkedAccountID := d.Get("linked_account_id").(int)
+
+	if _, ok := d.GetOk("oci_metadata_and_tags"); ok {
+		ociDisableIntegrations.OciMetadataAndTags = []cloud.CloudDisableAccountIntegrationInput{{LinkedAccountId: linkedAccountID}}
+	}
+	if _, ok := d.GetOk("oci_logs"); ok {
+		ociDisableIntegrations.OciLogs = []cloud.CloudDisableAccountIntegrationInput{{LinkedAccountId: linkedAccountID}}
+	}
+
+	return cloud.CloudDisableIntegrationsInput{Oci: ociDisableIntegrations}
+}
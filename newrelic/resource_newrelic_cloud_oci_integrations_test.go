@@ -0,0 +1,133 @@
+//go:build integration || CLOUD
+
+package newrelic
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNewRelicCloudOciIntegrations_Basic(t *testing.T) {
+	resourceName := "newrelic_cloud_oci_integrations.foo"
+
+	if subAccountIDExists := os.Getenv("NEW_RELIC_SUBACCOUNT_ID"); subAccountIDExists == "" {
+		t.Skipf("Skipping this test, as NEW_RELIC_SUBACCOUNT_ID must be set for this test to run.")
+	}
+
+	// The OCI tenancy is linked outside of the test, see TestAccNewRelicCloudOciLinkAccount_Basic.
+	testOciLinkedAccountID := os.Getenv("INTEGRATION_TESTING_OCI_LINKED_ACCOUNT_ID")
+	if testOciLinkedAccountID == "" {
+		t.Skipf("INTEGRATION_TESTING_OCI_LINKED_ACCOUNT_ID must be set for this acceptance test")
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicCloudOciIntegrationsDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicCloudOciIntegrationsConfig(testOciLinkedAccountID, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicCloudOciIntegrationsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "oci_metadata_and_tags.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "oci_logs.#", "0"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicCloudOciIntegrationsConfig(testOciLinkedAccountID, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicCloudOciIntegrationsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "oci_logs.#", "1"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicCloudOciIntegrationsExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+		linkedAccountID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error converting string id to int")
+		}
+
+		linkedAccount, err := client.Cloud.GetLinkedAccount(testSubAccountID, linkedAccountID)
+		if err != nil {
+			return err
+		}
+
+		if len(linkedAccount.Integrations) == 0 {
+			return fmt.Errorf("an error occurred creating OCI integrations")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicCloudOciIntegrationsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).NewClient
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_cloud_oci_integrations" {
+			continue
+		}
+
+		linkedAccountID, err := strconv.Atoi(r.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error converting string id to int")
+		}
+
+		linkedAccount, err := client.Cloud.GetLinkedAccount(testSubAccountID, linkedAccountID)
+		if err == nil && len(linkedAccount.Integrations) != 0 {
+			return fmt.Errorf("OCI integrations were not disabled")
+		}
+	}
+
+	return nil
+}
+
+func testAccNewRelicCloudOciIntegrationsConfig(linkedAccountID string, updated bool) string {
+	logs := ""
+	if updated {
+		logs = `
+	oci_logs {}`
+	}
+
+	return fmt.Sprintf(`
+provider "newrelic" {
+	account_id = "%[1]d"
+	alias      = "cloud-integration-provider"
+}
+
+resource "newrelic_cloud_oci_integrations" "foo" {
+	provider          = newrelic.cloud-integration-provider
+	account_id        = %[1]d
+	linked_account_id = %[2]s
+
+	oci_metadata_and_tags {}
+%[3]s
+}
+`, testSubAccountID, linkedAccountID, logs)
+}
@@ -0,0 +1,75 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandCloudOciIntegrationsInputs(t *testing.T) {
+	t.Parallel()
+
+	resource := resourceNewRelicCloudOciIntegrations()
+	state := resource.Data(nil)
+	state.SetId("1234")
+	flattenCloudOciLinkedAccount(state, &cloud.CloudLinkedAccount{
+		ID:          1234,
+		NrAccountId: 1,
+		Integrations: []cloud.CloudIntegrationInterface{
+			&cloud.CloudOciMetadataAndTagsIntegration{MetricsPollingInterval: 300},
+			&cloud.CloudOciLogsIntegration{},
+		},
+	})
+
+	// oci_logs is removed from the configuration.
+	diff, err := schema.InternalMap(resource.Schema).Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"linked_account_id":     1234,
+		"oci_metadata_and_tags": []interface{}{map[string]interface{}{}},
+	}), nil, nil, true)
+	require.NoError(t, err)
+	d, err := schema.InternalMap(resource.Schema).Data(state.State(), diff)
+	require.NoError(t, err)
+
+	configure, disable := expandCloudOciIntegrationsInputs(d)
+	require.Equal(t, cloud.CloudOciIntegrationsInput{
+		OciMetadataAndTags: []cloud.CloudOciMetadataAndTagsIntegrationInput{{LinkedAccountId: 1234}},
+	}, configure.Oci)
+	require.Equal(t, cloud.CloudOciDisableIntegrationsInput{
+		OciLogs: []cloud.CloudDisableAccountIntegrationInput{{LinkedAccountId: 1234}},
+	}, disable.Oci)
+
+	require.Equal(t, cloud.CloudOciDisableIntegrationsInput{
+		OciMetadataAndTags: []cloud.CloudDisableAccountIntegrationInput{{LinkedAccountId: 1234}},
+		OciLogs:            []cloud.CloudDisableAccountIntegrationInput{{LinkedAccountId: 1234}},
+	}, expandCloudOciDisableInputs(state).Oci)
+}
+
+func TestFlattenCloudOciLinkedAccount(t *testing.T) {
+	t.Parallel()
+
+	d := resourceNewRelicCloudOciIntegrations().Data(nil)
+	flattenCloudOciLinkedAccount(d, &cloud.CloudLinkedAccount{
+		ID:          1234,
+		NrAccountId: 1,
+		Integrations: []cloud.CloudIntegrationInterface{
+			&cloud.CloudOciMetadataAndTagsIntegration{
+				MetricsPollingInterval: 300,
+				InstrumentationType:    "METRICS",
+				MetricStacks:           []string{"ocid1.stack.oc1..metrics"},
+			},
+		},
+	})
+
+	require.Equal(t, 1234, d.Get("linked_account_id"))
+	require.Equal(t, 1, d.Get("account_id"))
+	require.Equal(t, 300, d.Get("oci_metadata_and_tags.0.metrics_polling_interval"))
+	require.Equal(t, "METRICS", d.Get("oci_metadata_and_tags.0.instrumentation_type"))
+	require.Equal(t, []interface{}{"ocid1.stack.oc1..metrics"}, d.Get("oci_metadata_and_tags.0.metric_stacks"))
+	require.Empty(t, d.Get("oci_logs"))
+}
@@ -0,0 +1,174 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
+	nrErrors "github.com/newrelic/newrelic-client-go/v2/pkg/errors"
+)
+
+func resourceNewRelicEntityTag() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicEntityTagCreate,
+		ReadContext:   resourceNewRelicEntityTagRead,
+		UpdateContext: resourceNewRelicEntityTagUpdate,
+		DeleteContext: resourceNewRelicEntityTagDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"guid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The guid of the entity to tag.",
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringNotInSlice(defaultTags, false),
+				Description:  "The tag key.",
+			},
+			"values": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    1,
+				Required:    true,
+				Description: "The tag values managed by the resource. Other values of the tag are left as they are.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(180 * time.Second),
+		},
+	}
+}
+
+func resourceNewRelicEntityTagCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	guid := common.EntityGUID(d.Get("guid").(string))
+	tag := expandEntityTag(d.Get("key").(string), d.Get("values"))
+
+	log.Printf("[INFO] Adding New Relic entity tag %s to entity guid %s", tag.Key, guid)
+
+	if err := addEntityTags(ctx, client, guid, []entities.TaggingTagInput{tag}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", guid, tag.Key))
+
+	if err := waitForEntityTags(ctx, client, d.Timeout(schema.TimeoutCreate), []common.EntityGUID{guid}, []entities.TaggingTagInput{tag}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicEntityTagRead(ctx, d, meta)
+}
+
+func resourceNewRelicEntityTagRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	guid, key, err := parseCompositeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Reading New Relic entity tag %s of entity guid %s", key, guid)
+
+	current, err := client.Entities.GetTagsForEntityWithContext(ctx, common.EntityGUID(guid))
+	if err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	var values []string
+	if d.Get("guid").(string) == "" {
+		// Imported, so every value of the tag is managed
+		for _, t := range current {
+			if t.Key == key {
+				values = t.Values
+			}
+		}
+	} else {
+		found := intersectEntityTags([]entities.TaggingTagInput{expandEntityTag(key, d.Get("values"))}, current)
+		if len(found) > 0 {
+			values = found[0].Values
+		}
+	}
+
+	if err := d.Set("guid", guid); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("key", key); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("values", values))
+}
+
+func resourceNewRelicEntityTagUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	guid := common.EntityGUID(d.Get("guid").(string))
+	key := d.Get("key").(string)
+
+	o, n := d.GetChange("values")
+	tag := expandEntityTag(key, n)
+	add, remove := diffEntityTags([]entities.TaggingTagInput{expandEntityTag(key, o)}, []entities.TaggingTagInput{tag})
+
+	log.Printf("[INFO] Updating New Relic entity tag %s of entity guid %s", key, guid)
+
+	if err := addEntityTags(ctx, client, guid, add); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := deleteEntityTagValues(ctx, client, guid, remove); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitForEntityTags(ctx, client, d.Timeout(schema.TimeoutCreate), []common.EntityGUID{guid}, []entities.TaggingTagInput{tag}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicEntityTagRead(ctx, d, meta)
+}
+
+func resourceNewRelicEntityTagDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	guid := common.EntityGUID(d.Get("guid").(string))
+	_, remove := diffEntityTags([]entities.TaggingTagInput{expandEntityTag(d.Get("key").(string), d.Get("values"))}, nil)
+
+	log.Printf("[INFO] Deleting New Relic entity tag %s values from entity guid %s", d.Get("key").(string), guid)
+
+	if err := deleteEntityTagValues(ctx, client, guid, remove); err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func expandEntityTag(key string, values interface{}) entities.TaggingTagInput {
+	return entities.TaggingTagInput{
+		Key:    key,
+		Values: expandEntityTagValues(values.(*schema.Set).List()),
+	}
+}
@@ -0,0 +1,115 @@
+//go:build integration || ENTITY
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+)
+
+func TestAccNewRelicEntityTag_Basic(t *testing.T) {
+	resourceName := "newrelic_entity_tag.foo"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicEntityTagDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicEntityTagConfig(testAccExpectedApplicationName, `"value_1"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "values.#", "1"),
+					testAccCheckNewRelicEntityTagValues(resourceName, []string{"value_1"}),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicEntityTagConfig(testAccExpectedApplicationName, `"value_1", "value_2"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "values.#", "2"),
+					testAccCheckNewRelicEntityTagValues(resourceName, []string{"value_1", "value_2"}),
+				),
+			},
+			// Test: Import
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+				ResourceName:      resourceName,
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicEntityTagValues(n string, values []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+		tags, err := client.Entities.GetTagsForEntity(common.EntityGUID(rs.Primary.Attributes["guid"]))
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			if tag.Key != rs.Primary.Attributes["key"] {
+				continue
+			}
+			for _, v := range values {
+				if !stringInSlice(tag.Values, v) {
+					return fmt.Errorf("entity tag %s has no value %s", tag.Key, v)
+				}
+			}
+			return nil
+		}
+
+		return fmt.Errorf("entity tag %s not found", rs.Primary.Attributes["key"])
+	}
+}
+
+func testAccCheckNewRelicEntityTagDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_entity_tag" {
+			continue
+		}
+
+		tags, err := client.Entities.GetTagsForEntity(common.EntityGUID(r.Primary.Attributes["guid"]))
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			if tag.Key == r.Primary.Attributes["key"] {
+				return fmt.Errorf("entity tag %s still exists", tag.Key)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccNewRelicEntityTagConfig(appName string, values string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "foo" {
+  name = "%s"
+  type = "APPLICATION"
+  domain = "APM"
+}
+
+resource "newrelic_entity_tag" "foo" {
+  guid   = data.newrelic_entity.foo.guid
+  key    = "tf_test_entity_tag"
+  values = [%s]
+}
+`, appName, values)
+}
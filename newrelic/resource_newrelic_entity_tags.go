@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
 	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
 	nrErrors "github.com/newrelic/newrelic-client-go/v2/pkg/errors"
@@ -25,21 +26,81 @@ var (
 	}
 )
 
+const (
+	entityTagsModeReplace = "replace"
+	entityTagsModeMerge   = "merge"
+)
+
+// The maximum number of entities tagged by one resource, the size of the first page of entity search results.
+const entityTagsMaxEntities = 200
+
 func resourceNewRelicEntityTags() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceNewRelicEntityTagsCreate,
 		ReadContext:   resourceNewRelicEntityTagsRead,
 		UpdateContext: resourceNewRelicEntityTagsUpdate,
 		DeleteContext: resourceNewRelicEntityTagsDelete,
+		CustomizeDiff: resourceNewRelicEntityTagsDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"guid": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The guid of the entity to tag.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"guid", "guids", "entity_search"},
+				Description:  "The guid of the entity to tag.",
+			},
+			"guids": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				MinItems:     1,
+				MaxItems:     entityTagsMaxEntities,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"guid", "guids", "entity_search"},
+				Description:  "The guids of the entities to tag. Requires the merge mode.",
+			},
+			"entity_search": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"guid", "guids", "entity_search"},
+				Description:  "An entity search query matching the entities to tag, e.g. `domain = 'APM' AND tags.environment = 'production'`. Requires the merge mode.",
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      entityTagsModeReplace,
+				ValidateFunc: validation.StringInSlice([]string{entityTagsModeReplace, entityTagsModeMerge}, false),
+				Description:  "How the tags of the entities are managed. replace makes the declared tags the only tags of the entity, while merge only adds and removes the declared values, leaving other tags as they are.",
+			},
+			"entity_guids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The guids of the tagged entities.",
+			},
+			"managed_tags": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The tag values added by the merge mode, removed from the entities once no longer declared.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The tag key.",
+						},
+						"values": {
+							Type:        schema.TypeSet,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Computed:    true,
+							Description: "The tag values.",
+						},
+					},
+				},
 			},
 			"tag": {
 				Type:        schema.TypeSet,
@@ -70,7 +131,33 @@ func resourceNewRelicEntityTags() *schema.Resource {
 	}
 }
 
+func resourceNewRelicEntityTagsDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Get("mode").(string) == entityTagsModeMerge {
+		// The declared values are kept apart from the ones read back, so a value missing on
+		// some of the entities is still removed from the others once it is no longer declared.
+		if !d.NewValueKnown("tag") {
+			return d.SetNewComputed("managed_tags")
+		}
+
+		return d.SetNew("managed_tags", flattenEntityTagsManaged(expandEntityTags(d.Get("tag").(*schema.Set).List())))
+	}
+
+	if d.Get("guids").(*schema.Set).Len() > 0 || d.Get("entity_search").(string) != "" {
+		return fmt.Errorf("guids and entity_search require mode to be %q", entityTagsModeMerge)
+	}
+
+	if d.Get("managed_tags").(*schema.Set).Len() > 0 {
+		return d.SetNew("managed_tags", []interface{}{})
+	}
+
+	return nil
+}
+
 func resourceNewRelicEntityTagsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("mode").(string) == entityTagsModeMerge {
+		return resourceNewRelicEntityTagsMergeCreate(ctx, d, meta)
+	}
+
 	providerConfig := meta.(*ProviderConfig)
 	client := providerConfig.NewClient
 
@@ -121,6 +208,16 @@ func resourceNewRelicEntityTagsCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceNewRelicEntityTagsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	switch d.Get("mode").(string) {
+	case entityTagsModeMerge:
+		return resourceNewRelicEntityTagsMergeRead(ctx, d, meta)
+	case "":
+		// Imported, or created before the mode argument existed
+		if err := d.Set("mode", entityTagsModeReplace); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	providerConfig := meta.(*ProviderConfig)
 	client := providerConfig.NewClient
 
@@ -143,6 +240,10 @@ func resourceNewRelicEntityTagsRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNewRelicEntityTagsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("mode").(string) == entityTagsModeMerge {
+		return resourceNewRelicEntityTagsMergeUpdate(ctx, d, meta)
+	}
+
 	providerConfig := meta.(*ProviderConfig)
 	client := providerConfig.NewClient
 
@@ -206,6 +307,10 @@ func convertTagTypes(tags []*entities.EntityTag) []*entities.TaggingTagInput {
 }
 
 func resourceNewRelicEntityTagsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("mode").(string) == entityTagsModeMerge {
+		return resourceNewRelicEntityTagsMergeDelete(ctx, d, meta)
+	}
+
 	providerConfig := meta.(*ProviderConfig)
 	client := providerConfig.NewClient
 
@@ -268,6 +373,10 @@ func flattenEntityTags(d *schema.ResourceData, tags []*entities.TaggingTagInput)
 		return err
 	}
 
+	if err := d.Set("entity_guids", []string{d.Id()}); err != nil {
+		return err
+	}
+
 	if err := d.Set("tag", out); err != nil {
 		return err
 	}
@@ -316,3 +425,141 @@ func handleEntityTagsMutationEmbeddedErrors(res *entities.TaggingMutationResult)
 	}
 	return diags
 }
+
+// In merge mode, only the declared tag values are added and removed, and only the ones found
+// on every tagged entity are read back, so tags set by agents or other teams are left as they are.
+// The declared values are recorded in managed_tags, which the values to remove are worked out from.
+func resourceNewRelicEntityTagsMergeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	guids, err := resolveEntityTagsGUIDs(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(guids) == 0 {
+		return diag.Errorf("no entities match the entity search query %q", d.Get("entity_search").(string))
+	}
+
+	tags := expandEntityTags(d.Get("tag").(*schema.Set).List())
+
+	for _, guid := range guids {
+		log.Printf("[INFO] Adding New Relic entity tags to entity guid %s", guid)
+
+		if err := addEntityTags(ctx, client, guid, tags); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(entityTagsMergeID(d))
+
+	if err := d.Set("managed_tags", flattenEntityTagsManaged(tags)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitForEntityTags(ctx, client, d.Timeout(schema.TimeoutCreate), guids, tags); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicEntityTagsMergeRead(ctx, d, meta)
+}
+
+func resourceNewRelicEntityTagsMergeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	log.Printf("[INFO] Reading New Relic entity tags %s", d.Id())
+
+	guids, err := resolveEntityTagsGUIDs(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tags := expandEntityTags(d.Get("tag").(*schema.Set).List())
+	tagged := []string{}
+
+	for _, guid := range guids {
+		current, err := client.Entities.GetTagsForEntityWithContext(ctx, guid)
+		if err != nil {
+			if _, ok := err.(*nrErrors.NotFound); ok {
+				continue
+			}
+
+			return diag.FromErr(err)
+		}
+
+		tags = intersectEntityTags(tags, current)
+		tagged = append(tagged, string(guid))
+	}
+
+	if len(tagged) == 0 && d.Get("guid").(string) != "" {
+		d.SetId("")
+		return nil
+	}
+
+	out := make([]map[string]interface{}, len(tags))
+	for i, t := range tags {
+		out[i] = map[string]interface{}{
+			"key":    t.Key,
+			"values": t.Values,
+		}
+	}
+
+	if err := d.Set("tag", out); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("entity_guids", tagged))
+}
+
+func resourceNewRelicEntityTagsMergeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	guids, err := resolveEntityTagsGUIDs(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tags := expandEntityTags(d.Get("tag").(*schema.Set).List())
+
+	if err := mergeEntityTags(ctx, client, guids, tags, entityTagsMergeRemovals(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("managed_tags", flattenEntityTagsManaged(tags)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitForEntityTags(ctx, client, d.Timeout(schema.TimeoutCreate), guids, tags); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicEntityTagsMergeRead(ctx, d, meta)
+}
+
+func resourceNewRelicEntityTagsMergeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	guids := []common.EntityGUID{}
+	for _, guid := range d.Get("entity_guids").([]interface{}) {
+		guids = append(guids, common.EntityGUID(guid.(string)))
+	}
+
+	_, remove := diffEntityTags(expandEntityTags(d.Get("managed_tags").(*schema.Set).List()), nil)
+
+	for _, guid := range guids {
+		log.Printf("[INFO] Deleting New Relic entity tag values from entity guid %s", guid)
+
+		if err := deleteEntityTagValues(ctx, client, guid, remove); err != nil {
+			if _, ok := err.(*nrErrors.NotFound); ok {
+				continue
+			}
+
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
 )
 
 func TestAccNewRelicEntityTags_Basic(t *testing.T) {
@@ -65,6 +66,98 @@ func TestAccNewRelicEntityTags_Basic(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicEntityTags_Merge(t *testing.T) {
+	resourceName := "newrelic_entity_tags.foo"
+	foreignTag := entities.TaggingTagInput{Key: "tf_test_foreign_key", Values: []string{"foreign_value"}}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				PreConfig: func() {
+					testAccAddForeignEntityTag(t, testAccExpectedApplicationName, foreignTag)
+				},
+				Config: testAccNewRelicEntityTagsMergeConfig(testAccExpectedApplicationName, "tf_test_merge_key", "value_1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "entity_guids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+					testAccCheckNewRelicEntityTagsExist(resourceName, []string{"tf_test_merge_key"}),
+					testAccCheckNewRelicEntityTagsMergeForeignExists(resourceName, foreignTag.Key),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicEntityTagsMergeConfig(testAccExpectedApplicationName, "tf_test_merge_key", "value_2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicEntityTagsExist(resourceName, []string{"tf_test_merge_key"}),
+					testAccCheckNewRelicEntityTagsMergeForeignExists(resourceName, foreignTag.Key),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicEntityTags_MergeRequired(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "newrelic_entity_tags" "foo" {
+  entity_search = "name = '%s' AND domain = 'APM'"
+
+  tag {
+	key = "tf_test_key"
+	values = ["tf_test_value"]
+  }
+}
+`, testAccExpectedApplicationName),
+				ExpectError: regexp.MustCompile(`require mode to be "merge"`),
+			},
+		},
+	})
+}
+
+func testAccAddForeignEntityTag(t *testing.T, appName string, tag entities.TaggingTagInput) {
+	client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+	results, err := client.Entities.GetEntitySearchByQuery(entities.EntitySearchOptions{}, fmt.Sprintf("name = '%s' AND domain = 'APM'", appName), []entities.EntitySearchSortCriteria{})
+	if err != nil || len(results.Results.Entities) == 0 {
+		t.Fatalf("error finding entity %s: %v", appName, err)
+	}
+
+	if _, err := client.Entities.TaggingAddTagsToEntity(results.Results.Entities[0].GetGUID(), []entities.TaggingTagInput{tag}); err != nil {
+		t.Fatalf("error adding tag %s to entity %s: %s", tag.Key, appName, err)
+	}
+}
+
+func testAccCheckNewRelicEntityTagsMergeForeignExists(n string, key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+		t, err := client.Entities.GetTagsForEntity(common.EntityGUID(rs.Primary.Attributes["entity_guids.0"]))
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range t {
+			if tag.Key == key {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("entity tag %s set outside of Terraform was removed", key)
+	}
+}
+
 func testAccCheckNewRelicEntityTagsDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderConfig).NewClient
 	for _, r := range s.RootModule().Resources {
@@ -96,7 +189,7 @@ func testAccCheckNewRelicEntityTagsExist(n string, keysToCheck []string) resourc
 		client := testAccProvider.Meta().(*ProviderConfig).NewClient
 
 		retryErr := resource.RetryContext(context.Background(), 10*time.Second, func() *resource.RetryError {
-			t, err := client.Entities.GetTagsForEntityMutable(common.EntityGUID(rs.Primary.ID))
+			t, err := client.Entities.GetTagsForEntityMutable(common.EntityGUID(rs.Primary.Attributes["entity_guids.0"]))
 			if err != nil {
 				return resource.RetryableError(err)
 			}
@@ -175,3 +268,17 @@ resource "newrelic_entity_tags" "foo" {
 }
 `, appName, tagKey, tagValue)
 }
+
+func testAccNewRelicEntityTagsMergeConfig(appName string, tagKey string, tagValue string) string {
+	return fmt.Sprintf(`
+resource "newrelic_entity_tags" "foo" {
+  entity_search = "name = '%s' AND domain = 'APM'"
+  mode          = "merge"
+
+  tag {
+	key = "%s"
+	values = ["%s"]
+  }
+}
+`, appName, tagKey, tagValue)
+}
@@ -0,0 +1,233 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntersectEntityTags(t *testing.T) {
+	t.Parallel()
+
+	declared := []entities.TaggingTagInput{
+		{Key: "team", Values: []string{"payments", "checkout"}},
+		{Key: "environment", Values: []string{"production"}},
+		{Key: "tier", Values: []string{"1"}},
+	}
+	current := []*entities.EntityTag{
+		{Key: "team", Values: []string{"checkout", "platform"}},
+		{Key: "environment", Values: []string{"production"}},
+		{Key: "language", Values: []string{"go"}},
+	}
+
+	require.Equal(t, []entities.TaggingTagInput{
+		{Key: "team", Values: []string{"checkout"}},
+		{Key: "environment", Values: []string{"production"}},
+	}, intersectEntityTags(declared, current))
+}
+
+func TestDiffEntityTags(t *testing.T) {
+	t.Parallel()
+
+	old := []entities.TaggingTagInput{
+		{Key: "team", Values: []string{"payments", "checkout"}},
+		{Key: "tier", Values: []string{"1"}},
+	}
+	new := []entities.TaggingTagInput{
+		{Key: "team", Values: []string{"checkout", "cart"}},
+		{Key: "environment", Values: []string{"production"}},
+	}
+
+	add, remove := diffEntityTags(old, new)
+	require.Equal(t, []entities.TaggingTagInput{
+		{Key: "team", Values: []string{"cart"}},
+		{Key: "environment", Values: []string{"production"}},
+	}, add)
+	require.Equal(t, []entities.TaggingTagValueInput{
+		{Key: "team", Value: "payments"},
+		{Key: "tier", Value: "1"},
+	}, remove)
+
+	add, remove = diffEntityTags(nil, new)
+	require.Equal(t, new, add)
+	require.Empty(t, remove)
+
+	add, remove = diffEntityTags(new, new)
+	require.Empty(t, add)
+	require.Empty(t, remove)
+}
+
+func TestResourceNewRelicEntityTagsDiff(t *testing.T) {
+	t.Parallel()
+
+	tag := []interface{}{map[string]interface{}{"key": "team", "values": []interface{}{"checkout"}}}
+
+	cases := map[string]struct {
+		raw map[string]interface{}
+		err string
+	}{
+		"guid": {
+			raw: map[string]interface{}{"guid": "MQ", "tag": tag},
+		},
+		"guid merge": {
+			raw: map[string]interface{}{"guid": "MQ", "mode": "merge", "tag": tag},
+		},
+		"guids merge": {
+			raw: map[string]interface{}{"guids": []interface{}{"MQ", "Mg"}, "mode": "merge", "tag": tag},
+		},
+		"entity search merge": {
+			raw: map[string]interface{}{"entity_search": "domain = 'APM'", "mode": "merge", "tag": tag},
+		},
+		"guids replace": {
+			raw: map[string]interface{}{"guids": []interface{}{"MQ", "Mg"}, "tag": tag},
+			err: `guids and entity_search require mode to be "merge"`,
+		},
+		"entity search replace": {
+			raw: map[string]interface{}{"entity_search": "domain = 'APM'", "mode": "replace", "tag": tag},
+			err: `guids and entity_search require mode to be "merge"`,
+		},
+	}
+
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := resourceNewRelicEntityTags().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(c.raw), nil)
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.err)
+			}
+		})
+	}
+}
+
+func TestResourceNewRelicEntityTagsDiff_ManagedTags(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"entity_search": "domain = 'APM'",
+		"mode":          "merge",
+		"tag":           []interface{}{map[string]interface{}{"key": "team", "values": []interface{}{"checkout", "payments"}}},
+	}
+
+	diff, err := resourceNewRelicEntityTags().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+	require.NoError(t, err)
+	require.Equal(t, "1", diff.Attributes["managed_tags.#"].New)
+	require.False(t, diff.Attributes["managed_tags.#"].NewComputed)
+}
+
+func TestEntityTagsMergeRemovals_ValueMissingOnAnEntity(t *testing.T) {
+	t.Parallel()
+
+	r := resourceNewRelicEntityTags()
+	config := func(values ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"entity_search": "domain = 'APM'",
+			"mode":          "merge",
+			"tag":           []interface{}{map[string]interface{}{"key": "team", "values": values}},
+		}
+	}
+
+	// Applied with both values, then read back after the search matched an entity without payments
+	declared := []entities.TaggingTagInput{{Key: "team", Values: []string{"checkout", "payments"}}}
+	read := intersectEntityTags(declared, []*entities.EntityTag{{Key: "team", Values: []string{"checkout"}}})
+	require.Equal(t, []entities.TaggingTagInput{{Key: "team", Values: []string{"checkout"}}}, read)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config("checkout", "payments"))
+	d.SetId("1")
+	require.NoError(t, d.Set("managed_tags", flattenEntityTagsManaged(declared)))
+	require.NoError(t, d.Set("tag", flattenEntityTagsManaged(read)))
+	state := d.State()
+
+	// payments is then no longer declared
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config("checkout")), nil)
+	require.NoError(t, err)
+
+	data, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+
+	require.Equal(t, []entities.TaggingTagValueInput{{Key: "team", Value: "payments"}}, entityTagsMergeRemovals(data))
+
+	// Destroying removes it as well, as the delete works from the managed values
+	_, remove := diffEntityTags(expandEntityTags(r.Data(state).Get("managed_tags").(*schema.Set).List()), nil)
+	require.ElementsMatch(t, []entities.TaggingTagValueInput{{Key: "team", Value: "checkout"}, {Key: "team", Value: "payments"}}, remove)
+}
+
+func TestMergeEntityTags_TagsEveryEntity(t *testing.T) {
+	t.Parallel()
+
+	// checkout was removed from a outside of Terraform, and b was matched after the last apply
+	tagged := map[string]map[string][]string{
+		"a": {"team": {"payments"}},
+		"b": {},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request testNerdGraphRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		guid := request.Variables["guid"].(string)
+		switch {
+		case strings.Contains(request.Query, "taggingAddTagsToEntity"):
+			for _, raw := range request.Variables["tags"].([]interface{}) {
+				tag := raw.(map[string]interface{})
+				for _, v := range tag["values"].([]interface{}) {
+					if !stringInSlice(tagged[guid][tag["key"].(string)], v.(string)) {
+						tagged[guid][tag["key"].(string)] = append(tagged[guid][tag["key"].(string)], v.(string))
+					}
+				}
+			}
+		case strings.Contains(request.Query, "taggingDeleteTagValuesFromEntity"):
+			t.Errorf("unexpected removal of %v from %s", request.Variables["tagValues"], guid)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"taggingAddTagsToEntity": {"errors": []}, "taggingDeleteTagValuesFromEntity": {"errors": []}}}`))
+	}))
+	defer server.Close()
+
+	client, err := newrelic.New(newrelic.ConfigPersonalAPIKey("NRAK-TEST"), newrelic.ConfigNerdGraphBaseURL(server.URL))
+	require.NoError(t, err)
+
+	// The declared tags did not change, so there is nothing to remove
+	declared := []entities.TaggingTagInput{{Key: "team", Values: []string{"checkout", "payments"}}}
+	d := schema.TestResourceDataRaw(t, resourceNewRelicEntityTags().Schema, map[string]interface{}{
+		"entity_search": "domain = 'APM'",
+		"mode":          "merge",
+		"tag":           []interface{}{map[string]interface{}{"key": "team", "values": []interface{}{"checkout", "payments"}}},
+	})
+	require.NoError(t, d.Set("managed_tags", flattenEntityTagsManaged(declared)))
+	remove := entityTagsMergeRemovals(d)
+	require.Empty(t, remove)
+
+	require.NoError(t, mergeEntityTags(context.Background(), client, []common.EntityGUID{"a", "b"}, declared, remove))
+	require.ElementsMatch(t, []string{"checkout", "payments"}, tagged["a"]["team"])
+	require.ElementsMatch(t, []string{"checkout", "payments"}, tagged["b"]["team"])
+}
+
+func TestEntityTagsMergeID(t *testing.T) {
+	t.Parallel()
+
+	s := resourceNewRelicEntityTags().Schema
+
+	require.Equal(t, "MQ", entityTagsMergeID(schema.TestResourceDataRaw(t, s, map[string]interface{}{"guid": "MQ"})))
+	require.Equal(t, strconv.Itoa(schema.HashString("domain = 'APM'")),
+		entityTagsMergeID(schema.TestResourceDataRaw(t, s, map[string]interface{}{"entity_search": "domain = 'APM'"})))
+	require.Equal(t,
+		entityTagsMergeID(schema.TestResourceDataRaw(t, s, map[string]interface{}{"guids": []interface{}{"MQ", "Mg"}})),
+		entityTagsMergeID(schema.TestResourceDataRaw(t, s, map[string]interface{}{"guids": []interface{}{"Mg", "MQ"}})))
+}
@@ -0,0 +1,312 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/authorizationmanagement"
+)
+
+func resourceNewRelicGroupAccessGrant() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicGroupAccessGrantCreate,
+		ReadContext:   resourceNewRelicGroupAccessGrantRead,
+		UpdateContext: resourceNewRelicGroupAccessGrantUpdate,
+		DeleteContext: resourceNewRelicGroupAccessGrantDelete,
+		CustomizeDiff: resourceNewRelicGroupAccessGrantCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNewRelicGroupAccessGrantImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:         schema.TypeString,
+				Description:  "The ID of the group the role is granted to.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"role_id": {
+				Type:         schema.TypeString,
+				Description:  "The ID of the standard or custom role granted to the group.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"scope_type": {
+				Type:        schema.TypeString,
+				Description: "The type of the scope the role is granted on. Valid values are ACCOUNT and ORGANIZATION.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     string(authorizationmanagement.AuthorizationManagementIamParentScopeTypeEnumTypes.ACCOUNT),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(authorizationmanagement.AuthorizationManagementIamParentScopeTypeEnumTypes.ACCOUNT),
+					string(authorizationmanagement.AuthorizationManagementIamParentScopeTypeEnumTypes.ORGANIZATION),
+				}, false),
+			},
+			"account_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the account the role is granted on, when scope_type is ACCOUNT. Defaults to the account ID set in the provider.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"data_access_policy_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of a data access policy restricting the data of the account the group can access.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"scope_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the account or organization the role is granted on.",
+				Computed:    true,
+			},
+			"grant_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the access grant.",
+				Computed:    true,
+			},
+			"exclusive": {
+				Type:        schema.TypeBool,
+				Description: "Whether the grants of other roles to the group on the scope, except the ones of other_role_ids, are revoked. When false, they are only reported as a warning.",
+				Optional:    true,
+				Default:     false,
+			},
+			"other_role_ids": {
+				Type:        schema.TypeSet,
+				Description: "The IDs of the other roles the group is expected to be granted on the scope, e.g. by other newrelic_group_access_grant resources.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"unmanaged_grants": {
+				Type:        schema.TypeList,
+				Description: "The grants of other roles to the group on the scope, except the ones of other_role_ids, e.g. made outside of Terraform.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"grant_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the access grant.",
+							Computed:    true,
+						},
+						"role_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the role granted to the group.",
+							Computed:    true,
+						},
+						"data_access_policy_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the data access policy of the grant, if any.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Minute),
+		},
+	}
+}
+
+// Plans the revocation of the unmanaged grants found by the last read, so that
+// they show as a diff when the grant is exclusive. Exclusive grants being created
+// revoke the unmanaged grants as well.
+func resourceNewRelicGroupAccessGrantCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.Get("exclusive").(bool) {
+		return nil
+	}
+
+	if len(d.Get("unmanaged_grants").([]interface{})) == 0 {
+		return nil
+	}
+
+	return d.SetNew("unmanaged_grants", []interface{}{})
+}
+
+func resourceNewRelicGroupAccessGrantCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	id := groupAccessGrantID{
+		GroupID:   d.Get("group_id").(string),
+		RoleID:    d.Get("role_id").(string),
+		ScopeType: d.Get("scope_type").(string),
+	}
+
+	if _, err := strconv.Atoi(id.RoleID); err != nil {
+		return diag.Errorf("invalid role ID %q: must be numeric", id.RoleID)
+	}
+
+	if id.ScopeType == string(authorizationmanagement.AuthorizationManagementIamParentScopeTypeEnumTypes.ORGANIZATION) {
+		if _, ok := d.GetOk("account_id"); ok {
+			return diag.Errorf("account_id cannot be set when scope_type is ORGANIZATION")
+		}
+
+		organization, err := client.Organization.GetOrganizationWithContext(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		id.ScopeID = organization.ID
+	} else {
+		accountID := selectAccountID(providerConfig, d)
+		_ = d.Set("account_id", accountID)
+		id.ScopeID = strconv.Itoa(accountID)
+	}
+
+	grant, err := expandGroupAccessGrant(id, d.Get("data_access_policy_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Granting role %s on %s %s to group %s", id.RoleID, id.ScopeType, id.ScopeID, id.GroupID)
+
+	if _, err := client.AuthorizationManagement.AuthorizationManagementGrantAccessWithContext(ctx, grant); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+
+	if d.Get("exclusive").(bool) {
+		otherRoleIDs := expandStringSlice(d.Get("other_role_ids").(*schema.Set).List())
+		if err := revokeUnmanagedGroupAccessGrants(ctx, client, id, otherRoleIDs, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceNewRelicGroupAccessGrantRead(ctx, d, meta)
+}
+
+func resourceNewRelicGroupAccessGrantRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	log.Printf("[INFO] Reading New Relic group access grant %s", d.Id())
+
+	id, err := parseGroupAccessGrantID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	grant, err := getGroupAccessGrant(ctx, client, *id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The grant was revoked outside of Terraform, so it is granted again on the next apply.
+	if grant == nil {
+		log.Printf("[WARN] Group access grant %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("group_id", id.GroupID)
+	_ = d.Set("role_id", id.RoleID)
+	_ = d.Set("scope_type", id.ScopeType)
+	_ = d.Set("scope_id", id.ScopeID)
+	_ = d.Set("grant_id", strconv.Itoa(grant.ID))
+	_ = d.Set("data_access_policy_id", grant.DataAccessPolicy.ID)
+
+	if id.ScopeType == string(authorizationmanagement.AuthorizationManagementIamParentScopeTypeEnumTypes.ACCOUNT) {
+		accountID, err := strconv.Atoi(id.ScopeID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = d.Set("account_id", accountID)
+	}
+
+	// Grants made outside of Terraform are stored in state, so that exclusive
+	// grants plan their revocation.
+	scopeGrants, err := getGroupScopeAccessGrants(ctx, client, *id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	unmanaged := unmanagedGroupAccessGrants(scopeGrants, *id, expandStringSlice(d.Get("other_role_ids").(*schema.Set).List()))
+	if err := d.Set("unmanaged_grants", flattenUnmanagedGroupAccessGrants(unmanaged)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(unmanaged) == 0 || d.Get("exclusive").(bool) {
+		return nil
+	}
+
+	roleIDs := make([]string, len(unmanaged))
+	for i, grant := range unmanaged {
+		roleIDs[i] = strconv.Itoa(grant.Role.ID)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Unmanaged group access grants",
+			Detail: fmt.Sprintf(
+				"Group %s is also granted the roles %s on %s %s. Add them to other_role_ids if they are expected, or set exclusive to revoke them.",
+				id.GroupID, strings.Join(roleIDs, ", "), id.ScopeType, id.ScopeID,
+			),
+		},
+	}
+}
+
+// Only exclusive, other_role_ids and the unmanaged grants can change. The
+// unmanaged grants are revoked when the grant is exclusive.
+func resourceNewRelicGroupAccessGrantUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	id, err := parseGroupAccessGrantID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The grants are listed again, so that the ones made since the last read are revoked too.
+	if d.Get("exclusive").(bool) {
+		otherRoleIDs := expandStringSlice(d.Get("other_role_ids").(*schema.Set).List())
+		if err := revokeUnmanagedGroupAccessGrants(ctx, client, *id, otherRoleIDs, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceNewRelicGroupAccessGrantRead(ctx, d, meta)
+}
+
+func resourceNewRelicGroupAccessGrantDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	id, err := parseGroupAccessGrantID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(revokeGroupAccessGrant(ctx, client, *id, d.Get("data_access_policy_id").(string)))
+}
+
+func resourceNewRelicGroupAccessGrantImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := parseGroupAccessGrantID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if id.ScopeType == string(authorizationmanagement.AuthorizationManagementIamParentScopeTypeEnumTypes.ACCOUNT) {
+		if _, err := strconv.Atoi(id.ScopeID); err != nil {
+			return nil, fmt.Errorf("invalid account ID %q: must be numeric", id.ScopeID)
+		}
+	}
+
+	d.SetId(id.String())
+	_ = d.Set("exclusive", false)
+
+	return []*schema.ResourceData{d}, nil
+}
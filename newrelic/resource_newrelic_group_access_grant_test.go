@@ -0,0 +1,156 @@
+//go:build integration || AUTH
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/authorizationmanagement"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/testhelpers"
+)
+
+var groupAccessGrantResourceName string = "newrelic_group_access_grant.foo"
+
+func TestAccNewRelicGroupAccessGrant_Basic(t *testing.T) {
+	groupName := fmt.Sprintf("%s-%s", groupNamePrefix, testhelpers.RandSeq(10))
+	var groupID, adminRoleID string
+	var accountID int
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEnvVars(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccNewRelicCheckGroupAccessGrantDestroy,
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccNewRelicGroupAccessGrantConfiguration(groupName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNewRelicCheckGroupAccessGrantExists(groupAccessGrantResourceName),
+					resource.TestCheckResourceAttrSet(groupAccessGrantResourceName, "grant_id"),
+					resource.TestCheckResourceAttr(groupAccessGrantResourceName, "scope_type", "ACCOUNT"),
+					resource.TestCheckResourceAttr(groupAccessGrantResourceName, "unmanaged_grants.#", "0"),
+					func(s *terraform.State) error {
+						grant := s.RootModule().Resources[groupAccessGrantResourceName].Primary.Attributes
+						groupID = grant["group_id"]
+						accountID, _ = strconv.Atoi(grant["account_id"])
+						adminRoleID = s.RootModule().Resources["data.newrelic_roles.admin"].Primary.Attributes["roles.0.id"]
+						return nil
+					},
+				),
+			},
+			// Import
+			{
+				ResourceName:      groupAccessGrantResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// A grant made outside of Terraform is stored in state
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*ProviderConfig).NewClient
+					if _, err := client.AuthorizationManagement.AuthorizationManagementGrantAccess(authorizationmanagement.AuthorizationManagementGrantAccess{
+						GroupId: groupID,
+						AccountAccessGrants: []authorizationmanagement.AuthorizationManagementAccountAccessGrant{
+							{AccountID: accountID, RoleId: adminRoleID},
+						},
+					}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccNewRelicGroupAccessGrantConfiguration(groupName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(groupAccessGrantResourceName, "unmanaged_grants.#", "1"),
+					resource.TestCheckResourceAttrPair(groupAccessGrantResourceName, "unmanaged_grants.0.role_id", "data.newrelic_roles.admin", "roles.0.id"),
+				),
+			},
+			// An exclusive grant plans to revoke it
+			{
+				Config:             testAccNewRelicGroupAccessGrantConfiguration(groupName, true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccNewRelicGroupAccessGrantConfiguration(groupName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(groupAccessGrantResourceName, "unmanaged_grants.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicCheckGroupAccessGrantExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		id, err := parseGroupAccessGrantID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+		grant, err := getGroupAccessGrant(context.Background(), client, *id)
+		if err != nil {
+			return err
+		}
+		if grant == nil {
+			return fmt.Errorf("group access grant %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicCheckGroupAccessGrantDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_group_access_grant" {
+			continue
+		}
+
+		id, err := parseGroupAccessGrantID(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		grant, err := getGroupAccessGrant(context.Background(), client, *id)
+		if err == nil && grant != nil {
+			return fmt.Errorf("group access grant %s still exists", r.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccNewRelicGroupAccessGrantConfiguration(groupName string, exclusive bool) string {
+	return fmt.Sprintf(`
+data "newrelic_roles" "read_only" {
+  name = "Read Only"
+}
+
+data "newrelic_roles" "admin" {
+  name = "All Product Admin"
+}
+
+resource "newrelic_group" "foo" {
+  name                     = "%s"
+  authentication_domain_id = "%s"
+}
+
+resource "newrelic_group_access_grant" "foo" {
+  group_id  = newrelic_group.foo.id
+  role_id   = data.newrelic_roles.read_only.roles[0].id
+  exclusive = %t
+}
+`, groupName, authenticationDomainId, exclusive)
+}
@@ -0,0 +1,143 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/region"
+)
+
+func resourceNewRelicIngestLogs() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"common": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Description: "The attributes shared by the logs.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"attribute": ingestAttributeSchema("An attribute shared by the logs, e.g. logtype or service."),
+				},
+			},
+		},
+		"log": {
+			Type:        schema.TypeList,
+			Required:    true,
+			ForceNew:    true,
+			MinItems:    1,
+			Description: "A log to send.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"message": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						Description:  "The message of the log.",
+					},
+					"timestamp": {
+						Type:        schema.TypeInt,
+						Optional:    true,
+						ForceNew:    true,
+						Description: "The time of the log, in milliseconds since the Unix epoch. Defaults to the time the log is received.",
+					},
+					"attribute": ingestAttributeSchema("An attribute of the log."),
+				},
+			},
+		},
+		"triggers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Arbitrary values which send the logs again when changed.",
+		},
+		"request_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the requests the logs were accepted in.",
+		},
+	}
+	for k, v := range ingestKeySchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceNewRelicIngestLogsCreate,
+		ReadContext:   schema.NoopContext,
+		Delete:        schema.RemoveFromState,
+		Schema:        s,
+	}
+}
+
+func resourceNewRelicIngestLogsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	sender, err := newIngestSender(providerConfig, d, (*region.Region).LogsURL)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	common, logs, err := expandIngestLogs(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Sending %d New Relic logs", len(logs))
+
+	requestIDs, err := sender.send(ctx, logs, func(batch []interface{}) interface{} {
+		return []map[string]interface{}{{"common": common, "logs": batch}}
+	})
+	if err != nil {
+		return diag.Errorf("error sending logs: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", rand.Int()))
+
+	return diag.FromErr(d.Set("request_ids", requestIDs))
+}
+
+// expandIngestLogs returns the common block and the logs of a Log API payload.
+func expandIngestLogs(d *schema.ResourceData) (map[string]interface{}, []interface{}, error) {
+	common := map[string]interface{}{}
+
+	if v, ok := d.GetOk("common.0.attribute"); ok {
+		attributes, err := expandIngestAttributes(v.(*schema.Set).List())
+		if err != nil {
+			return nil, nil, err
+		}
+		common["attributes"] = attributes
+	}
+
+	var logs []interface{}
+	for _, l := range d.Get("log").([]interface{}) {
+		entry := l.(map[string]interface{})
+
+		out := map[string]interface{}{
+			"message": entry["message"].(string),
+		}
+
+		if timestamp := entry["timestamp"].(int); timestamp > 0 {
+			out["timestamp"] = timestamp
+		}
+
+		attributes, err := expandIngestAttributes(entry["attribute"].(*schema.Set).List())
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(attributes) > 0 {
+			out["attributes"] = attributes
+		}
+
+		logs = append(logs, out)
+	}
+
+	return common, logs, nil
+}
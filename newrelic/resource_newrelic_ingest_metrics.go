@@ -0,0 +1,259 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/region"
+)
+
+const ingestMetricTypeSummary = "summary"
+
+func resourceNewRelicIngestMetrics() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"common": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Description: "The attributes shared by the metrics.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"timestamp": {
+						Type:        schema.TypeInt,
+						Optional:    true,
+						ForceNew:    true,
+						Description: "The start time of the metrics, in milliseconds since the Unix epoch.",
+					},
+					"interval_ms": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+						Description:  "The length of the time window of the count and summary metrics, in milliseconds.",
+					},
+					"attribute": ingestAttributeSchema("A dimension shared by the metrics."),
+				},
+			},
+		},
+		"metric": {
+			Type:        schema.TypeList,
+			Required:    true,
+			ForceNew:    true,
+			MinItems:    1,
+			Description: "A metric to send.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,255}$`), "must be a metric name of up to 255 letters, numbers, periods, colons, underscores and hyphens"),
+						Description:  "The name of the metric.",
+					},
+					"type": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						Default:      "gauge",
+						ValidateFunc: validation.StringInSlice([]string{"gauge", "count", ingestMetricTypeSummary}, false),
+						Description:  "The type of the metric, one of gauge, count or summary.",
+					},
+					"value": {
+						Type:        schema.TypeFloat,
+						Optional:    true,
+						ForceNew:    true,
+						Description: "The value of gauge and count metrics.",
+					},
+					"summary": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						ForceNew:    true,
+						MaxItems:    1,
+						Description: "The value of summary metrics.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"count": {Type: schema.TypeFloat, Required: true, ForceNew: true, Description: "The number of occurrences."},
+								"sum":   {Type: schema.TypeFloat, Required: true, ForceNew: true, Description: "The sum of the occurrences."},
+								"min":   {Type: schema.TypeFloat, Required: true, ForceNew: true, Description: "The smallest occurrence."},
+								"max":   {Type: schema.TypeFloat, Required: true, ForceNew: true, Description: "The largest occurrence."},
+							},
+						},
+					},
+					"timestamp": {
+						Type:        schema.TypeInt,
+						Optional:    true,
+						ForceNew:    true,
+						Description: "The start time of the metric, in milliseconds since the Unix epoch. Defaults to the common timestamp, or the time the metric is sent.",
+					},
+					"interval_ms": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+						Description:  "The length of the time window of count and summary metrics, in milliseconds. Defaults to the common interval.",
+					},
+					"attribute": ingestAttributeSchema("A dimension of the metric."),
+				},
+			},
+		},
+		"triggers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Arbitrary values which send the metrics again when changed.",
+		},
+		"request_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the requests the metrics were accepted in.",
+		},
+	}
+	for k, v := range ingestKeySchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceNewRelicIngestMetricsCreate,
+		ReadContext:   schema.NoopContext,
+		Delete:        schema.RemoveFromState,
+		CustomizeDiff: resourceNewRelicIngestMetricsDiff,
+		Schema:        s,
+	}
+}
+
+// ingestAttributeSchema returns attribute blocks typed like the ones of newrelic_insights_event.
+func ingestAttributeSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		ForceNew:    true,
+		MaxItems:    255,
+		Elem:        eventValueSchema(),
+		Description: description,
+	}
+}
+
+func resourceNewRelicIngestMetricsDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	commonInterval := d.Get("common.0.interval_ms").(int)
+
+	for i, m := range d.Get("metric").([]interface{}) {
+		if m == nil {
+			continue
+		}
+		metric := m.(map[string]interface{})
+		metricType := metric["type"].(string)
+		summary := len(metric["summary"].([]interface{})) > 0
+
+		if metricType == ingestMetricTypeSummary && !summary {
+			return fmt.Errorf("metric %d: summary is required for summary metrics", i+1)
+		}
+		if metricType != ingestMetricTypeSummary && summary {
+			return fmt.Errorf("metric %d: summary is only allowed for summary metrics", i+1)
+		}
+		if metricType != "gauge" && metric["interval_ms"].(int) == 0 && commonInterval == 0 {
+			return fmt.Errorf("metric %d: interval_ms is required for %s metrics", i+1, metricType)
+		}
+	}
+
+	return nil
+}
+
+func resourceNewRelicIngestMetricsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	sender, err := newIngestSender(providerConfig, d, (*region.Region).MetricsURL)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	common, metrics, err := expandIngestMetrics(d, time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Sending %d New Relic metrics", len(metrics))
+
+	requestIDs, err := sender.send(ctx, metrics, func(batch []interface{}) interface{} {
+		return []map[string]interface{}{{"common": common, "metrics": batch}}
+	})
+	if err != nil {
+		return diag.Errorf("error sending metrics: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", rand.Int()))
+
+	return diag.FromErr(d.Set("request_ids", requestIDs))
+}
+
+// expandIngestMetrics returns the common block and the metrics of a Metric API payload.
+// Metrics without a timestamp, in their own or the common block, are sent as of now.
+func expandIngestMetrics(d *schema.ResourceData, now time.Time) (map[string]interface{}, []interface{}, error) {
+	common := map[string]interface{}{}
+
+	if v, ok := d.GetOk("common.0.timestamp"); ok {
+		common["timestamp"] = v.(int)
+	}
+	if v, ok := d.GetOk("common.0.interval_ms"); ok {
+		common["interval.ms"] = v.(int)
+	}
+	if v, ok := d.GetOk("common.0.attribute"); ok {
+		attributes, err := expandIngestAttributes(v.(*schema.Set).List())
+		if err != nil {
+			return nil, nil, err
+		}
+		common["attributes"] = attributes
+	}
+
+	var metrics []interface{}
+	for _, m := range d.Get("metric").([]interface{}) {
+		metric := m.(map[string]interface{})
+
+		out := map[string]interface{}{
+			"name":  metric["name"].(string),
+			"type":  metric["type"].(string),
+			"value": metric["value"].(float64),
+		}
+
+		if summary := metric["summary"].([]interface{}); len(summary) > 0 && summary[0] != nil {
+			s := summary[0].(map[string]interface{})
+			out["value"] = map[string]interface{}{
+				"count": s["count"].(float64),
+				"sum":   s["sum"].(float64),
+				"min":   s["min"].(float64),
+				"max":   s["max"].(float64),
+			}
+		}
+
+		if timestamp := metric["timestamp"].(int); timestamp > 0 {
+			out["timestamp"] = timestamp
+		} else if _, ok := common["timestamp"]; !ok {
+			out["timestamp"] = now.UnixMilli()
+		}
+
+		if interval := metric["interval_ms"].(int); interval > 0 {
+			out["interval.ms"] = interval
+		}
+
+		attributes, err := expandIngestAttributes(metric["attribute"].(*schema.Set).List())
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(attributes) > 0 {
+			out["attributes"] = attributes
+		}
+
+		metrics = append(metrics, out)
+	}
+
+	return common, metrics, nil
+}
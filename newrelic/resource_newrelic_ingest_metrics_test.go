@@ -0,0 +1,142 @@
+//go:build integration || EVENTS
+
+package newrelic
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNewRelicIngestMetrics_Basic(t *testing.T) {
+	if v := os.Getenv("NEW_RELIC_LICENSE_KEY"); v == "" {
+		t.Skipf("NEW_RELIC_LICENSE_KEY must be set for acceptance tests")
+	}
+
+	rName := acctest.RandString(5)
+	tNow := time.Now().UnixMilli()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: func(*terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicIngestMetricsConfig(rName, tNow, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("newrelic_ingest_metrics.foo", "request_ids.#", "1"),
+				),
+			},
+			// Changing the triggers sends the metrics again
+			{
+				Config: testAccNewRelicIngestMetricsConfig(rName, tNow, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("newrelic_ingest_metrics.foo", "request_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicIngestLogs_Basic(t *testing.T) {
+	if v := os.Getenv("NEW_RELIC_LICENSE_KEY"); v == "" {
+		t.Skipf("NEW_RELIC_LICENSE_KEY must be set for acceptance tests")
+	}
+
+	rName := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: func(*terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicIngestLogsConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("newrelic_ingest_logs.foo", "request_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicIngestMetricsConfig(name string, timestamp int64, trigger string) string {
+	return fmt.Sprintf(`
+resource "newrelic_ingest_metrics" "foo" {
+  common {
+    timestamp   = %[2]d
+    interval_ms = 60000
+
+    attribute {
+      key   = "test_run"
+      value = "tf_test_%[1]s"
+    }
+  }
+
+  metric {
+    name  = "tf_test.gauge"
+    value = 12.5
+  }
+
+  metric {
+    name  = "tf_test.count"
+    type  = "count"
+    value = 3
+  }
+
+  metric {
+    name = "tf_test.summary"
+    type = "summary"
+
+    summary {
+      count = 4
+      sum   = 10
+      min   = 1
+      max   = 4
+    }
+
+    attribute {
+      key   = "attempt"
+      value = "2"
+      type  = "int"
+    }
+  }
+
+  triggers = {
+    run = "%[3]s"
+  }
+}
+`, name, timestamp, trigger)
+}
+
+func testAccNewRelicIngestLogsConfig(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_ingest_logs" "foo" {
+  common {
+    attribute {
+      key   = "test_run"
+      value = "tf_test_%[1]s"
+    }
+  }
+
+  log {
+    message = "tf_test deployment started"
+  }
+
+  log {
+    message = "tf_test deployment finished"
+
+    attribute {
+      key   = "duration"
+      value = "12.5"
+      type  = "float"
+    }
+  }
+}
+`, name)
+}
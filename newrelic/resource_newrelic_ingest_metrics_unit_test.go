@@ -0,0 +1,182 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandIngestMetrics(t *testing.T) {
+	t.Parallel()
+
+	now := time.UnixMilli(1700000000000)
+
+	d := schema.TestResourceDataRaw(t, resourceNewRelicIngestMetrics().Schema, map[string]interface{}{
+		"common": []interface{}{map[string]interface{}{
+			"interval_ms": 60000,
+			"attribute": []interface{}{
+				map[string]interface{}{"key": "host", "value": "web-1", "type": "string"},
+			},
+		}},
+		"metric": []interface{}{
+			map[string]interface{}{
+				"name":  "deploy.duration",
+				"value": 12.5,
+				"attribute": []interface{}{
+					map[string]interface{}{"key": "attempt", "value": "2", "type": "Int"},
+				},
+			},
+			map[string]interface{}{
+				"name":      "deploy.requests",
+				"type":      "summary",
+				"timestamp": 1690000000000,
+				"summary": []interface{}{
+					map[string]interface{}{"count": 4, "sum": 10, "min": 1, "max": 4},
+				},
+			},
+		},
+	})
+
+	common, metrics, err := expandIngestMetrics(d, now)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"interval.ms": 60000,
+		"attributes":  map[string]interface{}{"host": "web-1"},
+	}, common)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":       "deploy.duration",
+			"type":       "gauge",
+			"value":      12.5,
+			"timestamp":  now.UnixMilli(),
+			"attributes": map[string]interface{}{"attempt": 2},
+		},
+		map[string]interface{}{
+			"name":      "deploy.requests",
+			"type":      "summary",
+			"value":     map[string]interface{}{"count": 4.0, "sum": 10.0, "min": 1.0, "max": 4.0},
+			"timestamp": 1690000000000,
+		},
+	}, metrics)
+}
+
+func TestExpandIngestMetrics_InvalidAttribute(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceNewRelicIngestMetrics().Schema, map[string]interface{}{
+		"metric": []interface{}{
+			map[string]interface{}{
+				"name": "deploy.duration",
+				"attribute": []interface{}{
+					map[string]interface{}{"key": "attempt", "value": "second", "type": "int"},
+				},
+			},
+		},
+	})
+
+	_, _, err := expandIngestMetrics(d, time.Now())
+	require.EqualError(t, err, `unable to convert value "second" of attribute attempt to an int`)
+}
+
+func TestExpandIngestLogs(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceNewRelicIngestLogs().Schema, map[string]interface{}{
+		"common": []interface{}{map[string]interface{}{
+			"attribute": []interface{}{
+				map[string]interface{}{"key": "service", "value": "checkout", "type": "string"},
+			},
+		}},
+		"log": []interface{}{
+			map[string]interface{}{"message": "deployment started"},
+			map[string]interface{}{
+				"message":   "deployment finished",
+				"timestamp": 1690000000000,
+				"attribute": []interface{}{
+					map[string]interface{}{"key": "duration", "value": "12.5", "type": "float"},
+				},
+			},
+		},
+	})
+
+	common, logs, err := expandIngestLogs(d)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"attributes": map[string]interface{}{"service": "checkout"}}, common)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"message": "deployment started"},
+		map[string]interface{}{
+			"message":    "deployment finished",
+			"timestamp":  1690000000000,
+			"attributes": map[string]interface{}{"duration": 12.5},
+		},
+	}, logs)
+}
+
+func TestResourceNewRelicIngestMetricsDiff(t *testing.T) {
+	t.Parallel()
+
+	summary := []interface{}{map[string]interface{}{"count": 1, "sum": 1, "min": 1, "max": 1}}
+
+	cases := map[string]struct {
+		raw map[string]interface{}
+		err string
+	}{
+		"gauge": {
+			raw: map[string]interface{}{"metric": []interface{}{
+				map[string]interface{}{"name": "a", "value": 1},
+			}},
+		},
+		"count with interval": {
+			raw: map[string]interface{}{"metric": []interface{}{
+				map[string]interface{}{"name": "a", "type": "count", "value": 1, "interval_ms": 1000},
+			}},
+		},
+		"summary with common interval": {
+			raw: map[string]interface{}{
+				"common": []interface{}{map[string]interface{}{"interval_ms": 1000}},
+				"metric": []interface{}{
+					map[string]interface{}{"name": "a", "type": "summary", "summary": summary},
+				},
+			},
+		},
+		"count without interval": {
+			raw: map[string]interface{}{"metric": []interface{}{
+				map[string]interface{}{"name": "a", "value": 1},
+				map[string]interface{}{"name": "b", "type": "count", "value": 1},
+			}},
+			err: "metric 2: interval_ms is required for count metrics",
+		},
+		"summary without summary": {
+			raw: map[string]interface{}{"metric": []interface{}{
+				map[string]interface{}{"name": "a", "type": "summary", "interval_ms": 1000},
+			}},
+			err: "metric 1: summary is required for summary metrics",
+		},
+		"gauge with summary": {
+			raw: map[string]interface{}{"metric": []interface{}{
+				map[string]interface{}{"name": "a", "summary": summary},
+			}},
+			err: "metric 1: summary is only allowed for summary metrics",
+		},
+	}
+
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := resourceNewRelicIngestMetrics().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(c.raw), nil)
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package newrelic
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -18,6 +19,7 @@ func resourceNewRelicLogParsingRule() *schema.Resource {
 		ReadContext:   resourceNewRelicLogParsingRuleRead,
 		UpdateContext: resourceNewRelicLogParsingRuleUpdate,
 		DeleteContext: resourceNewRelicLogParsingRuleDelete,
+		CustomizeDiff: validateLogParsingRuleGrok,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -73,6 +75,28 @@ func resourceNewRelicLogParsingRule() *schema.Resource {
 	}
 }
 
+// Validate the Grok pattern compiles and captures each attribute once, with the offline Grok engine.
+// Patterns the offline engine does not support are left to New Relic to validate.
+func validateLogParsingRuleGrok(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("grok") {
+		return nil
+	}
+
+	pattern := d.Get("grok").(string)
+	_, err := compileGrok(pattern)
+
+	var unsupportedErr *grokUnsupportedError
+	if errors.As(err, &unsupportedErr) {
+		log.Printf("[WARN] Skipping the validation of the Grok pattern %q: %s", pattern, err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("invalid Grok pattern %q: %w", pattern, err)
+	}
+
+	return nil
+}
+
 // Create the obfuscation expression
 func resourceNewRelicLogParsingRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConfig := meta.(*ProviderConfig)
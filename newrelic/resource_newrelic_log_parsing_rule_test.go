@@ -174,6 +174,25 @@ func TestAccNewRelicLogParsingRule_Invalid_Grok_Test(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicLogParsingRule_Grok_Validated_At_Plan(t *testing.T) {
+	rName := generateNameForIntegrationTestResource()
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicLogParsingRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNewRelicLogParsingRuleGrokConfig(rName, "%%%%{IP:host} %%%%{WORD:host}"),
+				ExpectError: regexp.MustCompile("captured more than once"),
+			},
+			{
+				Config:      testAccNewRelicLogParsingRuleGrokConfig(rName, "%%%%{NUMBER:test:integer}"),
+				ExpectError: regexp.MustCompile("invalid attribute type"),
+			},
+		},
+	})
+}
+
 func testAccCheckNewRelicLogParsingRuleDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderConfig).NewClient
 	for _, rs := range s.RootModule().Resources {
@@ -384,3 +403,17 @@ resource "newrelic_log_parsing_rule" "foo"{
 }
 `, testAccountID, name, testAccExpectedApplicationName)
 }
+
+func testAccNewRelicLogParsingRuleGrokConfig(name string, grok string) string {
+	return fmt.Sprintf(`
+resource "newrelic_log_parsing_rule" "foo"{
+	account_id  = %[1]d
+	name        = "%[2]s"
+	attribute   = "%[3]s"
+	enabled     = true
+	grok        = "%[4]s"
+	lucene      = "logtype:linux_messages"
+	nrql        = "SELECT * FROM Log WHERE logtype = 'linux_messages'"
+}
+`, testAccountID, name, testAccExpectedApplicationName, grok)
+}
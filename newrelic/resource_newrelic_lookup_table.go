@@ -0,0 +1,252 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/errors"
+)
+
+func resourceNewRelicLookupTable() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicLookupTableCreate,
+		ReadContext:   resourceNewRelicLookupTableRead,
+		UpdateContext: resourceNewRelicLookupTableUpdate,
+		DeleteContext: resourceNewRelicLookupTableDelete,
+		CustomizeDiff: resourceNewRelicLookupTableDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the New Relic account the lookup table is uploaded to.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_-]+$`), "must only contain letters, numbers, underscores and hyphens"),
+				Description:  "The name of the lookup table, as used in the NRQL lookup() function.",
+			},
+			"csv": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"csv", "row"},
+				Description:  "The content of the lookup table as CSV, with a header row naming the columns.",
+			},
+			"column": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				RequiredWith: []string{"row"},
+				Description:  "The columns of the lookup table. Required with row, and optional with csv to check its header and values.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the column.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "string",
+							ValidateFunc: validation.StringInSlice(lookupTableColumnTypes, false),
+							Description:  "The type of the values of the column, checked before upload. One of string, int, float or boolean.",
+						},
+					},
+				},
+			},
+			"row": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ExactlyOneOf: []string{"csv", "row"},
+				Description:  "The rows of the lookup table, as an alternative to csv.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"values": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The values of the row, in the order of the columns.",
+						},
+					},
+				},
+			},
+			"content_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 hash of the content of the lookup table stored in New Relic, as recorded in its description when uploaded.",
+			},
+		},
+	}
+}
+
+// The table is validated at plan time, and its hash compared with the one of the stored table to detect drift.
+func resourceNewRelicLookupTableDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, k := range []string{"csv", "column", "row"} {
+		if !d.NewValueKnown(k) {
+			return d.SetNewComputed("content_hash")
+		}
+	}
+
+	records, columns, err := expandLookupTable(d)
+	if err != nil {
+		return err
+	}
+	if err := validateLookupTable(records, columns); err != nil {
+		return err
+	}
+
+	if hash := lookupTableContentHash(records); hash != d.Get("content_hash").(string) {
+		return d.SetNew("content_hash", hash)
+	}
+
+	return nil
+}
+
+func resourceNewRelicLookupTableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	accountID := selectAccountID(providerConfig, d)
+	name := d.Get("name").(string)
+
+	client, err := newLookupTableClient(providerConfig)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	records, _, err := expandLookupTable(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating New Relic lookup table %s", name)
+
+	if err := client.upload(ctx, accountID, name, encodeLookupTable(records), lookupTableContentHash(records), false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", accountID, name))
+
+	return resourceNewRelicLookupTableRead(ctx, d, meta)
+}
+
+func resourceNewRelicLookupTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	accountID, name, err := parseLookupTableID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := newLookupTableClient(providerConfig)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Reading New Relic lookup table %s", name)
+
+	// Only the metadata of the table is read, except on import when there is no
+	// hash in state yet.
+	var contentHash string
+	if d.Get("content_hash").(string) != "" {
+		metadata, err := client.metadata(ctx, accountID, name)
+		if err != nil {
+			if _, ok := err.(*errors.NotFound); ok {
+				d.SetId("")
+				return nil
+			}
+
+			return diag.FromErr(err)
+		}
+
+		// Tables uploaded outside of Terraform have no hash, and show as a diff.
+		contentHash = metadata.contentHash()
+	} else {
+		content, err := client.download(ctx, accountID, name)
+		if err != nil {
+			if _, ok := err.(*errors.NotFound); ok {
+				d.SetId("")
+				return nil
+			}
+
+			return diag.FromErr(err)
+		}
+
+		records, err := parseLookupTableCSV(content)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		contentHash = lookupTableContentHash(records)
+	}
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("content_hash", contentHash))
+}
+
+func resourceNewRelicLookupTableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	accountID, name, err := parseLookupTableID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := newLookupTableClient(providerConfig)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	records, _, err := expandLookupTable(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Replacing New Relic lookup table %s", name)
+
+	if err := client.upload(ctx, accountID, name, encodeLookupTable(records), lookupTableContentHash(records), true); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicLookupTableRead(ctx, d, meta)
+}
+
+func resourceNewRelicLookupTableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	accountID, name, err := parseLookupTableID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := newLookupTableClient(providerConfig)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Deleting New Relic lookup table %s", name)
+
+	if err := client.delete(ctx, accountID, name); err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
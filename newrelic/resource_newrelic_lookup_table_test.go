@@ -0,0 +1,174 @@
+//go:build integration || EVENTS
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/errors"
+)
+
+func TestAccNewRelicLookupTable_Basic(t *testing.T) {
+	resourceName := "newrelic_lookup_table.foo"
+	rName := fmt.Sprintf("tf_test_%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicLookupTableDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicLookupTableCSVConfig(rName, "checkout,payments,100"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicLookupTableExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "content_hash"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicLookupTableCSVConfig(rName, "checkout,payments,200"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicLookupTableExists(resourceName),
+				),
+			},
+			// Test: Update from rows
+			{
+				Config: testAccNewRelicLookupTableRowsConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicLookupTableExists(resourceName),
+				),
+			},
+			// Test: Import
+			{
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"column", "row"},
+				ResourceName:            resourceName,
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicLookupTableExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no lookup table ID is set")
+		}
+
+		accountID, name, err := parseLookupTableID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client, err := newLookupTableClient(testAccProvider.Meta().(*ProviderConfig))
+		if err != nil {
+			return err
+		}
+
+		content, err := client.download(context.Background(), accountID, name)
+		if err != nil {
+			return err
+		}
+
+		records, err := parseLookupTableCSV(content)
+		if err != nil {
+			return err
+		}
+		if hash := lookupTableContentHash(records); hash != rs.Primary.Attributes["content_hash"] {
+			return fmt.Errorf("the lookup table content hash is %s, expected %s", hash, rs.Primary.Attributes["content_hash"])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicLookupTableDestroy(s *terraform.State) error {
+	providerConfig := testAccProvider.Meta().(*ProviderConfig)
+
+	client, err := newLookupTableClient(providerConfig)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_lookup_table" {
+			continue
+		}
+
+		accountID, name, err := parseLookupTableID(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.download(context.Background(), accountID, name)
+		if err == nil {
+			return fmt.Errorf("lookup table still exists: %s", r.Primary.ID)
+		}
+		if _, ok := err.(*errors.NotFound); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccNewRelicLookupTableCSVConfig(name string, row string) string {
+	return fmt.Sprintf(`
+resource "newrelic_lookup_table" "foo" {
+	account_id = %[1]d
+	name       = "%[2]s"
+	csv        = <<-EOT
+		service,team,cost_center
+		%[3]s
+	EOT
+
+	column {
+		name = "service"
+	}
+	column {
+		name = "team"
+	}
+	column {
+		name = "cost_center"
+		type = "int"
+	}
+}
+`, testAccountID, name, row)
+}
+
+func testAccNewRelicLookupTableRowsConfig(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_lookup_table" "foo" {
+	account_id = %[1]d
+	name       = "%[2]s"
+
+	column {
+		name = "service"
+	}
+	column {
+		name = "team"
+	}
+	column {
+		name = "cost_center"
+		type = "int"
+	}
+
+	row {
+		values = ["checkout", "payments", "300"]
+	}
+	row {
+		values = ["cart", "shop", "400"]
+	}
+}
+`, testAccountID, name)
+}
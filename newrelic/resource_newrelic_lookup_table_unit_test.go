@@ -0,0 +1,343 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandLookupTable(t *testing.T) {
+	t.Parallel()
+
+	fromCSV := schema.TestResourceDataRaw(t, resourceNewRelicLookupTable().Schema, map[string]interface{}{
+		"name": "owners",
+		"csv":  "service,team,cost_center\ncheckout,payments,100\n\"cart\",shop,200\n",
+	})
+	fromRows := schema.TestResourceDataRaw(t, resourceNewRelicLookupTable().Schema, map[string]interface{}{
+		"name": "owners",
+		"column": []interface{}{
+			map[string]interface{}{"name": "service"},
+			map[string]interface{}{"name": "team"},
+			map[string]interface{}{"name": "cost_center", "type": "int"},
+		},
+		"row": []interface{}{
+			map[string]interface{}{"values": []interface{}{"checkout", "payments", "100"}},
+			map[string]interface{}{"values": []interface{}{"cart", "shop", "200"}},
+		},
+	})
+
+	csvRecords, csvColumns, err := expandLookupTable(fromCSV)
+	require.NoError(t, err)
+	require.Empty(t, csvColumns)
+
+	rowRecords, rowColumns, err := expandLookupTable(fromRows)
+	require.NoError(t, err)
+	require.Equal(t, []lookupTableColumn{{"service", "string"}, {"team", "string"}, {"cost_center", "int"}}, rowColumns)
+
+	require.Equal(t, [][]string{
+		{"service", "team", "cost_center"},
+		{"checkout", "payments", "100"},
+		{"cart", "shop", "200"},
+	}, rowRecords)
+	require.Equal(t, rowRecords, csvRecords)
+	require.Equal(t, lookupTableContentHash(csvRecords), lookupTableContentHash(rowRecords))
+}
+
+func TestValidateLookupTable(t *testing.T) {
+	t.Parallel()
+
+	columns := []lookupTableColumn{{"service", "string"}, {"cost_center", "int"}, {"ratio", "float"}, {"critical", "boolean"}}
+
+	cases := map[string]struct {
+		csv     string
+		columns []lookupTableColumn
+		err     string
+	}{
+		"valid": {
+			csv:     "service,cost_center,ratio,critical\ncheckout,100,0.5,true\ncart,,,\n",
+			columns: columns,
+		},
+		"undeclared types": {
+			csv: "service,cost_center\ncheckout,not a number\n",
+		},
+		"duplicate header": {
+			csv: "service,team,service\ncheckout,payments,cart\n",
+			err: `column "service" is defined more than once`,
+		},
+		"empty header": {
+			csv: "service,,team\ncheckout,payments,cart\n",
+			err: "column names must not be empty",
+		},
+		"short row": {
+			csv: "service,team\ncheckout,payments\ncart\n",
+			err: "row 2 has 1 values, but the table has 2 columns",
+		},
+		"declared columns count": {
+			csv:     "service,cost_center\ncheckout,100\n",
+			columns: columns,
+			err:     "the table has 2 columns, but 4 are declared",
+		},
+		"declared column name": {
+			csv:     "service,cost_centre,ratio,critical\ncheckout,100,0.5,true\n",
+			columns: columns,
+			err:     `column 2 is "cost_centre", but "cost_center" is declared`,
+		},
+		"invalid int": {
+			csv:     "service,cost_center,ratio,critical\ncheckout,1.5,0.5,true\n",
+			columns: columns,
+			err:     `row 1, column "cost_center": "1.5" is not a valid int`,
+		},
+		"invalid boolean": {
+			csv:     "service,cost_center,ratio,critical\ncheckout,1,0.5,yes\n",
+			columns: columns,
+			err:     `row 1, column "critical": "yes" is not a valid boolean`,
+		},
+		"too large": {
+			csv: "value\n" + strings.Repeat(strings.Repeat("x", 1023)+"\n", 4*1024),
+			err: "the table is 4194310 bytes, larger than the limit of 4194304 bytes",
+		},
+	}
+
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			records, err := parseLookupTableCSV(c.csv)
+			require.NoError(t, err)
+
+			err = validateLookupTable(records, c.columns)
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.err)
+			}
+		})
+	}
+}
+
+func TestResourceNewRelicLookupTableDiff(t *testing.T) {
+	t.Parallel()
+
+	r := resourceNewRelicLookupTable()
+	content := "service,team\ncheckout,payments\n"
+	records, err := parseLookupTableCSV(content)
+	require.NoError(t, err)
+	hash := lookupTableContentHash(records)
+
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "owners",
+		"csv":  content,
+	}), nil)
+	require.NoError(t, err)
+	require.Equal(t, hash, diff.Attributes["content_hash"].New)
+
+	state := &terraform.InstanceState{
+		ID: "1:owners",
+		Attributes: map[string]string{
+			"id":           "1:owners",
+			"account_id":   "1",
+			"name":         "owners",
+			"csv":          content,
+			"content_hash": hash,
+		},
+	}
+
+	// The stored table matches the configuration
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "owners",
+		"csv":  content,
+	}), nil)
+	require.NoError(t, err)
+	require.Nil(t, diff)
+
+	// The stored table was changed outside of Terraform
+	state.Attributes["content_hash"] = "changed"
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "owners",
+		"csv":  content,
+	}), nil)
+	require.NoError(t, err)
+	require.Equal(t, hash, diff.Attributes["content_hash"].New)
+	require.False(t, diff.RequiresNew())
+
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "owners",
+		"csv":  "service,service\ncheckout,cart\n",
+	}), nil)
+	require.EqualError(t, err, `column "service" is defined more than once`)
+}
+
+// testLookupTableServer stores the uploaded tables by path, and counts the downloads of their content.
+func testLookupTableServer(t *testing.T, tables map[string]testLookupTable, downloads *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Api-Key") != "NRAK-TEST" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost, http.MethodPut:
+			file, _, err := r.FormFile("table")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content, _ := io.ReadAll(file)
+			if _, ok := tables[r.URL.Path]; ok == (r.Method == http.MethodPost) {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte("conflict"))
+				return
+			}
+			tables[r.URL.Path] = testLookupTable{content: string(content), description: r.FormValue("description")}
+		case http.MethodGet:
+			table, ok := tables[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Header.Get("Accept") == "application/json" {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"table": map[string]interface{}{"name": path.Base(r.URL.Path), "description": table.description},
+				})
+				return
+			}
+			*downloads++
+			_, _ = w.Write([]byte(table.content))
+		case http.MethodDelete:
+			delete(tables, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+type testLookupTable struct {
+	content     string
+	description string
+}
+
+func TestLookupTableClient(t *testing.T) {
+	t.Parallel()
+
+	tables := map[string]testLookupTable{}
+	downloads := 0
+	server := testLookupTableServer(t, tables, &downloads)
+
+	ctx := context.Background()
+	client := &lookupTableClient{baseURL: server.URL + "/v1", apiKey: "NRAK-TEST", client: server.Client()}
+
+	require.NoError(t, client.upload(ctx, 1, "owners", "service\ncheckout\n", "hash1", false))
+	require.Equal(t, map[string]testLookupTable{
+		"/v1/accounts/1/owners": {content: "service\ncheckout\n", description: "content_hash=hash1"},
+	}, tables)
+
+	err := client.upload(ctx, 1, "owners", "service\ncart\n", "hash2", false)
+	require.EqualError(t, err, "409 response returned: conflict")
+
+	require.NoError(t, client.upload(ctx, 1, "owners", "service\ncart\n", "hash2", true))
+
+	metadata, err := client.metadata(ctx, 1, "owners")
+	require.NoError(t, err)
+	require.Equal(t, "owners", metadata.Name)
+	require.Equal(t, "hash2", metadata.contentHash())
+
+	content, err := client.download(ctx, 1, "owners")
+	require.NoError(t, err)
+	require.Equal(t, "service\ncart\n", content)
+
+	require.NoError(t, client.delete(ctx, 1, "owners"))
+
+	_, err = client.download(ctx, 1, "owners")
+	require.IsType(t, &errors.NotFound{}, err)
+
+	_, err = client.metadata(ctx, 1, "owners")
+	require.IsType(t, &errors.NotFound{}, err)
+
+	unauthorized := &lookupTableClient{baseURL: server.URL + "/v1", apiKey: "NRAK-OTHER", client: server.Client()}
+	_, err = unauthorized.download(ctx, 1, "owners")
+	require.IsType(t, &errors.UnauthorizedError{}, err)
+}
+
+func TestLookupTableMetadata_ContentHash(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "abc", (&lookupTableMetadata{Description: "content_hash=abc"}).contentHash())
+	// Uploaded outside of Terraform
+	require.Empty(t, (&lookupTableMetadata{Description: "Service owners"}).contentHash())
+	require.Empty(t, (&lookupTableMetadata{}).contentHash())
+}
+
+// Not parallel, since the endpoints of the regions are shared.
+func TestResourceNewRelicLookupTableRead(t *testing.T) {
+	records, err := parseLookupTableCSV("service,team\ncheckout,payments\n")
+	require.NoError(t, err)
+	content := encodeLookupTable(records)
+	hash := lookupTableContentHash(records)
+
+	tables := map[string]testLookupTable{
+		"/v1/accounts/1/owners": {content: content, description: "content_hash=" + hash},
+		"/v1/accounts/1/manual": {content: content, description: "Uploaded in the UI"},
+	}
+	downloads := 0
+	server := testLookupTableServer(t, tables, &downloads)
+
+	// The staging endpoint is pointed at the test server.
+	previous := lookupTableBaseURLs["staging"]
+	t.Cleanup(func() { lookupTableBaseURLs["staging"] = previous })
+	lookupTableBaseURLs["staging"] = server.URL + "/v1"
+	meta := &ProviderConfig{Region: "Staging", PersonalAPIKey: "NRAK-TEST", httpClient: server.Client()}
+
+	read := func(id string, contentHash string) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceNewRelicLookupTable().Schema, map[string]interface{}{})
+		d.SetId(id)
+		_ = d.Set("content_hash", contentHash)
+
+		require.False(t, resourceNewRelicLookupTableRead(context.Background(), d, meta).HasError())
+		return d
+	}
+
+	// Only the metadata is read
+	d := read("1:owners", hash)
+	require.Equal(t, hash, d.Get("content_hash"))
+	require.Equal(t, 0, downloads)
+
+	// Replaced outside of Terraform
+	d = read("1:manual", hash)
+	require.Empty(t, d.Get("content_hash"))
+	require.Equal(t, 0, downloads)
+
+	// Import
+	d = read("1:manual", "")
+	require.Equal(t, hash, d.Get("content_hash"))
+	require.Equal(t, "manual", d.Get("name"))
+	require.Equal(t, 1, downloads)
+
+	// Deleted outside of Terraform
+	d = read("1:deleted", hash)
+	require.Empty(t, d.Id())
+}
+
+func TestNewLookupTableClient(t *testing.T) {
+	t.Parallel()
+
+	client, err := newLookupTableClient(&ProviderConfig{Region: "EU", PersonalAPIKey: "NRAK-TEST"})
+	require.NoError(t, err)
+	require.Equal(t, "https://nrql-lookup.service.eu.newrelic.com/v1/accounts/1/owners", client.tableURL(1, "owners"))
+
+	_, err = newLookupTableClient(&ProviderConfig{Region: "Local"})
+	require.EqualError(t, err, "lookup tables are not supported in the Local region")
+}
@@ -0,0 +1,455 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/errors"
+)
+
+func resourceNewRelicNrqlAlertConditionSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicNrqlAlertConditionSetCreate,
+		ReadContext:   resourceNewRelicNrqlAlertConditionSetRead,
+		UpdateContext: resourceNewRelicNrqlAlertConditionSetUpdate,
+		DeleteContext: resourceNewRelicNrqlAlertConditionSetDelete,
+		CustomizeDiff: resourceNewRelicNrqlAlertConditionSetCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNewRelicNrqlAlertConditionSetImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID for managing your NRQL alert conditions.",
+			},
+			"policy_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the policy where the conditions should be used.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "static",
+				Description:  "The type of the NRQL alert conditions to create. Valid values are: 'static', 'baseline', 'outlier'.",
+				ValidateFunc: validation.StringInSlice([]string{"static", "baseline", "outlier"}, false),
+			},
+			"max_concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      nrqlAlertConditionSetDefaultConcurrency,
+				Description:  "The maximum number of conditions created, updated or deleted at the same time.",
+				ValidateFunc: validation.IntBetween(1, 16),
+			},
+			"template": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    1,
+				Description: "The condition every instance is rendered from. Supports the arguments of newrelic_nrql_alert_condition; `{{name}}` placeholders in string values are replaced with the substitutions of each instance.",
+				Elem: &schema.Resource{
+					Schema: embeddedNrqlAlertConditionSchema(),
+				},
+			},
+			"instance": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The conditions of the set, one per instance.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "A unique key for the instance, available in the template as `{{key}}`.",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"substitutions": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "The values of the template placeholders for this instance.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"critical_threshold": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Overrides the threshold of the template's critical term for this instance.",
+							ValidateFunc: validateNrqlAlertConditionSetThreshold,
+						},
+						"warning_threshold": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Overrides the threshold of the template's warning term for this instance.",
+							ValidateFunc: validateNrqlAlertConditionSetThreshold,
+						},
+					},
+				},
+			},
+			"condition_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The IDs of the NRQL alert conditions, keyed by instance key.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"entity_guids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The entity GUIDs of the NRQL alert conditions, keyed by instance key.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"conditions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The main attributes of the NRQL alert conditions as read from New Relic, sorted by instance key. Changes made outside of Terraform show as a diff of this attribute.",
+				Elem: &schema.Resource{
+					Schema: nrqlAlertConditionSetConditionSchema(),
+				},
+			},
+		},
+	}
+}
+
+func validateNrqlAlertConditionSetThreshold(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := strconv.ParseFloat(val.(string), 64); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a number, got %q", key, val))
+	}
+
+	return
+}
+
+// Renders every instance at plan time, so template errors are reported before
+// any condition is changed, and plans an update when conditions of the set no
+// longer exist or no longer match the template.
+func resourceNewRelicNrqlAlertConditionSetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfiguration := d.GetRawConfig()
+	if rawConfiguration.IsNull() {
+		return nil
+	}
+
+	if !rawConfiguration.GetAttr("template").IsWhollyKnown() || !rawConfiguration.GetAttr("instance").IsWhollyKnown() {
+		return nil
+	}
+
+	conditionType := d.Get("type").(string)
+	if conditionType != "baseline" {
+		if template := d.Get("template").([]interface{}); len(template) > 0 && template[0] != nil {
+			if template[0].(map[string]interface{})["signal_seasonality"].(string) != "" {
+				return fmt.Errorf("'signal_seasonality' is only valid on baseline conditions. Please remove this field or change the condition type")
+			}
+		}
+	}
+
+	members, err := expandNrqlAlertConditionSetMembers(conditionType, d.Get("template").([]interface{}), d.Get("instance").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	// The conditions are planned as rendered, so changes made outside of
+	// Terraform show as a diff with the conditions read from New Relic.
+	conditions := flattenNrqlAlertConditionSetMembers(members)
+	if !reflect.DeepEqual(d.Get("conditions").([]interface{}), conditions) {
+		if err := d.SetNew("conditions", conditions); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	conditionIDs := expandEmbeddedNrqlAlertConditionIDs(d.Get("condition_ids"))
+	for key := range members {
+		if conditionIDs[key] == "" {
+			log.Printf("[INFO] Condition %q of NRQL alert condition set %s no longer exists and will be created", key, d.Id())
+
+			if err := d.SetNewComputed("condition_ids"); err != nil {
+				return err
+			}
+			return d.SetNewComputed("entity_guids")
+		}
+	}
+
+	if d.HasChange("instance") || d.HasChange("template") {
+		if err := d.SetNewComputed("condition_ids"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("entity_guids")
+	}
+
+	return nil
+}
+
+func resourceNewRelicNrqlAlertConditionSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	policyID := d.Get("policy_id").(int)
+	conditionType := d.Get("type").(string)
+
+	members, err := expandNrqlAlertConditionSetMembers(conditionType, d.Get("template").([]interface{}), d.Get("instance").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	toCreate, _, _ := diffEmbeddedNrqlAlertConditions(nil, members, nil)
+
+	log.Printf("[INFO] Creating %d New Relic NRQL alert conditions in policy %d", len(toCreate), policyID)
+
+	created, err := applyNrqlAlertConditionSetOperations(ctx, toCreate, d.Get("max_concurrency").(int), func(ctx context.Context, key string) (*alerts.NrqlAlertCondition, error) {
+		return createNrqlAlertConditionOfType(ctx, client, accountID, strconv.Itoa(policyID), conditionType, *members[key].CreateInput)
+	})
+
+	conditionIDs := map[string]string{}
+	entityGUIDs := map[string]string{}
+	conditions := []interface{}{}
+	for key, condition := range created {
+		conditionIDs[key] = condition.ID
+		entityGUIDs[key] = string(condition.EntityGUID)
+		conditions = append(conditions, flattenNrqlAlertConditionSetCondition(key, condition))
+	}
+
+	if len(conditionIDs) == 0 {
+		return diag.FromErr(err)
+	}
+
+	// When some conditions fail to be created, the set is saved as tainted with
+	// the conditions that were created, so that they are deleted when the set
+	// is replaced by the next apply.
+	d.SetId(nrqlAlertConditionSetID(policyID, conditionIDs))
+	_ = d.Set("account_id", accountID)
+	_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(conditionIDs))
+	_ = d.Set("entity_guids", flattenEmbeddedNrqlAlertConditionIDs(entityGUIDs))
+	_ = d.Set("conditions", sortNrqlAlertConditionSetConditions(conditions))
+
+	return diag.FromErr(err)
+}
+
+// Imports a set from the IDs of its conditions by instance key, since the
+// conditions of a set are not grouped in New Relic.
+func resourceNewRelicNrqlAlertConditionSetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	policyID, conditionIDs, err := parseNrqlAlertConditionSetImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(nrqlAlertConditionSetID(policyID, conditionIDs))
+	_ = d.Set("policy_id", policyID)
+	_ = d.Set("max_concurrency", nrqlAlertConditionSetDefaultConcurrency)
+	_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(conditionIDs))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceNewRelicNrqlAlertConditionSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic NRQL alert condition set %s", d.Id())
+
+	_, err := client.Alerts.QueryPolicyWithContext(ctx, accountID, strconv.Itoa(d.Get("policy_id").(int)))
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	conditionIDs := expandEmbeddedNrqlAlertConditionIDs(d.Get("condition_ids"))
+	keys := make([]string, 0, len(conditionIDs))
+	for key := range conditionIDs {
+		keys = append(keys, key)
+	}
+
+	found, err := applyNrqlAlertConditionSetOperations(ctx, keys, d.Get("max_concurrency").(int), func(ctx context.Context, key string) (*alerts.NrqlAlertCondition, error) {
+		condition, err := client.Alerts.GetNrqlConditionQueryWithContext(ctx, accountID, conditionIDs[key])
+		if _, ok := err.(*errors.NotFound); ok {
+			return nil, nil
+		}
+		return condition, err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Conditions deleted outside of Terraform are dropped from state, and
+	// recreated by the next apply. The others are flattened into state, so the
+	// changes made to them outside of Terraform are planned to be reverted.
+	refreshedIDs := map[string]string{}
+	entityGUIDs := map[string]string{}
+	conditions := []interface{}{}
+	conditionType := ""
+	for key, condition := range found {
+		if condition == nil {
+			continue
+		}
+		refreshedIDs[key] = condition.ID
+		entityGUIDs[key] = string(condition.EntityGUID)
+		conditions = append(conditions, flattenNrqlAlertConditionSetCondition(key, condition))
+		conditionType = strings.ToLower(string(condition.Type))
+	}
+
+	// The type of imported sets is read from their conditions.
+	if d.Get("type").(string) == "" && conditionType != "" {
+		_ = d.Set("type", conditionType)
+	}
+
+	_ = d.Set("account_id", accountID)
+	_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(refreshedIDs))
+	_ = d.Set("entity_guids", flattenEmbeddedNrqlAlertConditionIDs(entityGUIDs))
+	_ = d.Set("conditions", sortNrqlAlertConditionSetConditions(conditions))
+
+	return nil
+}
+
+func resourceNewRelicNrqlAlertConditionSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	policyID := d.Get("policy_id").(int)
+	conditionType := d.Get("type").(string)
+	concurrency := d.Get("max_concurrency").(int)
+
+	members, err := expandNrqlAlertConditionSetMembers(conditionType, d.Get("template").([]interface{}), d.Get("instance").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// When the previous configuration can't be rendered, every condition is updated.
+	oldTemplate, _ := d.GetChange("template")
+	oldInstances, _ := d.GetChange("instance")
+	oldMembers, err := expandNrqlAlertConditionSetMembers(conditionType, oldTemplate.([]interface{}), oldInstances.(*schema.Set).List())
+	if err != nil {
+		oldMembers = nil
+	}
+
+	conditionIDs := expandEmbeddedNrqlAlertConditionIDs(d.Get("condition_ids"))
+	entityGUIDs := expandEmbeddedNrqlAlertConditionIDs(d.Get("entity_guids"))
+	toCreate, toUpdate, toDelete := diffEmbeddedNrqlAlertConditions(oldMembers, members, conditionIDs)
+
+	// Conditions changed outside of Terraform are updated even when their
+	// template and instance did not change.
+	oldConditions, _ := d.GetChange("conditions")
+	for _, key := range driftedNrqlAlertConditionSetMembers(oldConditions.([]interface{}), members) {
+		if conditionIDs[key] != "" && !stringInSlice(toUpdate, key) {
+			toUpdate = append(toUpdate, key)
+		}
+	}
+	sort.Strings(toUpdate)
+
+	conditions := map[string]interface{}{}
+	for _, c := range oldConditions.([]interface{}) {
+		conditions[c.(map[string]interface{})["key"].(string)] = c
+	}
+	setConditions := func() {
+		out := make([]interface{}, 0, len(conditions))
+		for _, c := range conditions {
+			out = append(out, c)
+		}
+		_ = d.Set("conditions", sortNrqlAlertConditionSetConditions(out))
+	}
+
+	log.Printf("[INFO] Updating New Relic NRQL alert condition set %s: %d to create, %d to update, %d to delete", d.Id(), len(toCreate), len(toUpdate), len(toDelete))
+
+	// On failure the previous configuration is kept in state, so the changes
+	// that were not applied are planned again.
+	fail := func(err error) diag.Diagnostics {
+		d.Partial(true)
+		_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(conditionIDs))
+		_ = d.Set("entity_guids", flattenEmbeddedNrqlAlertConditionIDs(entityGUIDs))
+		setConditions()
+		return diag.FromErr(err)
+	}
+
+	// Deleting first frees the names of removed conditions.
+	deleted, err := applyNrqlAlertConditionSetOperations(ctx, toDelete, concurrency, func(ctx context.Context, key string) (*alerts.NrqlAlertCondition, error) {
+		_, err := client.Alerts.DeleteNrqlConditionMutationWithContext(ctx, accountID, conditionIDs[key])
+		return nil, err
+	})
+	for key := range deleted {
+		delete(conditionIDs, key)
+		delete(entityGUIDs, key)
+		delete(conditions, key)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	updated, err := applyNrqlAlertConditionSetOperations(ctx, toUpdate, concurrency, func(ctx context.Context, key string) (*alerts.NrqlAlertCondition, error) {
+		return updateNrqlAlertConditionOfType(ctx, client, accountID, conditionIDs[key], conditionType, *members[key].UpdateInput)
+	})
+	for key, condition := range updated {
+		if condition != nil {
+			entityGUIDs[key] = string(condition.EntityGUID)
+			conditions[key] = flattenNrqlAlertConditionSetCondition(key, condition)
+		}
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	created, err := applyNrqlAlertConditionSetOperations(ctx, toCreate, concurrency, func(ctx context.Context, key string) (*alerts.NrqlAlertCondition, error) {
+		return createNrqlAlertConditionOfType(ctx, client, accountID, strconv.Itoa(policyID), conditionType, *members[key].CreateInput)
+	})
+	for key, condition := range created {
+		conditionIDs[key] = condition.ID
+		entityGUIDs[key] = string(condition.EntityGUID)
+		conditions[key] = flattenNrqlAlertConditionSetCondition(key, condition)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(conditionIDs))
+	_ = d.Set("entity_guids", flattenEmbeddedNrqlAlertConditionIDs(entityGUIDs))
+	setConditions()
+
+	return nil
+}
+
+func resourceNewRelicNrqlAlertConditionSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	conditionIDs := expandEmbeddedNrqlAlertConditionIDs(d.Get("condition_ids"))
+	keys := make([]string, 0, len(conditionIDs))
+	for key := range conditionIDs {
+		keys = append(keys, key)
+	}
+
+	log.Printf("[INFO] Deleting %d New Relic NRQL alert conditions of set %s", len(keys), d.Id())
+
+	deleted, err := applyNrqlAlertConditionSetOperations(ctx, keys, d.Get("max_concurrency").(int), func(ctx context.Context, key string) (*alerts.NrqlAlertCondition, error) {
+		_, err := client.Alerts.DeleteNrqlConditionMutationWithContext(ctx, accountID, conditionIDs[key])
+		if _, ok := err.(*errors.NotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	})
+	if err != nil {
+		// Keep the conditions that could not be deleted in state.
+		for key := range deleted {
+			delete(conditionIDs, key)
+		}
+		_ = d.Set("condition_ids", flattenEmbeddedNrqlAlertConditionIDs(conditionIDs))
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
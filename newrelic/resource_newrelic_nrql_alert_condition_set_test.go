@@ -0,0 +1,214 @@
+//go:build integration || ALERTS
+
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+)
+
+func TestAccNewRelicNrqlAlertConditionSet_Basic(t *testing.T) {
+	resourceName := "newrelic_nrql_alert_condition_set.foo"
+	rName := acctest.RandString(5)
+	var accountID int
+	var checkoutID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEnvVars(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionSetDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicNrqlAlertConditionSetConfig(rName, []string{"checkout", "search"}, "5"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionSetExists(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "conditions.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.key", "checkout"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.enabled", "true"),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources[resourceName]
+						accountID, _ = strconv.Atoi(rs.Primary.Attributes["account_id"])
+						checkoutID = rs.Primary.Attributes["condition_ids.checkout"]
+						return nil
+					},
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccNewRelicNrqlAlertConditionSetImportID(resourceName),
+				ImportStateVerifyIgnore: []string{"template", "instance"},
+			},
+			// Test: A condition disabled outside of Terraform is planned to be enabled again
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*ProviderConfig).NewClient
+					condition, err := client.Alerts.GetNrqlConditionQuery(accountID, checkoutID)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if _, err := client.Alerts.UpdateNrqlConditionStaticMutation(accountID, checkoutID, alerts.NrqlConditionUpdateInput{
+						NrqlConditionUpdateBase: alerts.NrqlConditionUpdateBase{
+							Enabled: false,
+							Name:    condition.Name,
+							Nrql:    alerts.NrqlConditionUpdateQuery{Query: condition.Nrql.Query},
+							Terms:   condition.Terms,
+						},
+					}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccNewRelicNrqlAlertConditionSetConfig(rName, []string{"checkout", "search"}, "5"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccNewRelicNrqlAlertConditionSetConfig(rName, []string{"checkout", "search"}, "5"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.enabled", "true"),
+				),
+			},
+			// Test: Update one instance, add one and remove one
+			{
+				Config: testAccNewRelicNrqlAlertConditionSetConfig(rName, []string{"checkout", "cart"}, "10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionSetExists(resourceName, 2),
+					resource.TestCheckResourceAttrSet(resourceName, "condition_ids.cart"),
+					resource.TestCheckNoResourceAttr(resourceName, "condition_ids.search"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicNrqlAlertConditionSetDestroy(s *terraform.State) error {
+	providerConfig := testAccProvider.Meta().(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_nrql_alert_condition_set" {
+			continue
+		}
+
+		accountID, err := strconv.Atoi(r.Primary.Attributes["account_id"])
+		if err != nil {
+			return err
+		}
+
+		for k, conditionID := range r.Primary.Attributes {
+			if !strings.HasPrefix(k, "condition_ids.") || k == "condition_ids.%" {
+				continue
+			}
+
+			if _, err := client.Alerts.GetNrqlConditionQuery(accountID, conditionID); err == nil {
+				return fmt.Errorf("NRQL alert condition %s still exists", conditionID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckNewRelicNrqlAlertConditionSetExists(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		providerConfig := testAccProvider.Meta().(*ProviderConfig)
+		client := providerConfig.NewClient
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no alert condition set ID is set")
+		}
+
+		if rs.Primary.Attributes["condition_ids.%"] != strconv.Itoa(count) {
+			return fmt.Errorf("expected %d conditions, found %s", count, rs.Primary.Attributes["condition_ids.%"])
+		}
+
+		accountID, err := strconv.Atoi(rs.Primary.Attributes["account_id"])
+		if err != nil {
+			return err
+		}
+
+		for k, conditionID := range rs.Primary.Attributes {
+			if !strings.HasPrefix(k, "condition_ids.") || k == "condition_ids.%" {
+				continue
+			}
+
+			if _, err := client.Alerts.GetNrqlConditionQuery(accountID, conditionID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicNrqlAlertConditionSetImportID(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", n)
+		}
+
+		var conditions []string
+		for k, conditionID := range rs.Primary.Attributes {
+			if !strings.HasPrefix(k, "condition_ids.") || k == "condition_ids.%" {
+				continue
+			}
+			conditions = append(conditions, fmt.Sprintf("%s=%s", strings.TrimPrefix(k, "condition_ids."), conditionID))
+		}
+
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["policy_id"], strings.Join(conditions, ",")), nil
+	}
+}
+
+func testAccNewRelicNrqlAlertConditionSetConfig(name string, apps []string, threshold string) string {
+	var instances strings.Builder
+	for _, app := range apps {
+		fmt.Fprintf(&instances, `
+  instance {
+    key = "%[1]s"
+    substitutions = {
+      appName = "tf-test-%[1]s"
+    }
+  }
+`, app)
+	}
+
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_nrql_alert_condition_set" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  template {
+    name = "tf-test-%[1]s {{key}} errors"
+
+    nrql {
+      query = "SELECT count(*) FROM TransactionError WHERE appName = '{{appName}}'"
+    }
+
+    critical {
+      operator              = "above"
+      threshold             = %[2]s
+      threshold_duration    = 120
+      threshold_occurrences = "ALL"
+    }
+  }
+%[3]s}
+`, name, threshold, instances.String())
+}
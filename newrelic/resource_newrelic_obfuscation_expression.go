@@ -38,9 +38,10 @@ func resourceNewRelicObfuscationExpression() *schema.Resource {
 				Required:    true,
 			},
 			"regex": {
-				Type:        schema.TypeString,
-				Description: "Regex of expression.",
-				Required:    true,
+				Type:         schema.TypeString,
+				Description:  "Regex of expression.",
+				Required:     true,
+				ValidateFunc: validateObfuscationExpressionRegex,
 			},
 		},
 	}
@@ -62,6 +62,23 @@ func TestAccNewRelicObfuscationExpression_Validation(t *testing.T) {
 	})
 }
 
+// Must fail at plan time if the regex is not valid RE2
+func TestAccNewRelicObfuscationExpression_InvalidRegex(t *testing.T) {
+	rName := acctest.RandString(7)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicObfuscationExpressionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNewRelicObfuscationExpressionConfigWithRegex(rName, `(?<=ssn=)\\d+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("invalid RE2 regular expression"),
+			},
+		},
+	})
+}
+
 func testAccCheckNewRelicObfuscationExpressionDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderConfig).NewClient
 	for _, rs := range s.RootModule().Resources {
@@ -89,6 +106,16 @@ resource "newrelic_obfuscation_expression" "foo"{
 `, testAccountID, name, testAccExpectedApplicationName)
 }
 
+func testAccNewRelicObfuscationExpressionConfigWithRegex(name string, regex string) string {
+	return fmt.Sprintf(`
+resource "newrelic_obfuscation_expression" "foo"{
+	account_id = %[1]d
+	name = "%[2]s"
+	regex = "%[3]s"
+}
+`, testAccountID, name, regex)
+}
+
 func testAccCheckNewRelicObfuscationExpressionExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
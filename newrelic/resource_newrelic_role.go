@@ -0,0 +1,186 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/customeradministration"
+)
+
+func resourceNewRelicRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicRoleCreate,
+		ReadContext:   resourceNewRelicRoleRead,
+		UpdateContext: resourceNewRelicRoleUpdate,
+		DeleteContext: resourceNewRelicRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the custom role.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"permission_ids": {
+				Type:        schema.TypeSet,
+				Description: "The IDs of the permissions granted by the custom role.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d+$`), "must be a numeric permission ID"),
+				},
+			},
+			"organization_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the organization the custom role belongs to. Defaults to the organization of the API key.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"scope": {
+				Type:        schema.TypeString,
+				Description: "The scope of the custom role.",
+				Computed:    true,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Description: "The type of the role, always CUSTOM.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceNewRelicRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	permissionIDs, err := expandRolePermissionIDs(d.Get("permission_ids").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	organizationID := d.Get("organization_id").(string)
+	if organizationID == "" {
+		organization, err := client.Organization.GetOrganizationWithContext(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		organizationID = organization.ID
+	}
+
+	name := d.Get("name").(string)
+
+	log.Printf("[INFO] Creating New Relic custom role %s", name)
+
+	roleID, err := createCustomRole(ctx, client, organizationID, name, permissionIDs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(roleID))
+	_ = d.Set("organization_id", organizationID)
+
+	return resourceNewRelicRoleRead(ctx, d, meta)
+}
+
+func resourceNewRelicRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	log.Printf("[INFO] Reading New Relic role %s", d.Id())
+
+	roleID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("invalid role ID %q: must be numeric", d.Id())
+	}
+
+	role, err := getRole(ctx, client, roleID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if role == nil {
+		log.Printf("[WARN] Role %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if !strings.EqualFold(role.Type, string(customeradministration.MultiTenantAuthorizationRoleTypeEnumTypes.CUSTOM)) {
+		return diag.Errorf("role %s is a %s role, only custom roles can be managed", d.Id(), role.Type)
+	}
+
+	permissionIDs, err := getRolePermissionIDs(ctx, client, roleID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("organization_id").(string) == "" {
+		organization, err := client.Organization.GetOrganizationWithContext(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = d.Set("organization_id", organization.ID)
+	}
+
+	_ = d.Set("name", role.Name)
+	_ = d.Set("scope", strings.ToUpper(role.Scope))
+	_ = d.Set("type", strings.ToUpper(role.Type))
+
+	if err := d.Set("permission_ids", permissionIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceNewRelicRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	roleID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("invalid role ID %q: must be numeric", d.Id())
+	}
+
+	permissionIDs, err := expandRolePermissionIDs(d.Get("permission_ids").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Updating New Relic custom role %s", d.Id())
+
+	if err := updateCustomRole(ctx, client, roleID, d.Get("name").(string), permissionIDs); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating custom role %s: %w", d.Id(), err))
+	}
+
+	return resourceNewRelicRoleRead(ctx, d, meta)
+}
+
+func resourceNewRelicRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	roleID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("invalid role ID %q: must be numeric", d.Id())
+	}
+
+	log.Printf("[INFO] Deleting New Relic custom role %s", d.Id())
+
+	if err := deleteCustomRole(ctx, client, roleID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+//go:build integration || AUTH
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/testhelpers"
+)
+
+var roleResourceName string = "newrelic_role.foo"
+
+func TestAccNewRelicRole_Basic(t *testing.T) {
+	name := fmt.Sprintf("terraform-provider-newrelic-integration-test-role-%s", testhelpers.RandSeq(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEnvVars(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccNewRelicCheckRoleDestroy,
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccNewRelicRoleConfiguration(name, `"1", "2"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNewRelicCheckRoleExists(roleResourceName),
+					resource.TestCheckResourceAttr(roleResourceName, "permission_ids.#", "2"),
+					resource.TestCheckResourceAttr(roleResourceName, "type", "CUSTOM"),
+				),
+			},
+			// Update
+			{
+				Config: testAccNewRelicRoleConfiguration(name+"-updated", `"1"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNewRelicCheckRoleExists(roleResourceName),
+					resource.TestCheckResourceAttr(roleResourceName, "permission_ids.#", "1"),
+				),
+			},
+			// Import
+			{
+				ResourceName:      roleResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicCheckRoleExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no role ID found")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+		roleID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		role, err := getRole(context.Background(), client, roleID)
+		if err != nil {
+			return err
+		}
+		if role == nil {
+			return fmt.Errorf("role %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicCheckRoleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_role" {
+			continue
+		}
+
+		roleID, err := strconv.Atoi(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		role, err := getRole(context.Background(), client, roleID)
+		if err == nil && role != nil {
+			return fmt.Errorf("role %s still exists", r.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccNewRelicRoleConfiguration(name string, permissionIDs string) string {
+	return fmt.Sprintf(`
+resource "newrelic_role" "foo" {
+  name           = "%s"
+  permission_ids = [%s]
+}
+`, name, permissionIDs)
+}
@@ -24,6 +24,7 @@ func resourceNewRelicServiceLevel() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceNewRelicServiceLevelCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"guid": {
 				Type:         schema.TypeString,
@@ -44,12 +45,22 @@ func resourceNewRelicServiceLevel() *schema.Resource {
 				Description: "",
 			},
 			"events": {
-				Type:        schema.TypeList,
-				Required:    true,
-				Description: "",
-				MinItems:    1,
-				MaxItems:    1,
-				Elem:        eventsSchema(),
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				Description:  "",
+				MinItems:     1,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"events", "template"},
+				Elem:         eventsSchema(),
+			},
+			"template": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Description:  "A predefined SLI that is expanded into events.",
+				MaxItems:     1,
+				ExactlyOneOf: []string{"events", "template"},
+				Elem:         serviceLevelTemplateSchema(),
 			},
 			"objective": {
 				Type:        schema.TypeSet,
@@ -229,6 +240,11 @@ func rollingTimeWindowSchema() *schema.Resource {
 func resourceNewRelicServiceLevelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderConfig).NewClient
 	entityGUID := d.Get("guid").(string)
+
+	if err := setServiceLevelTemplateEvents(d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	createInput := expandServiceLevelCreateInput(d)
 
 	if createInput.Events.GoodEvents == nil && createInput.Events.BadEvents == nil {
@@ -289,6 +305,11 @@ func resourceNewRelicServiceLevelRead(ctx context.Context, d *schema.ResourceDat
 
 func resourceNewRelicServiceLevelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderConfig).NewClient
+
+	if err := setServiceLevelTemplateEvents(d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	updateInput := expandServiceLevelUpdateInput(d)
 
 	log.Printf("[INFO] Updating New Relic One Service Level %s", d.Id())
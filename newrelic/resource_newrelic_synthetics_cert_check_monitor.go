@@ -51,7 +51,7 @@ func resourceNewRelicSyntheticsCertCheckMonitor() *schema.Resource {
 			},
 			"locations_public": {
 				Type:         schema.TypeSet,
-				Elem:         &schema.Schema{Type: schema.TypeString},
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validateSyntheticsPublicLocation},
 				MinItems:     1,
 				Optional:     true,
 				AtLeastOneOf: []string{"locations_public", "locations_private"},
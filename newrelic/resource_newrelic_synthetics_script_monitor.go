@@ -56,7 +56,7 @@ func syntheticsScriptMonitorLocationsSchema() map[string]*schema.Schema {
 		},
 		"locations_public": {
 			Type:         schema.TypeSet,
-			Elem:         &schema.Schema{Type: schema.TypeString},
+			Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validateSyntheticsPublicLocation},
 			MinItems:     1,
 			Optional:     true,
 			Description:  "The public location(s) that the monitor will run jobs from.",
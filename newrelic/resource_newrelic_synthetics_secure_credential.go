@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
 	"github.com/newrelic/newrelic-client-go/v2/pkg/synthetics"
 )
@@ -20,6 +21,7 @@ func resourceNewRelicSyntheticsSecureCredential() *schema.Resource {
 		ReadContext:   resourceNewRelicSyntheticsSecureCredentialRead,
 		UpdateContext: resourceNewRelicSyntheticsSecureCredentialUpdate,
 		DeleteContext: resourceNewRelicSyntheticsSecureCredentialDelete,
+		CustomizeDiff: resourceNewRelicSyntheticsSecureCredentialDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -42,10 +44,39 @@ func resourceNewRelicSyntheticsSecureCredential() *schema.Resource {
 				},
 			},
 			"value": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"value", "value_source"},
+				Description:  "The secure credential's value.",
+			},
+			"value_source": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"value", "value_source"},
+				Description:  "Where the secure credential's value is read from, instead of value. Only a salted hash of the value is stored in the state.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(syntheticsSecretProviderTypes(), false),
+							Description:  "The type of the source, one of env, file or secrets_file.",
+						},
+						"reference": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+							Description:  "The environment variable, the path of the file, or the path#name of the secret in the secrets file.",
+						},
+					},
+				},
+			},
+			"value_hash": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				Description: "The secure credential's value.",
+				Computed:    true,
+				Description: "The salted SHA-256 hash of the value read from value_source, as salt:hash.",
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -65,13 +96,44 @@ func resourceNewRelicSyntheticsSecureCredential() *schema.Resource {
 	}
 }
 
+// The value of value_source is read at plan time, and compared with the hash of the uploaded one,
+// so that the credential is uploaded again when the source changes.
+func resourceNewRelicSyntheticsSecureCredentialDiff(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("value_source") {
+		return d.SetNewComputed("value_hash")
+	}
+
+	hash := d.Get("value_hash").(string)
+
+	if len(d.Get("value_source").([]interface{})) == 0 {
+		if hash != "" {
+			return d.SetNew("value_hash", "")
+		}
+		return nil
+	}
+
+	value, err := resolveSyntheticsSecureCredentialValue(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	if syntheticsSecureCredentialValueChanged(hash, value) {
+		return d.SetNewComputed("value_hash")
+	}
+
+	return nil
+}
+
 func resourceNewRelicSyntheticsSecureCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConfig := meta.(*ProviderConfig)
 
 	client := providerConfig.NewClient
 	accountID := selectAccountID(providerConfig, d)
 
-	sc := expandSyntheticsSecureCredential(d)
+	sc, err := expandSyntheticsSecureCredential(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	log.Printf("[INFO] Creating New Relic Synthetics secure credential %s", sc.Key)
 
@@ -101,7 +163,7 @@ func resourceNewRelicSyntheticsSecureCredentialCreate(ctx context.Context, d *sc
 	_ = d.Set("last_updated", time.Time(*res.LastUpdate).Format(time.RFC3339))
 	_ = d.Set("account_id", accountID)
 
-	return nil
+	return diag.FromErr(setSyntheticsSecureCredentialValueHash(d, sc.Value))
 }
 
 func resourceNewRelicSyntheticsSecureCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -162,7 +224,10 @@ func resourceNewRelicSyntheticsSecureCredentialUpdate(ctx context.Context, d *sc
 
 	log.Printf("[INFO] Updating New Relic Synthetics secure credential %s", d.Id())
 
-	sc := expandSyntheticsSecureCredential(d)
+	sc, err := expandSyntheticsSecureCredential(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	var diags diag.Diagnostics
 
@@ -189,7 +254,7 @@ func resourceNewRelicSyntheticsSecureCredentialUpdate(ctx context.Context, d *sc
 	_ = d.Set("last_updated", time.Time(*res.LastUpdate).Format(time.RFC3339))
 	_ = d.Set("account_id", accountID)
 
-	return nil
+	return diag.FromErr(setSyntheticsSecureCredentialValueHash(d, sc.Value))
 }
 
 func resourceNewRelicSyntheticsSecureCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -222,17 +287,42 @@ func resourceNewRelicSyntheticsSecureCredentialDelete(ctx context.Context, d *sc
 	return nil
 }
 
-func expandSyntheticsSecureCredential(d *schema.ResourceData) *synthetics.SecureCredential {
+func expandSyntheticsSecureCredential(ctx context.Context, d *schema.ResourceData) (*synthetics.SecureCredential, error) {
 	key := d.Get("key").(string)
 	key = strings.ToUpper(key)
 
+	value, err := resolveSyntheticsSecureCredentialValue(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
 	sc := synthetics.SecureCredential{
 		Key:         key,
-		Value:       d.Get("value").(string),
+		Value:       value,
 		Description: d.Get("description").(string),
 	}
 
-	return &sc
+	return &sc, nil
+}
+
+// setSyntheticsSecureCredentialValueHash stores the hash of the uploaded value when it is read from value_source,
+// with a new salt every time the value changes. The hash of an unchanged value is kept, as planned by
+// resourceNewRelicSyntheticsSecureCredentialDiff.
+func setSyntheticsSecureCredentialValueHash(d *schema.ResourceData, value string) error {
+	if len(d.Get("value_source").([]interface{})) == 0 {
+		return d.Set("value_hash", "")
+	}
+
+	if old, _ := d.GetChange("value_hash"); !syntheticsSecureCredentialValueChanged(old.(string), value) {
+		return d.Set("value_hash", old)
+	}
+
+	salt, err := newSyntheticsSecureCredentialSalt()
+	if err != nil {
+		return err
+	}
+
+	return d.Set("value_hash", syntheticsSecureCredentialValueHash(salt, value))
 }
 
 func flattenSyntheticsSecureCredential(sc *entities.EntityOutlineInterface, d *schema.ResourceData) diag.Diagnostics {
@@ -4,6 +4,8 @@ package newrelic
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -53,6 +55,57 @@ func TestAccNewRelicSyntheticsSecureCredential_Basic(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicSyntheticsSecureCredential_ValueSource(t *testing.T) {
+	resourceName := "newrelic_synthetics_secure_credential.foo"
+	rName := fmt.Sprintf("TF_TEST_%s", acctest.RandString(7))
+	path := filepath.Join(t.TempDir(), "value")
+
+	writeValue := func(value string) func() {
+		return func() {
+			if err := os.WriteFile(path, []byte(value), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	var hash string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEnvVars(t); writeValue("Test Value")() },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicSyntheticsSecureCredentialDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicSyntheticsSecureCredentialConfigValueSource(rName, path),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsSecureCredentialExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "value", ""),
+					resource.TestMatchResourceAttr(resourceName, "value_hash", regexp.MustCompile("^[0-9a-f]{32}:[0-9a-f]{64}$")),
+					func(s *terraform.State) error {
+						hash = s.RootModule().Resources[resourceName].Primary.Attributes["value_hash"]
+						return nil
+					},
+				),
+			},
+			// Test: Update when the source changes
+			{
+				PreConfig: writeValue("Test Value Updated"),
+				Config:    testAccNewRelicSyntheticsSecureCredentialConfigValueSource(rName, path),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsSecureCredentialExists(resourceName),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[resourceName].Primary.Attributes["value_hash"] == hash {
+							return fmt.Errorf("expected value_hash to change")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccNewRelicSyntheticsSecureCredential_Error(t *testing.T) {
 	resourceName := "newrelic_synthetics_secure_credential.foo"
 
@@ -138,6 +191,20 @@ resource "newrelic_synthetics_secure_credential" "foo" {
 `, name)
 }
 
+func testAccNewRelicSyntheticsSecureCredentialConfigValueSource(name string, path string) string {
+	return fmt.Sprintf(`
+resource "newrelic_synthetics_secure_credential" "foo" {
+	key          = "%[1]s"
+	description  = "Test Description"
+
+	value_source {
+		type      = "file"
+		reference = "%[2]s"
+	}
+}
+`, name, path)
+}
+
 func testAccNewRelicSyntheticsSecureCredentialConfigUpdated(name string) string {
 	return fmt.Sprintf(`
 resource "newrelic_synthetics_secure_credential" "foo" {
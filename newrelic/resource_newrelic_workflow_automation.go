@@ -25,6 +25,7 @@ func resourceNewRelicWorkflowAutomation() *schema.Resource {
 		ReadContext:   resourceNewRelicWorkflowAutomationRead,
 		UpdateContext: resourceNewRelicWorkflowAutomationUpdate,
 		DeleteContext: resourceNewRelicWorkflowAutomationDelete,
+		CustomizeDiff: resourceNewRelicWorkflowAutomationDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -36,9 +37,11 @@ func resourceNewRelicWorkflowAutomation() *schema.Resource {
 				Description: "The name of the workflow automation. Must match the name in the YAML definition.",
 			},
 			"definition": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The YAML definition of the workflow automation.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     resourceNewRelicWorkflowAutomationValidateDefinition,
+				DiffSuppressFunc: resourceNewRelicWorkflowAutomationSuppressDefinitionDiff,
+				Description:      "The YAML definition of the workflow automation.",
 			},
 			"scope_id": {
 				Type:        schema.TypeString,
@@ -76,6 +79,41 @@ func resourceNewRelicWorkflowAutomation() *schema.Resource {
 	}
 }
 
+// resourceNewRelicWorkflowAutomationValidateDefinition validates the structure of the YAML definition,
+// so that mistakes are reported by terraform validate and plan rather than by the API.
+func resourceNewRelicWorkflowAutomationValidateDefinition(v interface{}, k string) (warns []string, errs []error) {
+	definition, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	return nil, validateWorkflowAutomationDefinition(definition)
+}
+
+// resourceNewRelicWorkflowAutomationSuppressDefinitionDiff ignores the changes of the formatting of the definition,
+// e.g. of its indentation, quotes or comments, or of the YAML returned by the API.
+func resourceNewRelicWorkflowAutomationSuppressDefinitionDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldNormalized, err := normalizeWorkflowAutomationDefinition(old)
+	if err != nil {
+		return false
+	}
+	newNormalized, err := normalizeWorkflowAutomationDefinition(new)
+	if err != nil {
+		return false
+	}
+
+	return oldNormalized == newNormalized
+}
+
+// The name of the definition is checked at plan time, once the name and definition are known.
+func resourceNewRelicWorkflowAutomationDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("name") || !d.NewValueKnown("definition") {
+		return nil
+	}
+
+	return resourceNewRelicWorkflowAutomationValidateName(d.Get("name").(string), d.Get("definition").(string))
+}
+
 func resourceNewRelicWorkflowAutomationValidateScopeType(scopeType string) error {
 	// Scope type is required and must not be empty
 	if scopeType == "" {
@@ -125,22 +163,27 @@ func resourceNewRelicWorkflowAutomationValidateAndGetName(d *schema.ResourceData
 	// Get name from Terraform resource config (required field, source of truth)
 	name := d.Get("name").(string)
 
-	// Get the YAML definition
-	definition := d.Get("definition").(string)
+	// Validate that YAML name matches the resource name
+	if err := resourceNewRelicWorkflowAutomationValidateName(name, d.Get("definition").(string)); err != nil {
+		return "", err
+	}
 
-	// Parse name from YAML to validate it matches
+	// Return the resource name (source of truth)
+	return name, nil
+}
+
+// resourceNewRelicWorkflowAutomationValidateName validates that the name in the YAML definition matches the given name.
+func resourceNewRelicWorkflowAutomationValidateName(name string, definition string) error {
 	yamlName, err := resourceNewRelicWorkflowAutomationParseNameFromYAML(definition)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	// Validate that YAML name matches the resource name
 	if name != yamlName {
-		return "", fmt.Errorf("name in resource configuration (%s) does not match name in YAML definition (%s). The name field in your YAML must match the resource name", name, yamlName)
+		return fmt.Errorf("name in resource configuration (%s) does not match name in YAML definition (%s). The name field in your YAML must match the resource name", name, yamlName)
 	}
 
-	// Return the resource name (source of truth)
-	return name, nil
+	return nil
 }
 
 func resourceNewRelicWorkflowAutomationParseID(id string) (scopeType string, scopeID string, name string, err error) {
@@ -69,6 +69,30 @@ func TestAccNewRelicWorkflowAutomation_Update_Account(t *testing.T) {
 	})
 }
 
+// Test that formatting changes of the definition don't produce a diff
+func TestAccNewRelicWorkflowAutomation_Reformatted_Account(t *testing.T) {
+	resourceName := "newrelic_workflow_automation.foo"
+	rName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEnvVars(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicWorkflowAutomationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicWorkflowAutomationConfig_Account(testAccountID, rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicWorkflowAutomationExists(resourceName),
+				),
+			},
+			{
+				Config:   testAccNewRelicWorkflowAutomationConfig_AccountReformatted(testAccountID, rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 // Test import functionality
 func TestAccNewRelicWorkflowAutomation_Import_Account(t *testing.T) {
 	resourceName := "newrelic_workflow_automation.foo"
@@ -215,6 +239,24 @@ func TestAccNewRelicWorkflowAutomation_YAMLWithoutName(t *testing.T) {
 	})
 }
 
+// Test validation: structural mistakes in the definition are reported at plan time
+func TestAccNewRelicWorkflowAutomation_InvalidDefinition(t *testing.T) {
+	rName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEnvVars(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicWorkflowAutomationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNewRelicWorkflowAutomationConfig_InvalidDefinition(testAccountID, rName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`next references the unknown step "waitStep3"`),
+			},
+		},
+	})
+}
+
 // Helper function to check if workflow automation exists
 func testAccCheckNewRelicWorkflowAutomationExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -352,6 +394,51 @@ EOT
 `, accountID, name)
 }
 
+func testAccNewRelicWorkflowAutomationConfig_AccountReformatted(accountID int, name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_workflow_automation" "foo" {
+  name       = "%[2]s"
+  scope_id   = "%[1]d"
+  scope_type = "ACCOUNT"
+
+  definition = <<-EOT
+# The same workflow, formatted differently
+name: "%[2]s"
+description: 'This is a test workflow created by terraform'
+steps:
+    -   type: wait
+        name: waitStep1
+        seconds: 10
+    -   type: wait
+        name: waitStep2
+        seconds: 10
+EOT
+}
+`, accountID, name)
+}
+
+func testAccNewRelicWorkflowAutomationConfig_InvalidDefinition(accountID int, name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_workflow_automation" "foo" {
+  name       = "%[2]s"
+  scope_id   = "%[1]d"
+  scope_type = "ACCOUNT"
+
+  definition = <<-EOT
+name: %[2]s
+steps:
+  - name: waitStep1
+    type: wait
+    seconds: 10
+    next: waitStep3
+  - name: waitStep2
+    type: wait
+    seconds: 10
+EOT
+}
+`, accountID, name)
+}
+
 func testAccNewRelicWorkflowAutomationConfig_AccountWithName(accountID int, name string) string {
 	return fmt.Sprintf(`
 resource "newrelic_workflow_automation" "foo" {
@@ -23,6 +23,7 @@ func resourceNewRelicWorkload() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceNewRelicWorkloadCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"account_id": {
 				Type:        schema.TypeInt,
@@ -73,8 +74,32 @@ func resourceNewRelicWorkload() *schema.Resource {
 								validation.StringIsNotEmpty,
 								validation.StringIsNotWhiteSpace,
 								validation.NoZeroValues,
+								validateEntitySearchQuery,
 							),
 						},
+						"max_entities": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "The maximum number of entities the query may match. The plan fails if the query currently matches more entities.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"resolve_entities": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether to resolve the entities the query currently matches on every refresh. Always done when max_entities is set.",
+						},
+						"resolved_entity_guids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The GUIDs of the entities the query currently matches, when resolve_entities or max_entities is set.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"resolved_entity_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of entities the query currently matches.",
+						},
 					},
 				},
 			},
@@ -161,6 +186,7 @@ func resourceNewRelicWorkload() *schema.Resource {
 														validation.StringIsNotEmpty,
 														validation.StringIsNotWhiteSpace,
 														validation.NoZeroValues,
+														validateEntitySearchQuery,
 													),
 												},
 											},
@@ -294,6 +320,60 @@ func WorkloadRollupInputSchemaElem() map[string]*schema.Schema {
 	}
 }
 
+// resourceNewRelicWorkloadCustomizeDiff fails the plan when an entity search
+// query matches more entities than its max_entities guard allows.
+func resourceNewRelicWorkloadCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfiguration := d.GetRawConfig()
+	if rawConfiguration.IsNull() {
+		return nil
+	}
+
+	if !rawConfiguration.GetAttr("entity_search_query").IsWhollyKnown() ||
+		!rawConfiguration.GetAttr("scope_account_ids").IsWhollyKnown() ||
+		!rawConfiguration.GetAttr("account_id").IsWhollyKnown() {
+		return nil
+	}
+
+	var guarded []map[string]interface{}
+	for _, q := range d.Get("entity_search_query").(*schema.Set).List() {
+		m := q.(map[string]interface{})
+		if m["max_entities"].(int) > 0 {
+			guarded = append(guarded, m)
+		}
+	}
+
+	if len(guarded) == 0 {
+		return nil
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+	accountID := providerConfig.AccountID
+	if !rawConfiguration.GetAttr("account_id").IsNull() {
+		accountID = d.Get("account_id").(int)
+	}
+
+	scopeAccountIDs := []int{accountID}
+	if !rawConfiguration.GetAttr("scope_account_ids").IsNull() {
+		scopeAccountIDs = expandWorkloadStatusScopeAccountIDs(d.Get("scope_account_ids").(*schema.Set).List())
+	}
+
+	for _, q := range guarded {
+		query := q["query"].(string)
+		maxEntities := q["max_entities"].(int)
+
+		_, count, err := searchWorkloadEntities(ctx, providerConfig.NewClient, scopeWorkloadEntitySearchQuery(query, scopeAccountIDs))
+		if err != nil {
+			return err
+		}
+
+		if count > maxEntities {
+			return fmt.Errorf("entity search query %q matches %d entities, which exceeds max_entities (%d)", query, count, maxEntities)
+		}
+	}
+
+	return nil
+}
+
 func resourceNewRelicWorkloadCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderConfig).NewClient
 	createInput := expandWorkloadCreateInput(d)
@@ -330,7 +410,25 @@ func resourceNewRelicWorkloadRead(ctx context.Context, d *schema.ResourceData, m
 		return nil
 	}
 
-	return diag.FromErr(flattenWorkload(workload, d))
+	// An entity search failure must not block the plan of the workload, so the
+	// previously resolved entities are kept instead.
+	var diags diag.Diagnostics
+	states := workloadEntitySearchQueryStates(d)
+	resolved, err := resolveWorkloadEntitySearchQueries(ctx, client, workload.EntitySearchQueries, states, workload.ScopeAccounts.AccountIDs)
+	if err != nil {
+		resolved = previousWorkloadEntitySearchQueryResults(states)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Failed to resolve the entities of the entity search queries",
+			Detail:   fmt.Sprintf("%s\n\nresolved_entity_guids and resolved_entity_count keep their previous values.", err),
+		})
+	}
+
+	if err := flattenWorkload(workload, d, resolved); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
 }
 
 func resourceNewRelicWorkloadUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -0,0 +1,352 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+)
+
+type alertPolicyBundleAction string
+
+// The changes applied to the conditions of an alert policy bundle, in the order they are applied.
+var alertPolicyBundleActions = struct {
+	Delete  alertPolicyBundleAction
+	Replace alertPolicyBundleAction
+	Update  alertPolicyBundleAction
+	Create  alertPolicyBundleAction
+}{
+	Delete:  "delete",
+	Replace: "replace",
+	Update:  "update",
+	Create:  "create",
+}
+
+type alertPolicyBundleChange struct {
+	Key    string
+	Action alertPolicyBundleAction
+}
+
+// Expands the condition blocks of a bundle, keyed by condition key.
+func expandAlertPolicyBundleConditions(cfg []interface{}) (map[string]embeddedNrqlAlertCondition, error) {
+	conditions := make(map[string]embeddedNrqlAlertCondition, len(cfg))
+	names := map[string]string{}
+
+	for _, c := range cfg {
+		m := c.(map[string]interface{})
+		key := m["key"].(string)
+
+		if _, ok := conditions[key]; ok {
+			return nil, fmt.Errorf("duplicate condition key %q", key)
+		}
+
+		conditionCfg := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			if k != "key" && k != "type" {
+				conditionCfg[k] = v
+			}
+		}
+
+		condition, err := expandEmbeddedNrqlAlertCondition(key, m["type"].(string), conditionCfg)
+		if err != nil {
+			return nil, fmt.Errorf("condition %q: %s", key, err)
+		}
+
+		if other, ok := names[condition.Name]; ok {
+			return nil, fmt.Errorf("conditions %q and %q have the same name %q", other, key, condition.Name)
+		}
+
+		names[condition.Name] = key
+		conditions[key] = *condition
+	}
+
+	return conditions, nil
+}
+
+// Computes the changes needed to go from the applied conditions to the
+// configured ones. Conditions whose type changed are replaced, since the type of
+// a NRQL alert condition can't be updated.
+func computeAlertPolicyBundleChangeset(oldConditions map[string]embeddedNrqlAlertCondition, newConditions map[string]embeddedNrqlAlertCondition, conditionIDs map[string]string) []alertPolicyBundleChange {
+	toCreate, toUpdate, toDelete := diffEmbeddedNrqlAlertConditions(oldConditions, newConditions, conditionIDs)
+
+	var replaced, updated []alertPolicyBundleChange
+	for _, key := range toUpdate {
+		if old, ok := oldConditions[key]; ok && old.Type != newConditions[key].Type {
+			replaced = append(replaced, alertPolicyBundleChange{Key: key, Action: alertPolicyBundleActions.Replace})
+		} else {
+			updated = append(updated, alertPolicyBundleChange{Key: key, Action: alertPolicyBundleActions.Update})
+		}
+	}
+
+	var changeset []alertPolicyBundleChange
+	for _, key := range toDelete {
+		changeset = append(changeset, alertPolicyBundleChange{Key: key, Action: alertPolicyBundleActions.Delete})
+	}
+	changeset = append(changeset, replaced...)
+	changeset = append(changeset, updated...)
+	for _, key := range toCreate {
+		changeset = append(changeset, alertPolicyBundleChange{Key: key, Action: alertPolicyBundleActions.Create})
+	}
+
+	return changeset
+}
+
+func flattenAlertPolicyBundleChangeset(changeset []alertPolicyBundleChange) []interface{} {
+	out := make([]interface{}, len(changeset))
+	for i, c := range changeset {
+		out[i] = map[string]interface{}{
+			"key":    c.Key,
+			"action": string(c.Action),
+		}
+	}
+
+	return out
+}
+
+// alertPolicyBundleOperations are the NerdGraph calls used to apply the changeset of a bundle.
+type alertPolicyBundleOperations struct {
+	get    func(ctx context.Context, conditionID string) (*alerts.NrqlAlertCondition, error)
+	create func(ctx context.Context, conditionType string, input alerts.NrqlConditionCreateInput) (*alerts.NrqlAlertCondition, error)
+	update func(ctx context.Context, conditionID string, conditionType string, input alerts.NrqlConditionUpdateInput) (*alerts.NrqlAlertCondition, error)
+	delete func(ctx context.Context, conditionID string) error
+}
+
+type alertPolicyBundleJournalEntry struct {
+	Key         string
+	Action      alertPolicyBundleAction
+	ConditionID string
+	Snapshot    *alerts.NrqlAlertCondition
+}
+
+// alertPolicyBundleJournal applies the changeset of a bundle and records every
+// change made, so they can be rolled back if a later change fails.
+type alertPolicyBundleJournal struct {
+	ops          alertPolicyBundleOperations
+	ConditionIDs map[string]string
+	EntityGUIDs  map[string]string
+	entries      []alertPolicyBundleJournalEntry
+}
+
+func newAlertPolicyBundleJournal(ops alertPolicyBundleOperations, conditionIDs map[string]string, entityGUIDs map[string]string) *alertPolicyBundleJournal {
+	j := &alertPolicyBundleJournal{
+		ops:          ops,
+		ConditionIDs: map[string]string{},
+		EntityGUIDs:  map[string]string{},
+	}
+
+	for k, v := range conditionIDs {
+		j.ConditionIDs[k] = v
+	}
+	for k, v := range entityGUIDs {
+		j.EntityGUIDs[k] = v
+	}
+
+	return j
+}
+
+// Applies a changeset. Every condition the changeset modifies is snapshotted
+// before any change is made, and the changeset stops at the first failure.
+func (j *alertPolicyBundleJournal) apply(ctx context.Context, changeset []alertPolicyBundleChange, conditions map[string]embeddedNrqlAlertCondition) error {
+	snapshots := map[string]*alerts.NrqlAlertCondition{}
+	for _, c := range changeset {
+		if c.Action == alertPolicyBundleActions.Create {
+			continue
+		}
+
+		snapshot, err := j.ops.get(ctx, j.ConditionIDs[c.Key])
+		if err != nil {
+			return fmt.Errorf("error taking a snapshot of condition %q: %w", c.Key, err)
+		}
+		snapshots[c.Key] = snapshot
+	}
+
+	for _, c := range changeset {
+		if err := j.applyChange(ctx, c, conditions[c.Key], snapshots[c.Key]); err != nil {
+			return fmt.Errorf("error applying %s of condition %q: %w", c.Action, c.Key, err)
+		}
+	}
+
+	return nil
+}
+
+func (j *alertPolicyBundleJournal) applyChange(ctx context.Context, c alertPolicyBundleChange, condition embeddedNrqlAlertCondition, snapshot *alerts.NrqlAlertCondition) error {
+	switch c.Action {
+	case alertPolicyBundleActions.Delete, alertPolicyBundleActions.Replace:
+		if err := j.ops.delete(ctx, j.ConditionIDs[c.Key]); err != nil {
+			return err
+		}
+
+		j.entries = append(j.entries, alertPolicyBundleJournalEntry{Key: c.Key, Action: alertPolicyBundleActions.Delete, Snapshot: snapshot})
+		delete(j.ConditionIDs, c.Key)
+		delete(j.EntityGUIDs, c.Key)
+
+		if c.Action == alertPolicyBundleActions.Delete {
+			return nil
+		}
+
+		return j.create(ctx, condition)
+
+	case alertPolicyBundleActions.Update:
+		updated, err := j.ops.update(ctx, j.ConditionIDs[c.Key], condition.Type, *condition.UpdateInput)
+		if err != nil {
+			return err
+		}
+
+		j.entries = append(j.entries, alertPolicyBundleJournalEntry{Key: c.Key, Action: alertPolicyBundleActions.Update, ConditionID: j.ConditionIDs[c.Key], Snapshot: snapshot})
+		if updated != nil {
+			j.EntityGUIDs[c.Key] = string(updated.EntityGUID)
+		}
+
+		return nil
+
+	case alertPolicyBundleActions.Create:
+		return j.create(ctx, condition)
+	}
+
+	return fmt.Errorf("unknown action %q", c.Action)
+}
+
+func (j *alertPolicyBundleJournal) create(ctx context.Context, condition embeddedNrqlAlertCondition) error {
+	created, err := j.ops.create(ctx, condition.Type, *condition.CreateInput)
+	if err != nil {
+		return err
+	}
+
+	if created == nil {
+		return fmt.Errorf("response was nil")
+	}
+
+	j.entries = append(j.entries, alertPolicyBundleJournalEntry{Key: condition.Key, Action: alertPolicyBundleActions.Create, ConditionID: created.ID})
+	j.ConditionIDs[condition.Key] = created.ID
+	j.EntityGUIDs[condition.Key] = string(created.EntityGUID)
+
+	return nil
+}
+
+// Reverts the recorded changes in reverse order: created conditions are deleted,
+// updated conditions are restored from their snapshot and deleted conditions are
+// recreated from their snapshot. Recreated conditions get a new ID.
+func (j *alertPolicyBundleJournal) rollback(ctx context.Context) error {
+	var errs []string
+
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		e := j.entries[i]
+
+		switch e.Action {
+		case alertPolicyBundleActions.Create:
+			if err := j.ops.delete(ctx, e.ConditionID); err != nil {
+				errs = append(errs, fmt.Sprintf("could not delete created condition %q: %s", e.Key, err))
+				continue
+			}
+			delete(j.ConditionIDs, e.Key)
+			delete(j.EntityGUIDs, e.Key)
+
+		case alertPolicyBundleActions.Update:
+			if _, err := j.ops.update(ctx, e.ConditionID, string(e.Snapshot.Type), expandNrqlAlertConditionSnapshotUpdateInput(e.Snapshot)); err != nil {
+				errs = append(errs, fmt.Sprintf("could not revert updated condition %q: %s", e.Key, err))
+				continue
+			}
+			j.EntityGUIDs[e.Key] = string(e.Snapshot.EntityGUID)
+
+		case alertPolicyBundleActions.Delete:
+			recreated, err := j.ops.create(ctx, string(e.Snapshot.Type), expandNrqlAlertConditionSnapshotCreateInput(e.Snapshot))
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("could not recreate deleted condition %q: %s", e.Key, err))
+				continue
+			}
+			j.ConditionIDs[e.Key] = recreated.ID
+			j.EntityGUIDs[e.Key] = string(recreated.EntityGUID)
+		}
+	}
+
+	j.entries = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("rollback incomplete:\n%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+// The keys of the conditions in state, sorted.
+func alertPolicyBundleConditionKeys(conditionIDs map[string]string) []string {
+	keys := make([]string, 0, len(conditionIDs))
+	for key := range conditionIDs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Builds the input that restores a condition to a snapshot taken from NerdGraph.
+func expandNrqlAlertConditionSnapshotUpdateInput(c *alerts.NrqlAlertCondition) alerts.NrqlConditionUpdateInput {
+	input := alerts.NrqlConditionUpdateInput{
+		NrqlConditionUpdateBase: alerts.NrqlConditionUpdateBase{
+			Description:               c.Description,
+			Enabled:                   c.Enabled,
+			Name:                      c.Name,
+			Nrql:                      alerts.NrqlConditionUpdateQuery(c.Nrql),
+			RunbookURL:                c.RunbookURL,
+			Terms:                     c.Terms,
+			ViolationTimeLimitSeconds: c.ViolationTimeLimitSeconds,
+			Expiration:                c.Expiration,
+			TitleTemplate:             c.TitleTemplate,
+			TargetEntity:              c.TargetEntity,
+		},
+		BaselineDirection:    c.BaselineDirection,
+		SignalSeasonality:    c.SignalSeasonality,
+		OutlierConfiguration: expandNrqlOutlierConfigurationSnapshot(c.OutlierConfiguration),
+	}
+
+	if c.Signal != nil {
+		signal := alerts.AlertsNrqlConditionUpdateSignal(*c.Signal)
+		input.Signal = &signal
+	}
+
+	return input
+}
+
+// Builds the input that recreates a condition from a snapshot taken from NerdGraph.
+func expandNrqlAlertConditionSnapshotCreateInput(c *alerts.NrqlAlertCondition) alerts.NrqlConditionCreateInput {
+	input := alerts.NrqlConditionCreateInput{
+		NrqlConditionCreateBase: alerts.NrqlConditionCreateBase{
+			Description:               c.Description,
+			Enabled:                   c.Enabled,
+			Name:                      c.Name,
+			Nrql:                      alerts.NrqlConditionCreateQuery(c.Nrql),
+			RunbookURL:                c.RunbookURL,
+			Terms:                     c.Terms,
+			ViolationTimeLimitSeconds: c.ViolationTimeLimitSeconds,
+			Expiration:                c.Expiration,
+			TitleTemplate:             c.TitleTemplate,
+			TargetEntity:              c.TargetEntity,
+		},
+		BaselineDirection:    c.BaselineDirection,
+		SignalSeasonality:    c.SignalSeasonality,
+		OutlierConfiguration: expandNrqlOutlierConfigurationSnapshot(c.OutlierConfiguration),
+	}
+
+	if c.Signal != nil {
+		signal := alerts.AlertsNrqlConditionCreateSignal(*c.Signal)
+		input.Signal = &signal
+	}
+
+	return input
+}
+
+func expandNrqlOutlierConfigurationSnapshot(in *alerts.NrqlOutlierConfigurationOutput) *alerts.NrqlOutlierConfigurationInput {
+	if in == nil {
+		return nil
+	}
+
+	return &alerts.NrqlOutlierConfigurationInput{
+		DBSCAN: alerts.NrqlOutlierDbScanConfigurationInput{
+			Epsilon:              in.Epsilon,
+			MinimumPoints:        in.MinimumPoints,
+			EvaluationGroupFacet: in.EvaluationGroupFacet,
+		},
+	}
+}
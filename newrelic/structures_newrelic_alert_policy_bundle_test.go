@@ -0,0 +1,236 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func testAlertPolicyBundleCondition(key string, conditionType string, threshold float64) map[string]interface{} {
+	return map[string]interface{}{
+		"key":  key,
+		"type": conditionType,
+		"name": fmt.Sprintf("%s condition", key),
+		"nrql": []interface{}{
+			map[string]interface{}{"query": "SELECT count(*) FROM Transaction"},
+		},
+		"baseline_direction": "upper_only",
+		"critical": []interface{}{
+			map[string]interface{}{
+				"operator":              "above",
+				"threshold":             threshold,
+				"threshold_duration":    120,
+				"threshold_occurrences": "ALL",
+			},
+		},
+	}
+}
+
+func testExpandAlertPolicyBundleConditions(t *testing.T, conditions ...map[string]interface{}) map[string]embeddedNrqlAlertCondition {
+	raw := make([]interface{}, len(conditions))
+	for i, c := range conditions {
+		raw[i] = c
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceNewRelicAlertPolicyBundle().Schema, map[string]interface{}{
+		"name":      "bundle",
+		"condition": raw,
+	})
+
+	expanded, err := expandAlertPolicyBundleConditions(d.Get("condition").([]interface{}))
+	require.NoError(t, err)
+
+	return expanded
+}
+
+func TestExpandAlertPolicyBundleConditions(t *testing.T) {
+	conditions := testExpandAlertPolicyBundleConditions(t,
+		testAlertPolicyBundleCondition("latency", "static", 1),
+		testAlertPolicyBundleCondition("throughput", "baseline", 2),
+	)
+
+	require.Len(t, conditions, 2)
+	require.Equal(t, "static", conditions["latency"].Type)
+	require.Equal(t, "latency condition", conditions["latency"].Name)
+	require.Nil(t, conditions["latency"].CreateInput.BaselineDirection)
+	require.Equal(t, "baseline", conditions["throughput"].Type)
+	require.Equal(t, alerts.NrqlBaselineDirection("UPPER_ONLY"), *conditions["throughput"].CreateInput.BaselineDirection)
+
+	_, err := expandAlertPolicyBundleConditions([]interface{}{
+		testAlertPolicyBundleCondition("latency", "static", 1),
+		testAlertPolicyBundleCondition("latency", "static", 2),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "duplicate condition key")
+}
+
+func TestComputeAlertPolicyBundleChangeset(t *testing.T) {
+	oldConditions := testExpandAlertPolicyBundleConditions(t,
+		testAlertPolicyBundleCondition("unchanged", "static", 1),
+		testAlertPolicyBundleCondition("updated", "static", 1),
+		testAlertPolicyBundleCondition("replaced", "static", 1),
+		testAlertPolicyBundleCondition("deleted", "static", 1),
+	)
+	newConditions := testExpandAlertPolicyBundleConditions(t,
+		testAlertPolicyBundleCondition("unchanged", "static", 1),
+		testAlertPolicyBundleCondition("updated", "static", 2),
+		testAlertPolicyBundleCondition("replaced", "baseline", 1),
+		testAlertPolicyBundleCondition("created", "static", 1),
+	)
+	conditionIDs := map[string]string{"unchanged": "1", "updated": "2", "replaced": "3", "deleted": "4"}
+
+	changeset := computeAlertPolicyBundleChangeset(oldConditions, newConditions, conditionIDs)
+	require.Equal(t, []alertPolicyBundleChange{
+		{Key: "deleted", Action: alertPolicyBundleActions.Delete},
+		{Key: "replaced", Action: alertPolicyBundleActions.Replace},
+		{Key: "updated", Action: alertPolicyBundleActions.Update},
+		{Key: "created", Action: alertPolicyBundleActions.Create},
+	}, changeset)
+
+	require.Empty(t, computeAlertPolicyBundleChangeset(newConditions, newConditions, map[string]string{
+		"unchanged": "1", "updated": "2", "replaced": "3", "created": "5",
+	}))
+}
+
+// testAlertPolicyBundleAPI is an in-memory stand-in for the NerdGraph alert condition calls.
+type testAlertPolicyBundleAPI struct {
+	conditions map[string]*alerts.NrqlAlertCondition
+	nextID     int
+	failCreate string
+	calls      []string
+}
+
+func (api *testAlertPolicyBundleAPI) operations() alertPolicyBundleOperations {
+	return alertPolicyBundleOperations{
+		get: func(ctx context.Context, conditionID string) (*alerts.NrqlAlertCondition, error) {
+			c, ok := api.conditions[conditionID]
+			if !ok {
+				return nil, fmt.Errorf("condition %s not found", conditionID)
+			}
+			snapshot := *c
+			return &snapshot, nil
+		},
+		create: func(ctx context.Context, conditionType string, input alerts.NrqlConditionCreateInput) (*alerts.NrqlAlertCondition, error) {
+			api.calls = append(api.calls, "create "+input.Name)
+			if input.Name == api.failCreate {
+				return nil, fmt.Errorf("boom")
+			}
+
+			api.nextID++
+			c := &alerts.NrqlAlertCondition{ID: fmt.Sprint(api.nextID)}
+			c.Name = input.Name
+			c.Terms = input.Terms
+			c.Type = alerts.NrqlConditionType(conditionType)
+			c.EntityGUID = common.EntityGUID("guid-" + c.ID)
+			api.conditions[c.ID] = c
+			return c, nil
+		},
+		update: func(ctx context.Context, conditionID string, conditionType string, input alerts.NrqlConditionUpdateInput) (*alerts.NrqlAlertCondition, error) {
+			api.calls = append(api.calls, "update "+conditionID)
+			c := api.conditions[conditionID]
+			c.Name = input.Name
+			c.Terms = input.Terms
+			return c, nil
+		},
+		delete: func(ctx context.Context, conditionID string) error {
+			api.calls = append(api.calls, "delete "+conditionID)
+			delete(api.conditions, conditionID)
+			return nil
+		},
+	}
+}
+
+func TestAlertPolicyBundleJournal_Rollback(t *testing.T) {
+	threshold := 1.0
+	existing := func(id string, name string, conditionType alerts.NrqlConditionType) *alerts.NrqlAlertCondition {
+		c := &alerts.NrqlAlertCondition{ID: id}
+		c.Name = name
+		c.Type = conditionType
+		c.Terms = []alerts.NrqlConditionTerm{{Threshold: &threshold}}
+		return c
+	}
+
+	api := &testAlertPolicyBundleAPI{
+		conditions: map[string]*alerts.NrqlAlertCondition{
+			"1": existing("1", "updated condition", alerts.NrqlConditionTypes.Static),
+			"2": existing("2", "replaced condition", alerts.NrqlConditionTypes.Static),
+			"3": existing("3", "deleted condition", alerts.NrqlConditionTypes.Static),
+		},
+		nextID:     10,
+		failCreate: "created condition",
+	}
+
+	conditions := testExpandAlertPolicyBundleConditions(t,
+		testAlertPolicyBundleCondition("updated", "static", 2),
+		testAlertPolicyBundleCondition("replaced", "baseline", 1),
+		testAlertPolicyBundleCondition("created", "static", 1),
+	)
+	changeset := []alertPolicyBundleChange{
+		{Key: "deleted", Action: alertPolicyBundleActions.Delete},
+		{Key: "replaced", Action: alertPolicyBundleActions.Replace},
+		{Key: "updated", Action: alertPolicyBundleActions.Update},
+		{Key: "created", Action: alertPolicyBundleActions.Create},
+	}
+
+	journal := newAlertPolicyBundleJournal(api.operations(), map[string]string{"updated": "1", "replaced": "2", "deleted": "3"}, nil)
+
+	err := journal.apply(context.Background(), changeset, conditions)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `error applying create of condition "created": boom`)
+
+	require.NoError(t, journal.rollback(context.Background()))
+	require.Equal(t, []string{
+		"delete 3",
+		"delete 2",
+		"create replaced condition",
+		"update 1",
+		"create created condition",
+		// rollback
+		"update 1",
+		"delete 11",
+		"create replaced condition",
+		"create deleted condition",
+	}, api.calls)
+
+	// The updated condition is restored, the deleted ones are recreated with new IDs.
+	require.Equal(t, 1.0, *api.conditions["1"].Terms[0].Threshold)
+	require.Equal(t, map[string]string{"updated": "1", "replaced": "12", "deleted": "13"}, journal.ConditionIDs)
+	require.Equal(t, alerts.NrqlConditionTypes.Static, api.conditions["12"].Type)
+	require.Len(t, api.conditions, 3)
+}
+
+func TestExpandNrqlAlertConditionSnapshotInputs(t *testing.T) {
+	offset := 60
+	window := 120
+	fill := alerts.AlertsFillOptionTypes.STATIC
+	title := "{{conditionName}}"
+
+	c := &alerts.NrqlAlertCondition{ID: "1"}
+	c.Name = "snapshot"
+	c.Enabled = true
+	c.Nrql = alerts.NrqlConditionQuery{Query: "SELECT count(*) FROM Transaction", EvaluationOffset: &offset}
+	c.Signal = &alerts.AlertsNrqlConditionSignal{AggregationWindow: &window, FillOption: &fill}
+	c.TitleTemplate = &title
+	c.OutlierConfiguration = &alerts.NrqlOutlierConfigurationOutput{Epsilon: 0.5, MinimumPoints: 3}
+
+	update := expandNrqlAlertConditionSnapshotUpdateInput(c)
+	require.Equal(t, "snapshot", update.Name)
+	require.True(t, update.Enabled)
+	require.Equal(t, &offset, update.Nrql.EvaluationOffset)
+	require.Equal(t, &window, update.Signal.AggregationWindow)
+	require.Equal(t, &fill, update.Signal.FillOption)
+	require.Equal(t, &title, update.TitleTemplate)
+	require.Equal(t, 3, update.OutlierConfiguration.DBSCAN.MinimumPoints)
+
+	create := expandNrqlAlertConditionSnapshotCreateInput(c)
+	require.Equal(t, "SELECT count(*) FROM Transaction", create.Nrql.Query)
+	require.Equal(t, &window, create.Signal.AggregationWindow)
+	require.Equal(t, 0.5, create.OutlierConfiguration.DBSCAN.Epsilon)
+}
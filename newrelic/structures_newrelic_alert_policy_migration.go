@@ -0,0 +1,519 @@
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	alertMigrationConditionType      = "newrelic_alert_condition"
+	alertMigrationInfraConditionType = "newrelic_infra_alert_condition"
+	alertMigrationChannelType        = "newrelic_alert_channel"
+	alertMigrationPolicyType         = "newrelic_alert_policy"
+
+	// The default webhook payload of workflows, used in place of the legacy payloads.
+	alertMigrationWebhookPayload = `{
+  "id": {{ json issueId }},
+  "issueUrl": {{ json issuePageUrl }},
+  "title": {{ json annotations.title.[0] }},
+  "priority": {{ json priority }},
+  "state": {{ json state }},
+  "policyName": {{ json accumulations.policyName.[0] }},
+  "conditionName": {{ json accumulations.conditionName.[0] }}
+}`
+)
+
+var alertMigrationInvalidNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// alertMigration translates the legacy conditions and channels of an alert policy into the configuration
+// of NRQL conditions, notification destinations and channels, and a workflow, reporting what can't be
+// translated exactly.
+type alertMigration struct {
+	policyID   int
+	policyName string
+
+	file  *hclwrite.File
+	names map[string]bool
+
+	conditions []interface{}
+	channels   []interface{}
+	report     []interface{}
+
+	// The resource names of the notification channels the workflow sends to
+	workflowChannels []string
+}
+
+func newAlertMigration(policyID int, policyName string) *alertMigration {
+	return &alertMigration{
+		policyID:   policyID,
+		policyName: policyName,
+		file:       hclwrite.NewEmptyFile(),
+		names:      map[string]bool{},
+		conditions: []interface{}{},
+		channels:   []interface{}{},
+		report:     []interface{}{},
+	}
+}
+
+func (m *alertMigration) hcl() string {
+	return string(m.file.Bytes())
+}
+
+func (m *alertMigration) note(sourceType string, sourceID int, name string, message string) {
+	m.report = append(m.report, map[string]interface{}{
+		"source_type": sourceType,
+		"source_id":   sourceID,
+		"name":        name,
+		"message":     message,
+	})
+}
+
+// resourceName returns a Terraform identifier derived from the name, unique for the resource type.
+func (m *alertMigration) resourceName(resourceType string, name string) string {
+	base := strings.Trim(alertMigrationInvalidNameChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" {
+		base = "migrated"
+	}
+	if base[0] >= '0' && base[0] <= '9' {
+		base = "_" + base
+	}
+
+	unique := base
+	for i := 2; m.names[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", base, i)
+	}
+	m.names[resourceType+"."+unique] = true
+
+	return unique
+}
+
+// alertMigrationThreshold is a critical or warning threshold of a legacy condition.
+type alertMigrationThreshold struct {
+	priority     string
+	operator     string
+	value        float64
+	minutes      int
+	timeFunction string
+}
+
+// alertMigrationCondition is a legacy condition translated into NRQL.
+type alertMigrationCondition struct {
+	sourceType          string
+	sourceID            int
+	name                string
+	description         string
+	runbookURL          string
+	enabled             bool
+	query               string
+	violationCloseHours int
+	lossOfSignalMinutes int
+	thresholds          []alertMigrationThreshold
+	notes               []string
+}
+
+func (m *alertMigration) addCondition(c *alertMigrationCondition) {
+	resourceName := m.resourceName("newrelic_nrql_alert_condition", c.name)
+
+	body := m.file.Body()
+	block := body.AppendNewBlock("resource", []string{"newrelic_nrql_alert_condition", resourceName}).Body()
+	block.SetAttributeValue("policy_id", cty.NumberIntVal(int64(m.policyID)))
+	block.SetAttributeValue("type", cty.StringVal("static"))
+	block.SetAttributeValue("name", cty.StringVal(c.name))
+	if c.description != "" {
+		block.SetAttributeValue("description", cty.StringVal(c.description))
+	}
+	if c.runbookURL != "" {
+		block.SetAttributeValue("runbook_url", cty.StringVal(c.runbookURL))
+	}
+	block.SetAttributeValue("enabled", cty.BoolVal(c.enabled))
+	if c.violationCloseHours > 0 {
+		block.SetAttributeValue("violation_time_limit_seconds", cty.NumberIntVal(int64(c.violationCloseHours*3600)))
+	}
+	block.SetAttributeValue("aggregation_window", cty.NumberIntVal(60))
+	block.SetAttributeValue("aggregation_method", cty.StringVal("event_flow"))
+	block.SetAttributeValue("aggregation_delay", cty.NumberIntVal(120))
+	if c.lossOfSignalMinutes > 0 {
+		block.SetAttributeValue("expiration_duration", cty.NumberIntVal(int64(c.lossOfSignalMinutes*60)))
+		block.SetAttributeValue("open_violation_on_expiration", cty.BoolVal(true))
+		block.SetAttributeValue("close_violations_on_expiration", cty.BoolVal(true))
+	}
+
+	block.AppendNewline()
+	block.AppendNewBlock("nrql", nil).Body().SetAttributeValue("query", cty.StringVal(c.query))
+
+	for _, t := range c.thresholds {
+		occurrences := "ALL"
+		if t.timeFunction == string(alerts.TimeFunctionTypes.Any) {
+			occurrences = "AT_LEAST_ONCE"
+		}
+
+		block.AppendNewline()
+		term := block.AppendNewBlock(t.priority, nil).Body()
+		term.SetAttributeValue("operator", cty.StringVal(t.operator))
+		term.SetAttributeValue("threshold", cty.NumberFloatVal(t.value))
+		term.SetAttributeValue("threshold_duration", cty.NumberIntVal(int64(t.minutes*60)))
+		term.SetAttributeValue("threshold_occurrences", cty.StringVal(occurrences))
+	}
+	body.AppendNewline()
+
+	for _, n := range c.notes {
+		m.note(c.sourceType, c.sourceID, c.name, n)
+	}
+
+	m.conditions = append(m.conditions, map[string]interface{}{
+		"source_type":   c.sourceType,
+		"source_id":     c.sourceID,
+		"name":          c.name,
+		"resource_name": resourceName,
+		"query":         c.query,
+		"exact":         len(c.notes) == 0,
+	})
+}
+
+// translateAlertCondition translates an APM condition into NRQL, or returns why it can't be.
+func translateAlertCondition(c *alerts.Condition) (*alertMigrationCondition, string) {
+	if c.Type != alerts.ConditionTypes.APMApplicationMetric {
+		return nil, fmt.Sprintf("conditions of type %s can't be translated into NRQL, create the NRQL condition by hand", c.Type)
+	}
+
+	out := &alertMigrationCondition{
+		sourceType:          alertMigrationConditionType,
+		sourceID:            c.ID,
+		name:                c.Name,
+		runbookURL:          c.RunbookURL,
+		enabled:             c.Enabled,
+		violationCloseHours: c.ViolationCloseTimer,
+	}
+
+	var selectClause, from string
+	var where []string
+
+	switch c.Metric {
+	case alerts.MetricTypes.Apdex:
+		selectClause, from = "apdex(duration, t: 0.5)", "Transaction"
+		out.notes = append(out.notes, "the Apdex T of the applications is not known to the migration, set the t argument of apdex() to their Apdex T in seconds")
+	case alerts.MetricTypes.ErrorPercentage:
+		selectClause, from = "percentage(count(*), WHERE error IS true)", "Transaction"
+	case alerts.MetricTypes.ResponseTimeWeb:
+		selectClause, from = "average(duration)", "Transaction"
+		where = append(where, "transactionType = 'Web'")
+	case alerts.MetricTypes.ResponseTimeBackground:
+		selectClause, from = "average(duration)", "Transaction"
+		where = append(where, "transactionType = 'Other'")
+	case alerts.MetricTypes.ThroughputWeb:
+		selectClause, from = "rate(count(*), 1 minute)", "Transaction"
+		where = append(where, "transactionType = 'Web'")
+	case alerts.MetricTypes.ThroughputBackground:
+		selectClause, from = "rate(count(*), 1 minute)", "Transaction"
+		where = append(where, "transactionType = 'Other'")
+	case alerts.MetricTypes.UserDefined:
+		functions := map[alerts.ValueFunctionType]string{
+			alerts.ValueFunctionTypes.Average:    "average",
+			alerts.ValueFunctionTypes.Min:        "min",
+			alerts.ValueFunctionTypes.Max:        "max",
+			alerts.ValueFunctionTypes.Total:      "sum",
+			alerts.ValueFunctionTypes.SampleSize: "count",
+		}
+		function, ok := functions[c.UserDefined.ValueFunction]
+		if !ok {
+			return nil, fmt.Sprintf("the value function %s of user defined metrics can't be translated into NRQL, create the NRQL condition by hand", c.UserDefined.ValueFunction)
+		}
+		selectClause, from = function+"(newrelic.timeslice.value)", "Metric"
+		where = append(where, fmt.Sprintf("metricTimesliceName = %s", nrqlQuote(c.UserDefined.Metric)))
+	default:
+		return nil, fmt.Sprintf("the metric %s can't be translated into NRQL, create the NRQL condition by hand", c.Metric)
+	}
+
+	if len(c.Entities) > 0 {
+		where = append(where, fmt.Sprintf("appId IN (%s)", strings.Join(c.Entities, ", ")))
+	}
+
+	facet := "appName"
+	if c.Scope == "instance" {
+		facet = "host"
+	}
+
+	out.query = fmt.Sprintf("SELECT %s FROM %s", selectClause, from)
+	if len(where) > 0 {
+		out.query += " WHERE " + strings.Join(where, " AND ")
+	}
+	out.query += " FACET " + facet
+
+	for _, t := range c.Terms {
+		out.thresholds = append(out.thresholds, alertMigrationThreshold{
+			priority:     string(t.Priority),
+			operator:     alertMigrationOperator(string(t.Operator)),
+			value:        t.Threshold,
+			minutes:      t.Duration,
+			timeFunction: string(t.TimeFunction),
+		})
+	}
+
+	return out, ""
+}
+
+// translateInfraAlertCondition translates an infrastructure condition into NRQL, or returns why it can't be.
+// The queries follow the ones of the alert conditions migration guide.
+func translateInfraAlertCondition(c alerts.InfrastructureCondition) (*alertMigrationCondition, string) {
+	out := &alertMigrationCondition{
+		sourceType:  alertMigrationInfraConditionType,
+		sourceID:    c.ID,
+		name:        c.Name,
+		description: c.Description,
+		runbookURL:  c.RunbookURL,
+		enabled:     c.Enabled,
+	}
+	if c.ViolationCloseTimer != nil {
+		out.violationCloseHours = *c.ViolationCloseTimer
+	}
+
+	var where []string
+	if c.Where != "" {
+		where = append(where, "("+c.Where+")")
+	}
+
+	switch c.Type {
+	case "infra_metric":
+		if c.IntegrationProvider != "" {
+			where = append([]string{fmt.Sprintf("provider = %s", nrqlQuote(c.IntegrationProvider))}, where...)
+			out.notes = append(out.notes, "the condition is translated into a query of the samples of the integration, which may have been replaced by dimensional metrics, check the query returns data")
+		}
+
+		facet := "entityGuid"
+		if c.Event == "StorageSample" {
+			facet = "entityAndMountPoint, mountPoint"
+		}
+
+		out.query = fmt.Sprintf("SELECT average(%s) FROM %s", c.Select, c.Event)
+		if len(where) > 0 {
+			out.query += " WHERE " + strings.Join(where, " AND ")
+		}
+		out.query += " FACET " + facet
+	case "infra_process_running":
+		count := "uniqueCount(processId)"
+		if c.ProcessWhere != "" {
+			count = fmt.Sprintf("filter(uniqueCount(processId), WHERE %s)", c.ProcessWhere)
+		}
+		out.query = fmt.Sprintf("SELECT %s FROM ProcessSample WHERE %s FACET entityGuid", count, strings.Join(append([]string{"hostname IS NOT NULL"}, where...), " AND "))
+	case "infra_host_not_responding":
+		out.query = fmt.Sprintf("SELECT count(`host.cpuPercent`) FROM Metric WHERE %s FACET entity.guid",
+			strings.Join(append([]string{"metricName = 'host.cpuPercent'", "host.hostname IS NOT NULL"}, where...), " AND "))
+		if c.Where != "" {
+			out.notes = append(out.notes, "the where clause is applied to dimensional metrics as is, check its attribute names, e.g. host.hostname instead of hostname")
+		}
+		if c.Critical != nil {
+			out.lossOfSignalMinutes = c.Critical.Duration
+			out.thresholds = append(out.thresholds, alertMigrationThreshold{
+				priority:     string(alerts.PriorityTypes.Critical),
+				operator:     "equals",
+				minutes:      c.Critical.Duration,
+				timeFunction: string(alerts.TimeFunctionTypes.All),
+			})
+		}
+		return out, ""
+	default:
+		return nil, fmt.Sprintf("conditions of type %s can't be translated into NRQL, create the NRQL condition by hand", c.Type)
+	}
+
+	thresholds := []struct {
+		priority  string
+		threshold *alerts.InfrastructureConditionThreshold
+	}{
+		{string(alerts.PriorityTypes.Critical), c.Critical},
+		{string(alerts.PriorityTypes.Warning), c.Warning},
+	}
+	for _, t := range thresholds {
+		if t.threshold == nil {
+			continue
+		}
+		threshold := alertMigrationThreshold{
+			priority:     t.priority,
+			operator:     alertMigrationOperator(c.Comparison),
+			minutes:      t.threshold.Duration,
+			timeFunction: t.threshold.Function,
+		}
+		if t.threshold.Value != nil {
+			threshold.value = *t.threshold.Value
+		}
+		out.thresholds = append(out.thresholds, threshold)
+	}
+
+	return out, ""
+}
+
+func alertMigrationOperator(operator string) string {
+	if operator == string(alerts.OperatorTypes.Equal) {
+		return "equals"
+	}
+
+	return operator
+}
+
+func nrqlQuote(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", `\'`) + "'"
+}
+
+// addChannel translates a legacy channel into a notification destination and channel sent to by the workflow.
+func (m *alertMigration) addChannel(c *alerts.Channel) {
+	var destinationType, channelType string
+	var notes []string
+
+	switch c.Type {
+	case alerts.ChannelTypes.Email:
+		destinationType, channelType = "EMAIL", "EMAIL"
+		if c.Configuration.IncludeJSONAttachment == "true" {
+			notes = append(notes, "email destinations don't attach the issue as JSON")
+		}
+	case alerts.ChannelTypes.Webhook:
+		destinationType, channelType = "WEBHOOK", "WEBHOOK"
+		if len(c.Configuration.Payload) > 0 {
+			notes = append(notes, "the custom payload uses the variables of legacy channels, e.g. $CONDITION_NAME, rewrite it into the payload property with the Handlebars variables of workflows")
+		}
+		if len(c.Configuration.Headers) > 0 {
+			notes = append(notes, "the custom headers are not migrated as they may hold credentials, add them to the headers property of the notification channel")
+		}
+		if c.Configuration.PayloadType != "" && c.Configuration.PayloadType != "application/json" {
+			notes = append(notes, fmt.Sprintf("the payload type %s is not supported, payloads are sent as JSON", c.Configuration.PayloadType))
+		}
+	case alerts.ChannelTypes.PagerDuty:
+		destinationType, channelType = "PAGERDUTY_SERVICE_INTEGRATION", "PAGERDUTY_SERVICE_INTEGRATION"
+	case alerts.ChannelTypes.Slack:
+		m.note(alertMigrationChannelType, c.ID, c.Name, "Slack destinations can't be created by Terraform, connect Slack in New Relic, then import the destination and add a SLACK notification channel to the workflow")
+		return
+	case alerts.ChannelTypes.User:
+		m.note(alertMigrationChannelType, c.ID, c.Name, "user channels have no equivalent, add the email address of the user to an EMAIL destination")
+		return
+	case alerts.ChannelTypes.OpsGenie, alerts.ChannelTypes.VictorOps:
+		m.note(alertMigrationChannelType, c.ID, c.Name, fmt.Sprintf("there is no %s destination, send to the integration URL of %s with a WEBHOOK destination", c.Type, c.Type))
+		return
+	default:
+		m.note(alertMigrationChannelType, c.ID, c.Name, fmt.Sprintf("channels of type %s can't be translated", c.Type))
+		return
+	}
+
+	resourceName := m.resourceName("newrelic_notification_destination", c.Name)
+	body := m.file.Body()
+
+	// Secrets are never returned in full by the API, and are left to variables rather than written out
+	secret := ""
+	switch c.Type {
+	case alerts.ChannelTypes.Webhook:
+		if c.Configuration.AuthUsername != "" {
+			secret = resourceName + "_password"
+		}
+	case alerts.ChannelTypes.PagerDuty:
+		secret = resourceName + "_service_key"
+	}
+	if secret != "" {
+		variable := body.AppendNewBlock("variable", []string{secret}).Body()
+		variable.SetAttributeRaw("type", hclwrite.TokensForIdentifier("string"))
+		variable.SetAttributeValue("sensitive", cty.True)
+		body.AppendNewline()
+	}
+
+	destination := body.AppendNewBlock("resource", []string{"newrelic_notification_destination", resourceName}).Body()
+	destination.SetAttributeValue("name", cty.StringVal(c.Name))
+	destination.SetAttributeValue("type", cty.StringVal(destinationType))
+
+	switch c.Type {
+	case alerts.ChannelTypes.Email:
+		appendAlertMigrationProperty(destination, "email", c.Configuration.Recipients)
+	case alerts.ChannelTypes.Webhook:
+		appendAlertMigrationProperty(destination, "url", c.Configuration.BaseURL)
+		if secret != "" {
+			destination.AppendNewline()
+			auth := destination.AppendNewBlock("auth_basic", nil).Body()
+			auth.SetAttributeValue("user", cty.StringVal(c.Configuration.AuthUsername))
+			auth.SetAttributeTraversal("password", alertMigrationTraversal("var", secret))
+		}
+	case alerts.ChannelTypes.PagerDuty:
+		appendAlertMigrationProperty(destination, "", "")
+		destination.AppendNewline()
+		auth := destination.AppendNewBlock("auth_token", nil).Body()
+		auth.SetAttributeValue("prefix", cty.StringVal("Token token="))
+		auth.SetAttributeTraversal("token", alertMigrationTraversal("var", secret))
+	}
+	body.AppendNewline()
+
+	channel := body.AppendNewBlock("resource", []string{"newrelic_notification_channel", resourceName}).Body()
+	channel.SetAttributeValue("name", cty.StringVal(c.Name))
+	channel.SetAttributeValue("type", cty.StringVal(channelType))
+	channel.SetAttributeTraversal("destination_id", alertMigrationTraversal("newrelic_notification_destination", resourceName, "id"))
+	channel.SetAttributeValue("product", cty.StringVal("IINT"))
+
+	switch c.Type {
+	case alerts.ChannelTypes.Email:
+		appendAlertMigrationProperty(channel, "subject", "{{ issueTitle }}")
+	case alerts.ChannelTypes.Webhook:
+		appendAlertMigrationProperty(channel, "payload", alertMigrationWebhookPayload)
+	case alerts.ChannelTypes.PagerDuty:
+		appendAlertMigrationProperty(channel, "summary", "{{ annotations.title.[0] }}")
+	}
+	body.AppendNewline()
+
+	m.workflowChannels = append(m.workflowChannels, resourceName)
+
+	for _, n := range notes {
+		m.note(alertMigrationChannelType, c.ID, c.Name, n)
+	}
+
+	m.channels = append(m.channels, map[string]interface{}{
+		"source_id":        c.ID,
+		"name":             c.Name,
+		"type":             string(c.Type),
+		"destination_type": destinationType,
+		"resource_name":    resourceName,
+		"exact":            len(notes) == 0,
+	})
+}
+
+func appendAlertMigrationProperty(body *hclwrite.Body, key string, value string) {
+	body.AppendNewline()
+	property := body.AppendNewBlock("property", nil).Body()
+	property.SetAttributeValue("key", cty.StringVal(key))
+	property.SetAttributeValue("value", cty.StringVal(value))
+}
+
+func alertMigrationTraversal(root string, attributes ...string) hcl.Traversal {
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: root}}
+	for _, a := range attributes {
+		traversal = append(traversal, hcl.TraverseAttr{Name: a})
+	}
+
+	return traversal
+}
+
+// addWorkflow adds the workflow notifying the translated channels of the issues of the policy.
+func (m *alertMigration) addWorkflow() {
+	if len(m.workflowChannels) == 0 {
+		return
+	}
+
+	body := m.file.Body()
+	workflow := body.AppendNewBlock("resource", []string{"newrelic_workflow", m.resourceName("newrelic_workflow", m.policyName)}).Body()
+	workflow.SetAttributeValue("name", cty.StringVal(m.policyName))
+	workflow.SetAttributeValue("muting_rules_handling", cty.StringVal("NOTIFY_ALL_ISSUES"))
+
+	workflow.AppendNewline()
+	filter := workflow.AppendNewBlock("issues_filter", nil).Body()
+	filter.SetAttributeValue("name", cty.StringVal(m.policyName))
+	filter.SetAttributeValue("type", cty.StringVal("FILTER"))
+
+	filter.AppendNewline()
+	predicate := filter.AppendNewBlock("predicate", nil).Body()
+	predicate.SetAttributeValue("attribute", cty.StringVal("labels.policyIds"))
+	predicate.SetAttributeValue("operator", cty.StringVal("EXACTLY_MATCHES"))
+	predicate.SetAttributeValue("values", cty.ListVal([]cty.Value{cty.StringVal(fmt.Sprintf("%d", m.policyID))}))
+
+	for _, c := range m.workflowChannels {
+		workflow.AppendNewline()
+		workflow.AppendNewBlock("destination", nil).Body().
+			SetAttributeTraversal("channel_id", alertMigrationTraversal("newrelic_notification_channel", c, "id"))
+	}
+}
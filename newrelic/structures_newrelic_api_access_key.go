@@ -26,6 +26,14 @@ func resourceNewRelicAPIAccessKeySchema() map[string]*schema.Schema {
 		ResourceNewRelicAPIAccessKeyAttributeLabels.Name:       APIAccessKeysSchemaName,
 		ResourceNewRelicAPIAccessKeyAttributeLabels.Notes:      APIAccessKeysSchemaNotes,
 		ResourceNewRelicAPIAccessKeyAttributeLabels.Key:        APIAccessKeysSchemaKey,
+
+		ResourceNewRelicAPIAccessKeyAttributeLabels.RotationPeriod: APIAccessKeysSchemaRotationPeriod,
+		ResourceNewRelicAPIAccessKeyAttributeLabels.OverlapPeriod:  APIAccessKeysSchemaOverlapPeriod,
+		ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKey:     APIAccessKeysSchemaCurrentKey,
+		ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKeyID:   APIAccessKeysSchemaCurrentKeyID,
+		ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKey:    APIAccessKeysSchemaPreviousKey,
+		ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID:  APIAccessKeysSchemaPreviousKeyID,
+		ResourceNewRelicAPIAccessKeyAttributeLabels.RotatedAt:      APIAccessKeysSchemaRotatedAt,
 	}
 }
 
@@ -35,21 +43,35 @@ func resourceNewRelicAPIAccessKeySchema() map[string]*schema.Schema {
 // and not directly as a string isn't allowing them to be recognized as strings in the schema
 
 var ResourceNewRelicAPIAccessKeyAttributeLabels = struct {
-	AccountID  string
-	KeyType    string
-	UserID     string
-	IngestType string
-	Name       string
-	Notes      string
-	Key        string
+	AccountID      string
+	KeyType        string
+	UserID         string
+	IngestType     string
+	Name           string
+	Notes          string
+	Key            string
+	RotationPeriod string
+	OverlapPeriod  string
+	CurrentKey     string
+	CurrentKeyID   string
+	PreviousKey    string
+	PreviousKeyID  string
+	RotatedAt      string
 }{
-	AccountID:  "account_id",
-	KeyType:    "key_type",
-	UserID:     "user_id",
-	IngestType: "ingest_type",
-	Name:       "name",
-	Notes:      "notes",
-	Key:        "key",
+	AccountID:      "account_id",
+	KeyType:        "key_type",
+	UserID:         "user_id",
+	IngestType:     "ingest_type",
+	Name:           "name",
+	Notes:          "notes",
+	Key:            "key",
+	RotationPeriod: "rotation_period",
+	OverlapPeriod:  "overlap_period",
+	CurrentKey:     "current_key",
+	CurrentKeyID:   "current_key_id",
+	PreviousKey:    "previous_key",
+	PreviousKeyID:  "previous_key_id",
+	RotatedAt:      "rotated_at",
 }
 
 func validateAPIAccessKeyAttributes(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
@@ -249,3 +271,44 @@ var APIAccessKeysSchemaKey = &schema.Schema{
 	Type:     schema.TypeString,
 	Computed: true,
 }
+
+var APIAccessKeysSchemaRotationPeriod = &schema.Schema{
+	Type:         schema.TypeString,
+	Optional:     true,
+	Description:  "How often the key is rotated, e.g. `90d` or `720h`. Enables rotation mode.",
+	ValidateFunc: validateAPIAccessKeyPeriod,
+}
+
+var APIAccessKeysSchemaOverlapPeriod = &schema.Schema{
+	Type:         schema.TypeString,
+	Optional:     true,
+	Description:  "How long the previous key remains valid after a rotation, e.g. `7d` or `24h`. Must be shorter than `rotation_period`. Defaults to `24h` in rotation mode.",
+	ValidateFunc: validateAPIAccessKeyPeriod,
+}
+
+var APIAccessKeysSchemaCurrentKey = &schema.Schema{
+	Type:      schema.TypeString,
+	Computed:  true,
+	Sensitive: true,
+}
+
+var APIAccessKeysSchemaCurrentKeyID = &schema.Schema{
+	Type:     schema.TypeString,
+	Computed: true,
+}
+
+var APIAccessKeysSchemaPreviousKey = &schema.Schema{
+	Type:      schema.TypeString,
+	Computed:  true,
+	Sensitive: true,
+}
+
+var APIAccessKeysSchemaPreviousKeyID = &schema.Schema{
+	Type:     schema.TypeString,
+	Computed: true,
+}
+
+var APIAccessKeysSchemaRotatedAt = &schema.Schema{
+	Type:     schema.TypeString,
+	Computed: true,
+}
@@ -0,0 +1,220 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type apiAccessKeyRotationAction string
+
+var apiAccessKeyRotationActions = struct {
+	None           apiAccessKeyRotationAction
+	Start          apiAccessKeyRotationAction
+	Rotate         apiAccessKeyRotationAction
+	RevokePrevious apiAccessKeyRotationAction
+}{
+	None:           "",
+	Start:          "start",
+	Rotate:         "rotate",
+	RevokePrevious: "revoke_previous",
+}
+
+// apiAccessKeyRotation is the rotation state of a key, as recorded in state.
+type apiAccessKeyRotation struct {
+	// RotationPeriod is zero when rotation is disabled.
+	RotationPeriod time.Duration
+	OverlapPeriod  time.Duration
+	// RotatedAt is zero when the key has not been managed in rotation mode yet.
+	RotatedAt     time.Time
+	PreviousKeyID string
+}
+
+// Returns the action due at the given time. A rotation is due once the rotation
+// period elapsed since the last rotation, and the previous key is revoked once
+// the overlap period elapsed, or when rotation is disabled.
+func (r apiAccessKeyRotation) action(now time.Time) apiAccessKeyRotationAction {
+	if r.RotationPeriod == 0 {
+		if r.PreviousKeyID != "" {
+			return apiAccessKeyRotationActions.RevokePrevious
+		}
+		return apiAccessKeyRotationActions.None
+	}
+
+	if r.RotatedAt.IsZero() {
+		return apiAccessKeyRotationActions.Start
+	}
+
+	if !now.Before(r.RotatedAt.Add(r.RotationPeriod)) {
+		return apiAccessKeyRotationActions.Rotate
+	}
+
+	if r.PreviousKeyID != "" && !now.Before(r.RotatedAt.Add(r.OverlapPeriod)) {
+		return apiAccessKeyRotationActions.RevokePrevious
+	}
+
+	return apiAccessKeyRotationActions.None
+}
+
+// Parses a period given in days, e.g. `90d`, or as a Go duration, e.g. `36h`.
+func parseAPIAccessKeyPeriod(in string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(in, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid period %q, expected a positive number of days such as 90d, or a duration such as 36h", in)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	period, err := time.ParseDuration(in)
+	if err != nil || period <= 0 {
+		return 0, fmt.Errorf("invalid period %q, expected a positive number of days such as 90d, or a duration such as 36h", in)
+	}
+
+	return period, nil
+}
+
+func validateAPIAccessKeyPeriod(v interface{}, k string) (warnings []string, errs []error) {
+	if _, err := parseAPIAccessKeyPeriod(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", k, err))
+	}
+
+	return warnings, errs
+}
+
+// The overlap period of keys rotated without an overlap_period. It is not a schema default, so that keys
+// which are not rotated, including the ones created before rotation existed, don't plan a change to it.
+const apiAccessKeyDefaultOverlapPeriod = "24h"
+
+func expandAPIAccessKeyRotationPeriods(rotationPeriod string, overlapPeriod string) (time.Duration, time.Duration, error) {
+	if rotationPeriod == "" {
+		return 0, 0, nil
+	}
+
+	if overlapPeriod == "" {
+		overlapPeriod = apiAccessKeyDefaultOverlapPeriod
+	}
+
+	rotation, err := parseAPIAccessKeyPeriod(rotationPeriod)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	overlap, err := parseAPIAccessKeyPeriod(overlapPeriod)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if overlap >= rotation {
+		return 0, 0, fmt.Errorf("the `overlap_period` (%s) must be shorter than the `rotation_period` (%s)", overlapPeriod, rotationPeriod)
+	}
+
+	return rotation, overlap, nil
+}
+
+type apiAccessKeyAttributeGetter interface {
+	Get(string) interface{}
+}
+
+func expandAPIAccessKeyRotation(d apiAccessKeyAttributeGetter) (*apiAccessKeyRotation, error) {
+	rotation, overlap, err := expandAPIAccessKeyRotationPeriods(
+		d.Get(ResourceNewRelicAPIAccessKeyAttributeLabels.RotationPeriod).(string),
+		d.Get(ResourceNewRelicAPIAccessKeyAttributeLabels.OverlapPeriod).(string),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &apiAccessKeyRotation{
+		RotationPeriod: rotation,
+		OverlapPeriod:  overlap,
+		PreviousKeyID:  d.Get(ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID).(string),
+	}
+
+	if rotatedAt := d.Get(ResourceNewRelicAPIAccessKeyAttributeLabels.RotatedAt).(string); rotatedAt != "" {
+		r.RotatedAt, err = time.Parse(time.RFC3339, rotatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `rotated_at` in state: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+// Plans the rotation of the key by comparing the time of the plan with the time
+// of the last rotation, so rotations happen on a regular apply.
+func customizeAPIAccessKeyRotationDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rotation, err := expandAPIAccessKeyRotation(d)
+	if err != nil {
+		return err
+	}
+
+	// Nothing to rotate in a key that is about to be created or replaced.
+	if d.Id() == "" {
+		return nil
+	}
+	for _, k := range []string{
+		ResourceNewRelicAPIAccessKeyAttributeLabels.AccountID,
+		ResourceNewRelicAPIAccessKeyAttributeLabels.KeyType,
+		ResourceNewRelicAPIAccessKeyAttributeLabels.IngestType,
+		ResourceNewRelicAPIAccessKeyAttributeLabels.UserID,
+	} {
+		if d.HasChange(k) {
+			return nil
+		}
+	}
+
+	action := rotation.action(time.Now())
+	log.Printf("[DEBUG] api access key %s rotation action: %q", d.Id(), action)
+
+	switch action {
+	// The values are only known once applied, so the plan does not depend on its time.
+	case apiAccessKeyRotationActions.Start:
+		for _, k := range []string{
+			ResourceNewRelicAPIAccessKeyAttributeLabels.RotatedAt,
+			ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKeyID,
+			ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKey,
+		} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	case apiAccessKeyRotationActions.Rotate:
+		for _, k := range []string{
+			ResourceNewRelicAPIAccessKeyAttributeLabels.Key,
+			ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKey,
+			ResourceNewRelicAPIAccessKeyAttributeLabels.CurrentKeyID,
+			ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKey,
+			ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID,
+			ResourceNewRelicAPIAccessKeyAttributeLabels.RotatedAt,
+		} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	case apiAccessKeyRotationActions.RevokePrevious:
+		for _, k := range []string{
+			ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKey,
+			ResourceNewRelicAPIAccessKeyAttributeLabels.PreviousKeyID,
+		} {
+			if err := d.SetNew(k, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func customizeAPIAccessKeyDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateAPIAccessKeyAttributes(ctx, d, meta); err != nil {
+		return err
+	}
+
+	return customizeAPIAccessKeyRotationDiff(ctx, d, meta)
+}
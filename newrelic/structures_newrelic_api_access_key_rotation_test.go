@@ -0,0 +1,145 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAPIAccessKeyPeriod(t *testing.T) {
+	period, err := parseAPIAccessKeyPeriod("90d")
+	require.NoError(t, err)
+	require.Equal(t, 90*24*time.Hour, period)
+
+	period, err = parseAPIAccessKeyPeriod("36h")
+	require.NoError(t, err)
+	require.Equal(t, 36*time.Hour, period)
+
+	for _, invalid := range []string{"", "d", "0d", "-1d", "1.5d", "0h", "90 days"} {
+		_, err := parseAPIAccessKeyPeriod(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestExpandAPIAccessKeyRotationPeriods(t *testing.T) {
+	rotation, overlap, err := expandAPIAccessKeyRotationPeriods("30d", "24h")
+	require.NoError(t, err)
+	require.Equal(t, 30*24*time.Hour, rotation)
+	require.Equal(t, 24*time.Hour, overlap)
+
+	// The overlap period defaults to 24h in rotation mode
+	rotation, overlap, err = expandAPIAccessKeyRotationPeriods("30d", "")
+	require.NoError(t, err)
+	require.Equal(t, 30*24*time.Hour, rotation)
+	require.Equal(t, 24*time.Hour, overlap)
+
+	_, _, err = expandAPIAccessKeyRotationPeriods("1d", "")
+	require.ErrorContains(t, err, "the `overlap_period` (24h) must be shorter")
+
+	// Rotation disabled
+	rotation, overlap, err = expandAPIAccessKeyRotationPeriods("", "24h")
+	require.NoError(t, err)
+	require.Zero(t, rotation)
+	require.Zero(t, overlap)
+
+	_, _, err = expandAPIAccessKeyRotationPeriods("1d", "24h")
+	require.ErrorContains(t, err, "must be shorter")
+}
+
+func TestAPIAccessKeyRotation_Action(t *testing.T) {
+	rotatedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		rotation apiAccessKeyRotation
+		now      time.Time
+		expected apiAccessKeyRotationAction
+	}{
+		"rotation disabled": {
+			rotation: apiAccessKeyRotation{},
+			now:      rotatedAt,
+			expected: apiAccessKeyRotationActions.None,
+		},
+		"rotation disabled with a previous key": {
+			rotation: apiAccessKeyRotation{PreviousKeyID: "previous"},
+			now:      rotatedAt,
+			expected: apiAccessKeyRotationActions.RevokePrevious,
+		},
+		"rotation enabled on an existing key": {
+			rotation: apiAccessKeyRotation{RotationPeriod: 30 * 24 * time.Hour, OverlapPeriod: 24 * time.Hour},
+			now:      rotatedAt,
+			expected: apiAccessKeyRotationActions.Start,
+		},
+		"within the overlap period": {
+			rotation: apiAccessKeyRotation{RotationPeriod: 30 * 24 * time.Hour, OverlapPeriod: 24 * time.Hour, RotatedAt: rotatedAt, PreviousKeyID: "previous"},
+			now:      rotatedAt.Add(23 * time.Hour),
+			expected: apiAccessKeyRotationActions.None,
+		},
+		"overlap period elapsed": {
+			rotation: apiAccessKeyRotation{RotationPeriod: 30 * 24 * time.Hour, OverlapPeriod: 24 * time.Hour, RotatedAt: rotatedAt, PreviousKeyID: "previous"},
+			now:      rotatedAt.Add(24 * time.Hour),
+			expected: apiAccessKeyRotationActions.RevokePrevious,
+		},
+		"overlap period elapsed without a previous key": {
+			rotation: apiAccessKeyRotation{RotationPeriod: 30 * 24 * time.Hour, OverlapPeriod: 24 * time.Hour, RotatedAt: rotatedAt},
+			now:      rotatedAt.Add(48 * time.Hour),
+			expected: apiAccessKeyRotationActions.None,
+		},
+		"rotation period elapsed": {
+			rotation: apiAccessKeyRotation{RotationPeriod: 30 * 24 * time.Hour, OverlapPeriod: 24 * time.Hour, RotatedAt: rotatedAt, PreviousKeyID: "previous"},
+			now:      rotatedAt.Add(30 * 24 * time.Hour),
+			expected: apiAccessKeyRotationActions.Rotate,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.rotation.action(tc.now))
+		})
+	}
+}
+
+func TestCustomizeAPIAccessKeyRotationDiff_StartIsStable(t *testing.T) {
+	r := resourceNewRelicAPIAccessKey()
+	r.CustomizeDiff = customizeAPIAccessKeyRotationDiff
+
+	// A key created before rotation mode was enabled.
+	state := &terraform.InstanceState{
+		ID: "key-1",
+		Attributes: map[string]string{
+			"id":          "key-1",
+			"account_id":  "1",
+			"key_type":    "USER",
+			"user_id":     "2",
+			"name":        "key",
+			"key":         "NRAK-1",
+			"ingest_type": "",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id":      1,
+		"key_type":        "USER",
+		"user_id":         2,
+		"name":            "key",
+		"rotation_period": "30d",
+		"overlap_period":  "1d",
+	})
+
+	first, err := r.Diff(context.Background(), state, config, nil)
+	require.NoError(t, err)
+
+	// Past the second resolution of rotated_at.
+	time.Sleep(1100 * time.Millisecond)
+
+	second, err := r.Diff(context.Background(), state, config, nil)
+	require.NoError(t, err)
+
+	for _, k := range []string{"rotated_at", "current_key_id", "current_key"} {
+		require.True(t, first.Attributes[k].NewComputed, k)
+		require.Equal(t, first.Attributes[k], second.Attributes[k], k)
+	}
+}
@@ -0,0 +1,523 @@
+package newrelic
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/usermanagement"
+)
+
+// Certificates expiring within this window are reported with a warning when applied.
+const samlCertificateExpiryWarningWindow = 30 * 24 * time.Hour
+
+var authenticationDomainUserUpgradeRequestPolicies = struct {
+	ManualReview      string
+	AutomaticApproval string
+}{
+	ManualReview:      "MANUAL_REVIEW",
+	AutomaticApproval: "AUTOMATIC_APPROVAL",
+}
+
+// Signature algorithms no longer accepted for signing SAML assertions.
+var weakSamlSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.MD2WithRSA:    true,
+	x509.MD5WithRSA:    true,
+	x509.SHA1WithRSA:   true,
+	x509.DSAWithSHA1:   true,
+	x509.ECDSAWithSHA1: true,
+}
+
+// Signature methods no longer accepted for signed SAML metadata.
+var weakSamlSignatureMethods = []string{
+	"http://www.w3.org/2000/09/xmldsig#rsa-sha1",
+	"http://www.w3.org/2000/09/xmldsig#dsa-sha1",
+	"http://www.w3.org/2001/04/xmldsig-more#rsa-md5",
+	"http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha1",
+}
+
+// samlMetadata is the subset of a SAML 2.0 identity provider metadata document
+// used to configure SAML SSO.
+type samlMetadata struct {
+	XMLName          xml.Name `xml:"EntityDescriptor"`
+	EntityID         string   `xml:"entityID,attr"`
+	IDPSSODescriptor *struct {
+		KeyDescriptors []struct {
+			Use             string `xml:"use,attr"`
+			X509Certificate string `xml:"KeyInfo>X509Data>X509Certificate"`
+		} `xml:"KeyDescriptor"`
+		SingleSignOnServices []samlEndpoint `xml:"SingleSignOnService"`
+		SingleLogoutServices []samlEndpoint `xml:"SingleLogoutService"`
+	} `xml:"IDPSSODescriptor"`
+	Signature *struct {
+		SignatureMethod struct {
+			Algorithm string `xml:"Algorithm,attr"`
+		} `xml:"SignedInfo>SignatureMethod"`
+	} `xml:"Signature"`
+}
+
+type samlEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+// samlConfiguration is the SAML SSO configuration of an authentication domain,
+// either set directly or extracted from identity provider metadata.
+type samlConfiguration struct {
+	EntityID    string
+	SsoURL      string
+	LogoutURL   string
+	Certificate *x509.Certificate
+}
+
+// Parses a certificate in PEM format, or the base64 encoded DER format used
+// in SAML metadata.
+func parseSamlCertificate(in string) (*x509.Certificate, error) {
+	in = strings.TrimSpace(in)
+
+	if block, _ := pem.Decode([]byte(in)); block != nil {
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("expected a CERTIFICATE PEM block, got %s", block.Type)
+		}
+		return x509.ParseCertificate(block.Bytes)
+	}
+
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(in), ""))
+	if err != nil {
+		return nil, fmt.Errorf("certificate is neither PEM nor base64 encoded DER")
+	}
+
+	return x509.ParseCertificate(der)
+}
+
+// Checks a SAML signing certificate is not signed with a weak algorithm. Its
+// validity period is checked when applied, see samlCertificateExpiryWarning.
+func validateSamlCertificate(cert *x509.Certificate) error {
+	if weakSamlSignatureAlgorithms[cert.SignatureAlgorithm] {
+		return fmt.Errorf("certificate %q is signed with %s, which is not supported; use a SHA-256 or stronger signature algorithm", cert.Subject.CommonName, cert.SignatureAlgorithm)
+	}
+
+	return nil
+}
+
+// Returns a warning when a SAML signing certificate is not valid at the given
+// time, or expires soon.
+func samlCertificateExpiryWarning(cert *x509.Certificate, now time.Time) string {
+	if now.Before(cert.NotBefore) {
+		return fmt.Sprintf("certificate %q is not valid before %s", cert.Subject.CommonName, cert.NotBefore.Format(time.RFC3339))
+	}
+
+	if now.After(cert.NotAfter) {
+		return fmt.Sprintf("certificate %q expired on %s", cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+	}
+
+	if cert.NotAfter.Sub(now) < samlCertificateExpiryWarningWindow {
+		return fmt.Sprintf("certificate %q expires on %s", cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+	}
+
+	return ""
+}
+
+// Extracts the SAML SSO configuration from identity provider metadata, and
+// validates its signing certificate.
+func expandSamlMetadata(in string) (*samlConfiguration, error) {
+	var metadata samlMetadata
+	if err := xml.Unmarshal([]byte(in), &metadata); err != nil {
+		return nil, fmt.Errorf("invalid SAML metadata: %w", err)
+	}
+
+	if metadata.IDPSSODescriptor == nil {
+		return nil, fmt.Errorf("invalid SAML metadata: no IDPSSODescriptor found")
+	}
+
+	if metadata.Signature != nil {
+		for _, method := range weakSamlSignatureMethods {
+			if metadata.Signature.SignatureMethod.Algorithm == method {
+				return nil, fmt.Errorf("SAML metadata is signed with %s, which is not supported; use a SHA-256 or stronger signature method", method)
+			}
+		}
+	}
+
+	config := &samlConfiguration{EntityID: metadata.EntityID}
+
+	// Prefer the HTTP-Redirect binding, which is used to send authentication requests.
+	for _, sso := range metadata.IDPSSODescriptor.SingleSignOnServices {
+		if config.SsoURL == "" || strings.HasSuffix(sso.Binding, ":HTTP-Redirect") {
+			config.SsoURL = sso.Location
+		}
+	}
+	if config.SsoURL == "" {
+		return nil, fmt.Errorf("invalid SAML metadata: no SingleSignOnService location found")
+	}
+
+	if len(metadata.IDPSSODescriptor.SingleLogoutServices) > 0 {
+		config.LogoutURL = metadata.IDPSSODescriptor.SingleLogoutServices[0].Location
+	}
+
+	for _, key := range metadata.IDPSSODescriptor.KeyDescriptors {
+		// Keys without a use are used for both signing and encryption.
+		if key.Use != "" && key.Use != "signing" {
+			continue
+		}
+
+		cert, err := parseSamlCertificate(key.X509Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SAML metadata signing certificate: %w", err)
+		}
+
+		if err := validateSamlCertificate(cert); err != nil {
+			return nil, err
+		}
+
+		if config.Certificate == nil {
+			config.Certificate = cert
+		}
+	}
+
+	if config.Certificate == nil {
+		return nil, fmt.Errorf("invalid SAML metadata: no signing certificate found")
+	}
+
+	return config, nil
+}
+
+func validateSamlMetadataXML(v interface{}, k string) (warnings []string, errs []error) {
+	if _, err := expandSamlMetadata(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", k, err))
+	}
+
+	return nil, errs
+}
+
+func validateSamlCertificatePEM(v interface{}, k string) (warnings []string, errs []error) {
+	cert, err := parseSamlCertificate(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+
+	if err := validateSamlCertificate(cert); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", k, err))
+	}
+
+	return nil, errs
+}
+
+// Returns the SAML configuration of the saml block, from either its metadata or
+// its individual arguments.
+func expandAuthenticationDomainSaml(in []interface{}) (*samlConfiguration, error) {
+	if len(in) == 0 || in[0] == nil {
+		return nil, nil
+	}
+
+	cfg := in[0].(map[string]interface{})
+
+	if metadata, ok := cfg["metadata_xml"].(string); ok && metadata != "" {
+		return expandSamlMetadata(metadata)
+	}
+
+	config := &samlConfiguration{
+		EntityID:  cfg["entity_id"].(string),
+		SsoURL:    cfg["sso_url"].(string),
+		LogoutURL: cfg["logout_url"].(string),
+	}
+
+	if certificate, ok := cfg["certificate"].(string); ok && certificate != "" {
+		cert, err := parseSamlCertificate(certificate)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateSamlCertificate(cert); err != nil {
+			return nil, err
+		}
+		config.Certificate = cert
+	}
+
+	if config.SsoURL == "" || config.Certificate == nil {
+		return nil, fmt.Errorf("saml: either metadata_xml, or both sso_url and certificate must be set")
+	}
+
+	return config, nil
+}
+
+// Warns about the validity period of the signing certificate of the saml block.
+// It depends on the current time, so it is only checked when the certificate is
+// created or updated rather than in a ValidateFunc, which would make plans of
+// unchanged configurations fail once it expires. Diffs cannot return warnings,
+// so the warning is returned when applied.
+func samlCertificateExpiryDiagnostics(saml []interface{}, now time.Time) diag.Diagnostics {
+	config, err := expandAuthenticationDomainSaml(saml)
+	if err != nil || config == nil || config.Certificate == nil {
+		return nil
+	}
+
+	warning := samlCertificateExpiryWarning(config.Certificate, now)
+	if warning == "" {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "SAML signing certificate validity",
+			Detail:   warning,
+		},
+	}
+}
+
+func encodeSamlCertificate(cert *x509.Certificate) string {
+	if cert == nil {
+		return ""
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+// Authentication domain settings are not covered by the NerdGraph client yet,
+// so they are managed with direct queries.
+const authenticationDomainFields = `
+	id
+	name
+	authenticationType
+	provisioningType
+	userUpgradeRequestPolicy
+	session {
+		idleTimeoutMinutes
+		maxDurationHours
+	}
+	saml {
+		entityId
+		ssoUrl
+		logoutUrl
+		certificate
+	}`
+
+const getAuthenticationDomainQuery = `query(
+	$id: [ID!],
+) { actor { organization { userManagement { authenticationDomains(
+	id: $id,
+) {
+	authenticationDomains {` + authenticationDomainFields + `
+	}
+} } } } }`
+
+const authenticationDomainCreateMutation = `mutation(
+	$authenticationDomain: UserManagementAuthenticationDomainInput!,
+) { userManagementCreateAuthenticationDomain(
+	authenticationDomain: $authenticationDomain,
+) {
+	authenticationDomain {` + authenticationDomainFields + `
+	}
+	scimBearerToken
+} }`
+
+const authenticationDomainUpdateMutation = `mutation(
+	$id: ID!,
+	$authenticationDomain: UserManagementAuthenticationDomainInput!,
+) { userManagementUpdateAuthenticationDomain(
+	id: $id,
+	authenticationDomain: $authenticationDomain,
+) {
+	authenticationDomain {` + authenticationDomainFields + `
+	}
+} }`
+
+const authenticationDomainDeleteMutation = `mutation(
+	$id: ID!,
+) { userManagementDeleteAuthenticationDomain(
+	id: $id,
+) {
+	authenticationDomain {
+		id
+	}
+} }`
+
+const authenticationDomainGenerateScimBearerTokenMutation = `mutation(
+	$id: ID!,
+) { userManagementGenerateScimBearerToken(
+	authenticationDomainId: $id,
+) {
+	scimBearerToken
+} }`
+
+type authenticationDomainSession struct {
+	IdleTimeoutMinutes *int `json:"idleTimeoutMinutes"`
+	MaxDurationHours   *int `json:"maxDurationHours"`
+}
+
+type authenticationDomainSaml struct {
+	EntityID    string `json:"entityId,omitempty"`
+	SsoURL      string `json:"ssoUrl,omitempty"`
+	LogoutURL   string `json:"logoutUrl,omitempty"`
+	Certificate string `json:"certificate,omitempty"`
+}
+
+type authenticationDomain struct {
+	ID                       string                      `json:"id,omitempty"`
+	Name                     string                      `json:"name"`
+	AuthenticationType       string                      `json:"authenticationType"`
+	ProvisioningType         string                      `json:"provisioningType"`
+	UserUpgradeRequestPolicy string                      `json:"userUpgradeRequestPolicy"`
+	Session                  authenticationDomainSession `json:"session"`
+	Saml                     *authenticationDomainSaml   `json:"saml,omitempty"`
+}
+
+func expandAuthenticationDomain(d *schema.ResourceData) (*authenticationDomain, error) {
+	domain := &authenticationDomain{
+		Name:                     d.Get("name").(string),
+		AuthenticationType:       d.Get("authentication_type").(string),
+		ProvisioningType:         d.Get("provisioning_type").(string),
+		UserUpgradeRequestPolicy: d.Get("user_upgrade_request_policy").(string),
+	}
+
+	if v, ok := d.GetOk("session_idle_timeout_minutes"); ok {
+		timeout := v.(int)
+		domain.Session.IdleTimeoutMinutes = &timeout
+	}
+
+	if v, ok := d.GetOk("session_max_duration_hours"); ok {
+		duration := v.(int)
+		domain.Session.MaxDurationHours = &duration
+	}
+
+	saml, err := expandAuthenticationDomainSaml(d.Get("saml").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	if domain.AuthenticationType == string(usermanagement.OrganizationAuthenticationTypeEnumTypes.SAML_SSO) {
+		if saml == nil {
+			return nil, fmt.Errorf("a saml block is required when authentication_type is %s", domain.AuthenticationType)
+		}
+
+		domain.Saml = &authenticationDomainSaml{
+			EntityID:    saml.EntityID,
+			SsoURL:      saml.SsoURL,
+			LogoutURL:   saml.LogoutURL,
+			Certificate: encodeSamlCertificate(saml.Certificate),
+		}
+	}
+
+	return domain, nil
+}
+
+// Flattens the saml block, keeping the metadata of the configuration since it is
+// not returned by NerdGraph.
+func flattenAuthenticationDomainSaml(in *authenticationDomainSaml, metadataXML string) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"metadata_xml": metadataXML,
+			"entity_id":    in.EntityID,
+			"sso_url":      in.SsoURL,
+			"logout_url":   in.LogoutURL,
+			"certificate":  in.Certificate,
+		},
+	}
+}
+
+func getAuthenticationDomain(ctx context.Context, client *newrelic.NewRelic, id string) (*authenticationDomain, error) {
+	resp := struct {
+		Actor struct {
+			Organization struct {
+				UserManagement struct {
+					AuthenticationDomains struct {
+						AuthenticationDomains []authenticationDomain `json:"authenticationDomains"`
+					} `json:"authenticationDomains"`
+				} `json:"userManagement"`
+			} `json:"organization"`
+		} `json:"actor"`
+	}{}
+	vars := map[string]interface{}{
+		"id": []string{id},
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, getAuthenticationDomainQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	for _, domain := range resp.Actor.Organization.UserManagement.AuthenticationDomains.AuthenticationDomains {
+		if domain.ID == id {
+			return &domain, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func createAuthenticationDomain(ctx context.Context, client *newrelic.NewRelic, domain *authenticationDomain) (*authenticationDomain, string, error) {
+	resp := struct {
+		UserManagementCreateAuthenticationDomain struct {
+			AuthenticationDomain authenticationDomain `json:"authenticationDomain"`
+			ScimBearerToken      string               `json:"scimBearerToken"`
+		} `json:"userManagementCreateAuthenticationDomain"`
+	}{}
+	vars := map[string]interface{}{
+		"authenticationDomain": domain,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, authenticationDomainCreateMutation, vars, &resp); err != nil {
+		return nil, "", err
+	}
+
+	created := resp.UserManagementCreateAuthenticationDomain
+	if created.AuthenticationDomain.ID == "" {
+		return nil, "", fmt.Errorf("error creating authentication domain %q: no ID returned", domain.Name)
+	}
+
+	return &created.AuthenticationDomain, created.ScimBearerToken, nil
+}
+
+func updateAuthenticationDomain(ctx context.Context, client *newrelic.NewRelic, id string, domain *authenticationDomain) error {
+	resp := struct {
+		UserManagementUpdateAuthenticationDomain struct {
+			AuthenticationDomain authenticationDomain `json:"authenticationDomain"`
+		} `json:"userManagementUpdateAuthenticationDomain"`
+	}{}
+	vars := map[string]interface{}{
+		"id":                   id,
+		"authenticationDomain": domain,
+	}
+
+	return client.NerdGraph.QueryWithResponseAndContext(ctx, authenticationDomainUpdateMutation, vars, &resp)
+}
+
+func deleteAuthenticationDomain(ctx context.Context, client *newrelic.NewRelic, id string) error {
+	resp := struct {
+		UserManagementDeleteAuthenticationDomain struct {
+			AuthenticationDomain authenticationDomain `json:"authenticationDomain"`
+		} `json:"userManagementDeleteAuthenticationDomain"`
+	}{}
+	vars := map[string]interface{}{
+		"id": id,
+	}
+
+	return client.NerdGraph.QueryWithResponseAndContext(ctx, authenticationDomainDeleteMutation, vars, &resp)
+}
+
+func generateAuthenticationDomainScimBearerToken(ctx context.Context, client *newrelic.NewRelic, id string) (string, error) {
+	resp := struct {
+		UserManagementGenerateScimBearerToken struct {
+			ScimBearerToken string `json:"scimBearerToken"`
+		} `json:"userManagementGenerateScimBearerToken"`
+	}{}
+	vars := map[string]interface{}{
+		"id": id,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, authenticationDomainGenerateScimBearerTokenMutation, vars, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.UserManagementGenerateScimBearerToken.ScimBearerToken, nil
+}
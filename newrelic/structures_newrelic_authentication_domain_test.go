@@ -0,0 +1,321 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/stretchr/testify/require"
+)
+
+var testSamlNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testSamlCertificate(t *testing.T, notBefore time.Time, notAfter time.Time) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func testSamlMetadata(cert *x509.Certificate, signatureMethod string) string {
+	signature := ""
+	if signatureMethod != "" {
+		signature = fmt.Sprintf(`
+  <ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+    <ds:SignedInfo>
+      <ds:SignatureMethod Algorithm="%s"/>
+    </ds:SignedInfo>
+  </ds:Signature>`, signatureMethod)
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com/metadata">%s
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="encryption">
+      <ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+        <ds:X509Data><ds:X509Certificate>not a certificate</ds:X509Certificate></ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+        <ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/logout"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso/redirect"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, signature, base64.StdEncoding.EncodeToString(cert.Raw))
+}
+
+func TestParseSamlCertificate(t *testing.T) {
+	cert := testSamlCertificate(t, testSamlNow.AddDate(-1, 0, 0), testSamlNow.AddDate(1, 0, 0))
+
+	parsed, err := parseSamlCertificate(encodeSamlCertificate(cert))
+	require.NoError(t, err)
+	require.Equal(t, cert.Raw, parsed.Raw)
+
+	// Base64 encoded DER, wrapped as in SAML metadata
+	encoded := base64.StdEncoding.EncodeToString(cert.Raw)
+	parsed, err = parseSamlCertificate(encoded[:40] + "\n  " + encoded[40:])
+	require.NoError(t, err)
+	require.Equal(t, cert.Raw, parsed.Raw)
+
+	_, err = parseSamlCertificate(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})))
+	require.Error(t, err)
+
+	_, err = parseSamlCertificate("not a certificate")
+	require.Error(t, err)
+}
+
+func TestValidateSamlCertificate(t *testing.T) {
+	valid := testSamlCertificate(t, testSamlNow.AddDate(-1, 0, 0), testSamlNow.AddDate(1, 0, 0))
+	require.NoError(t, validateSamlCertificate(valid))
+
+	// The validity period is not checked, since it depends on the current time.
+	expired := testSamlCertificate(t, testSamlNow.AddDate(-2, 0, 0), testSamlNow.AddDate(-1, 0, 0))
+	require.NoError(t, validateSamlCertificate(expired))
+
+	weak := *valid
+	weak.SignatureAlgorithm = x509.SHA1WithRSA
+	require.ErrorContains(t, validateSamlCertificate(&weak), "SHA1-RSA")
+}
+
+func TestSamlCertificateExpiryWarning(t *testing.T) {
+	valid := testSamlCertificate(t, testSamlNow.AddDate(-1, 0, 0), testSamlNow.AddDate(1, 0, 0))
+	require.Empty(t, samlCertificateExpiryWarning(valid, testSamlNow))
+
+	expiringSoon := testSamlCertificate(t, testSamlNow.AddDate(-1, 0, 0), testSamlNow.AddDate(0, 0, 10))
+	require.Contains(t, samlCertificateExpiryWarning(expiringSoon, testSamlNow), "expires on 2026-01-11")
+
+	expired := testSamlCertificate(t, testSamlNow.AddDate(-2, 0, 0), testSamlNow.AddDate(-1, 0, 0))
+	require.Contains(t, samlCertificateExpiryWarning(expired, testSamlNow), "expired on 2025-01-01")
+
+	notYetValid := testSamlCertificate(t, testSamlNow.AddDate(0, 1, 0), testSamlNow.AddDate(1, 0, 0))
+	require.Contains(t, samlCertificateExpiryWarning(notYetValid, testSamlNow), "is not valid before")
+}
+
+func TestSamlCertificateExpiryDiagnostics(t *testing.T) {
+	expired := testSamlCertificate(t, testSamlNow.AddDate(-2, 0, 0), testSamlNow.AddDate(-1, 0, 0))
+	saml := []interface{}{
+		map[string]interface{}{
+			"metadata_xml": testSamlMetadata(expired, ""),
+		},
+	}
+
+	diags := samlCertificateExpiryDiagnostics(saml, testSamlNow)
+	require.Len(t, diags, 1)
+	require.Equal(t, diag.Warning, diags[0].Severity)
+	require.Contains(t, diags[0].Detail, "expired on 2025-01-01")
+
+	require.Empty(t, samlCertificateExpiryDiagnostics(saml, testSamlNow.AddDate(-1, -6, 0)))
+	require.Empty(t, samlCertificateExpiryDiagnostics([]interface{}{}, testSamlNow))
+}
+
+func TestExpandSamlMetadata(t *testing.T) {
+	cert := testSamlCertificate(t, testSamlNow.AddDate(-1, 0, 0), testSamlNow.AddDate(1, 0, 0))
+
+	config, err := expandSamlMetadata(testSamlMetadata(cert, "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"))
+	require.NoError(t, err)
+	require.Equal(t, "https://idp.example.com/metadata", config.EntityID)
+	require.Equal(t, "https://idp.example.com/sso/redirect", config.SsoURL)
+	require.Equal(t, "https://idp.example.com/logout", config.LogoutURL)
+	require.Equal(t, cert.Raw, config.Certificate.Raw)
+
+	_, err = expandSamlMetadata(testSamlMetadata(cert, "http://www.w3.org/2000/09/xmldsig#rsa-sha1"))
+	require.ErrorContains(t, err, "rsa-sha1")
+
+	// Expired certificates are warned about when applied.
+	expired := testSamlCertificate(t, testSamlNow.AddDate(-2, 0, 0), testSamlNow.AddDate(-1, 0, 0))
+	_, err = expandSamlMetadata(testSamlMetadata(expired, ""))
+	require.NoError(t, err)
+
+	_, err = expandSamlMetadata(`<EntityDescriptor entityID="x"></EntityDescriptor>`)
+	require.ErrorContains(t, err, "no IDPSSODescriptor")
+
+	_, err = expandSamlMetadata(`<EntityDescriptor entityID="x"><IDPSSODescriptor><SingleSignOnService Location="https://idp"/></IDPSSODescriptor></EntityDescriptor>`)
+	require.ErrorContains(t, err, "no signing certificate")
+
+	_, err = expandSamlMetadata(`not xml`)
+	require.Error(t, err)
+}
+
+func TestSuppressEquivalentSamlCertificate(t *testing.T) {
+	cert := testSamlCertificate(t, testSamlNow.AddDate(-1, 0, 0), testSamlNow.AddDate(1, 0, 0))
+	other := testSamlCertificate(t, testSamlNow.AddDate(-1, 0, 0), testSamlNow.AddDate(1, 0, 0))
+
+	require.True(t, suppressEquivalentSamlCertificate("", encodeSamlCertificate(cert), base64.StdEncoding.EncodeToString(cert.Raw), nil))
+	require.False(t, suppressEquivalentSamlCertificate("", encodeSamlCertificate(cert), encodeSamlCertificate(other), nil))
+	require.False(t, suppressEquivalentSamlCertificate("", "", encodeSamlCertificate(cert), nil))
+}
+
+// Serves the given NerdGraph responses in order, and records the requests.
+func testAuthenticationDomainNerdGraph(t *testing.T, responses ...string) (*newrelic.NewRelic, *[]testNerdGraphRequest) {
+	var requests []testNerdGraphRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request testNerdGraphRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		require.Less(t, len(requests), len(responses), "unexpected request: %s", request.Query)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(responses[len(requests)]))
+		requests = append(requests, request)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := newrelic.New(newrelic.ConfigPersonalAPIKey("NRAK-TEST"), newrelic.ConfigNerdGraphBaseURL(server.URL))
+	require.NoError(t, err)
+
+	return client, &requests
+}
+
+type testNerdGraphRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+func TestCreateAuthenticationDomain(t *testing.T) {
+	client, requests := testAuthenticationDomainNerdGraph(t, `{"data": {"userManagementCreateAuthenticationDomain": {
+		"authenticationDomain": {
+			"id": "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c",
+			"name": "Okta",
+			"authenticationType": "SAML_SSO",
+			"provisioningType": "SCIM",
+			"userUpgradeRequestPolicy": "MANUAL_REVIEW",
+			"session": {"idleTimeoutMinutes": 30, "maxDurationHours": null},
+			"saml": {"entityId": "https://idp.example.com/metadata", "ssoUrl": "https://idp.example.com/sso", "logoutUrl": null, "certificate": "-----BEGIN CERTIFICATE-----"}
+		},
+		"scimBearerToken": "scim-token"
+	}}}`)
+
+	idleTimeout := 30
+	created, token, err := createAuthenticationDomain(context.Background(), client, &authenticationDomain{
+		Name:                     "Okta",
+		AuthenticationType:       "SAML_SSO",
+		ProvisioningType:         "SCIM",
+		UserUpgradeRequestPolicy: "MANUAL_REVIEW",
+		Session:                  authenticationDomainSession{IdleTimeoutMinutes: &idleTimeout},
+		Saml:                     &authenticationDomainSaml{EntityID: "https://idp.example.com/metadata", SsoURL: "https://idp.example.com/sso", Certificate: "-----BEGIN CERTIFICATE-----"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c", created.ID)
+	require.Equal(t, 30, *created.Session.IdleTimeoutMinutes)
+	require.Nil(t, created.Session.MaxDurationHours)
+	require.Equal(t, "https://idp.example.com/sso", created.Saml.SsoURL)
+	require.Equal(t, "scim-token", token)
+
+	require.Len(t, *requests, 1)
+	request := (*requests)[0]
+	require.Contains(t, request.Query, "userManagementCreateAuthenticationDomain(")
+	require.Equal(t, map[string]interface{}{
+		"authenticationDomain": map[string]interface{}{
+			"name":                     "Okta",
+			"authenticationType":       "SAML_SSO",
+			"provisioningType":         "SCIM",
+			"userUpgradeRequestPolicy": "MANUAL_REVIEW",
+			"session":                  map[string]interface{}{"idleTimeoutMinutes": 30.0, "maxDurationHours": nil},
+			"saml": map[string]interface{}{
+				"entityId":    "https://idp.example.com/metadata",
+				"ssoUrl":      "https://idp.example.com/sso",
+				"certificate": "-----BEGIN CERTIFICATE-----",
+			},
+		},
+	}, request.Variables)
+}
+
+func TestCreateAuthenticationDomain_Errors(t *testing.T) {
+	client, _ := testAuthenticationDomainNerdGraph(t,
+		`{"data": {"userManagementCreateAuthenticationDomain": null}, "errors": [{"message": "Authentication domain name already in use"}]}`,
+		`{"data": {"userManagementCreateAuthenticationDomain": {"authenticationDomain": null, "scimBearerToken": null}}}`,
+	)
+
+	_, _, err := createAuthenticationDomain(context.Background(), client, &authenticationDomain{Name: "Okta"})
+	require.ErrorContains(t, err, "Authentication domain name already in use")
+
+	_, _, err = createAuthenticationDomain(context.Background(), client, &authenticationDomain{Name: "Okta"})
+	require.ErrorContains(t, err, "no ID returned")
+}
+
+func TestUpdateAuthenticationDomain(t *testing.T) {
+	client, requests := testAuthenticationDomainNerdGraph(t,
+		`{"data": {"userManagementUpdateAuthenticationDomain": {"authenticationDomain": {"id": "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c", "name": "Okta SSO"}}}}`,
+	)
+
+	err := updateAuthenticationDomain(context.Background(), client, "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c", &authenticationDomain{
+		Name:                     "Okta SSO",
+		AuthenticationType:       "PASSWORD",
+		ProvisioningType:         "MANUAL",
+		UserUpgradeRequestPolicy: "AUTOMATIC_APPROVAL",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *requests, 1)
+	request := (*requests)[0]
+	require.Contains(t, request.Query, "userManagementUpdateAuthenticationDomain(")
+	require.Equal(t, "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c", request.Variables["id"])
+	// Password domains have no saml input.
+	require.Equal(t, map[string]interface{}{
+		"name":                     "Okta SSO",
+		"authenticationType":       "PASSWORD",
+		"provisioningType":         "MANUAL",
+		"userUpgradeRequestPolicy": "AUTOMATIC_APPROVAL",
+		"session":                  map[string]interface{}{"idleTimeoutMinutes": nil, "maxDurationHours": nil},
+	}, request.Variables["authenticationDomain"])
+}
+
+func TestDeleteAuthenticationDomain(t *testing.T) {
+	client, requests := testAuthenticationDomainNerdGraph(t,
+		`{"data": {"userManagementDeleteAuthenticationDomain": {"authenticationDomain": {"id": "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c"}}}}`,
+		`{"data": {"userManagementDeleteAuthenticationDomain": null}, "errors": [{"message": "Authentication domain has users"}]}`,
+	)
+
+	require.NoError(t, deleteAuthenticationDomain(context.Background(), client, "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c"))
+	require.ErrorContains(t, deleteAuthenticationDomain(context.Background(), client, "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c"), "Authentication domain has users")
+
+	require.Contains(t, (*requests)[0].Query, "userManagementDeleteAuthenticationDomain(")
+	require.Equal(t, map[string]interface{}{"id": "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c"}, (*requests)[0].Variables)
+}
+
+func TestGenerateAuthenticationDomainScimBearerToken(t *testing.T) {
+	client, requests := testAuthenticationDomainNerdGraph(t,
+		`{"data": {"userManagementGenerateScimBearerToken": {"scimBearerToken": "new-scim-token"}}}`,
+	)
+
+	token, err := generateAuthenticationDomainScimBearerToken(context.Background(), client, "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c")
+	require.NoError(t, err)
+	require.Equal(t, "new-scim-token", token)
+
+	require.Len(t, *requests, 1)
+	require.Contains(t, (*requests)[0].Query, "userManagementGenerateScimBearerToken(")
+	require.Contains(t, (*requests)[0].Query, "authenticationDomainId: $id")
+	require.Equal(t, map[string]interface{}{"id": "84cb286a-8eb0-4478-8ec8-b5c3bc7b9a1c"}, (*requests)[0].Variables)
+}
@@ -0,0 +1,116 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/datamanagement"
+)
+
+// cardinalityLimitChange is the limit to set for a metric, the platform default when its override is removed.
+type cardinalityLimitChange struct {
+	metric  string
+	limit   int
+	removed bool
+}
+
+// diffCardinalityLimits returns the changes turning the old limits into the new ones, by metric name,
+// so that only the limits which changed are set.
+func diffCardinalityLimits(oldLimits map[string]interface{}, newLimits map[string]interface{}) []cardinalityLimitChange {
+	changes := []cardinalityLimitChange{}
+
+	for metric, limit := range newLimits {
+		if oldLimit, ok := oldLimits[metric]; !ok || oldLimit.(int) != limit.(int) {
+			changes = append(changes, cardinalityLimitChange{metric: metric, limit: limit.(int)})
+		}
+	}
+	for metric := range oldLimits {
+		if _, ok := newLimits[metric]; !ok {
+			changes = append(changes, cardinalityLimitChange{metric: metric, limit: cardinalityLimitPlatformDefault, removed: true})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].metric < changes[j].metric
+	})
+
+	return changes
+}
+
+// batchCardinalityLimitChanges splits the changes in batches of at most size changes.
+func batchCardinalityLimitChanges(changes []cardinalityLimitChange, size int) [][]cardinalityLimitChange {
+	var batches [][]cardinalityLimitChange
+	for len(changes) > size {
+		batches = append(batches, changes[:size])
+		changes = changes[size:]
+	}
+	if len(changes) > 0 {
+		batches = append(batches, changes)
+	}
+
+	return batches
+}
+
+// cardinalityLimitsBatchMutation sets the limits of a batch of metrics in a single request,
+// with one aliased dataManagementCreateAccountLimit per metric.
+func cardinalityLimitsBatchMutation(size int) string {
+	var b strings.Builder
+
+	b.WriteString("mutation(\n\t$accountId: Int!,\n")
+	for i := 0; i < size; i++ {
+		fmt.Fprintf(&b, "\t$limit%d: DataManagementAccountLimitInput,\n", i)
+	}
+	b.WriteString(") {\n")
+	for i := 0; i < size; i++ {
+		fmt.Fprintf(&b, "\tlimit%[1]d: dataManagementCreateAccountLimit(accountId: $accountId, accountLimit: $limit%[1]d) { name value }\n", i)
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
+
+func cardinalityLimitsBatchVariables(accountID int, batch []cardinalityLimitChange) map[string]interface{} {
+	vars := map[string]interface{}{
+		"accountId": accountID,
+	}
+
+	for i, c := range batch {
+		reason := fmt.Sprintf("Cardinality limit for metric %q in account %d set to %d via Terraform", c.metric, accountID, c.limit)
+		if c.removed {
+			reason = fmt.Sprintf("Cardinality limit override for metric %q in account %d removed via Terraform; reset to platform default (%d)", c.metric, accountID, c.limit)
+		}
+
+		vars[fmt.Sprintf("limit%d", i)] = datamanagement.DataManagementAccountLimitInput{
+			Limit:          datamanagement.DataManagementLimitLookupInput{Name: cardinalityLimitName},
+			OverrideValue:  c.limit,
+			OverrideReason: reason,
+			Qualifier:      c.metric,
+		}
+	}
+
+	return vars
+}
+
+// applyCardinalityLimitChanges sets the limits batch by batch, calling applied, when given, after each batch,
+// so that the limits set before a failing batch are kept in the state.
+func applyCardinalityLimitChanges(ctx context.Context, client *newrelic.NewRelic, accountID int, changes []cardinalityLimitChange, batchSize int, applied func([]cardinalityLimitChange)) error {
+	for _, batch := range batchCardinalityLimitChanges(changes, batchSize) {
+		resp := map[string]interface{}{}
+		if err := client.NerdGraph.QueryWithResponseAndContext(ctx, cardinalityLimitsBatchMutation(len(batch)), cardinalityLimitsBatchVariables(accountID, batch), &resp); err != nil {
+			metrics := make([]string, len(batch))
+			for i, c := range batch {
+				metrics[i] = c.metric
+			}
+			return fmt.Errorf("failed to set the cardinality limits of metrics %s: %w", strings.Join(metrics, ", "), err)
+		}
+
+		if applied != nil {
+			applied(batch)
+		}
+	}
+
+	return nil
+}
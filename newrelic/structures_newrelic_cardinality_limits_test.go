@@ -0,0 +1,161 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCardinalityLimits(t *testing.T) {
+	t.Parallel()
+
+	changes := diffCardinalityLimits(
+		map[string]interface{}{"http.server.duration": 150000, "db.query.duration": 120000, "queue.depth": 200000},
+		map[string]interface{}{"http.server.duration": 150000, "db.query.duration": 300000, "cache.hits": 110000},
+	)
+
+	require.Equal(t, []cardinalityLimitChange{
+		{metric: "cache.hits", limit: 110000},
+		{metric: "db.query.duration", limit: 300000},
+		{metric: "queue.depth", limit: cardinalityLimitPlatformDefault, removed: true},
+	}, changes)
+
+	require.Empty(t, diffCardinalityLimits(map[string]interface{}{"a": 1}, map[string]interface{}{"a": 1}))
+}
+
+func TestBatchCardinalityLimitChanges(t *testing.T) {
+	t.Parallel()
+
+	changes := []cardinalityLimitChange{{metric: "a"}, {metric: "b"}, {metric: "c"}, {metric: "d"}, {metric: "e"}}
+
+	batches := batchCardinalityLimitChanges(changes, 2)
+	require.Len(t, batches, 3)
+	require.Equal(t, []cardinalityLimitChange{{metric: "e"}}, batches[2])
+
+	require.Len(t, batchCardinalityLimitChanges(changes, 5), 1)
+	require.Empty(t, batchCardinalityLimitChanges(nil, 5))
+}
+
+func TestCardinalityLimitsBatchMutation(t *testing.T) {
+	t.Parallel()
+
+	mutation := cardinalityLimitsBatchMutation(2)
+	require.Contains(t, mutation, "$limit1: DataManagementAccountLimitInput,")
+	require.Contains(t, mutation, "limit1: dataManagementCreateAccountLimit(accountId: $accountId, accountLimit: $limit1) { name value }")
+	require.NotContains(t, mutation, "limit2")
+
+	vars := cardinalityLimitsBatchVariables(1, []cardinalityLimitChange{
+		{metric: "cache.hits", limit: 110000},
+		{metric: "queue.depth", limit: cardinalityLimitPlatformDefault, removed: true},
+	})
+	require.Len(t, vars, 3)
+
+	encoded, err := json.Marshal(vars["limit1"])
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"limit": {"name": "Dimensional Metric per-metric cardinality ingested per day"},
+		"overrideReason": "Cardinality limit override for metric \"queue.depth\" in account 1 removed via Terraform; reset to platform default (100000)",
+		"overrideValue": 100000,
+		"qualifier": "queue.depth"
+	}`, string(encoded))
+}
+
+func TestApplyCardinalityLimitChanges(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests++
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// The third batch fails
+		if requests == 3 {
+			_, _ = w.Write([]byte(`{"errors": [{"message": "limit exceeded"}]}`))
+			return
+		}
+		require.Equal(t, 2, strings.Count(body.Query, "dataManagementCreateAccountLimit"))
+		_, _ = w.Write([]byte(`{"data": {"limit0": {"name": "x", "value": 1}, "limit1": {"name": "x", "value": 1}}}`))
+	}))
+	defer server.Close()
+
+	client, err := newrelic.New(newrelic.ConfigPersonalAPIKey("NRAK-TEST"), newrelic.ConfigNerdGraphBaseURL(server.URL))
+	require.NoError(t, err)
+
+	changes := []cardinalityLimitChange{{metric: "a", limit: 1}, {metric: "b", limit: 1}, {metric: "c", limit: 1}, {metric: "d", limit: 1}, {metric: "e", limit: 1}}
+
+	var applied []string
+	err = applyCardinalityLimitChanges(context.Background(), client, 1, changes, 2, func(batch []cardinalityLimitChange) {
+		for _, c := range batch {
+			applied = append(applied, c.metric)
+		}
+	})
+	require.ErrorContains(t, err, "failed to set the cardinality limits of metrics e: ")
+	require.Equal(t, []string{"a", "b", "c", "d"}, applied)
+}
+
+func TestResourceNewRelicCardinalityLimitsApply_KeepsAppliedBatches(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+
+		// The second batch fails
+		if requests == 2 {
+			_, _ = w.Write([]byte(`{"errors": [{"message": "limit exceeded"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"limit0": {"name": "x", "value": 1}}}`))
+	}))
+	defer server.Close()
+
+	client, err := newrelic.New(newrelic.ConfigPersonalAPIKey("NRAK-TEST"), newrelic.ConfigNerdGraphBaseURL(server.URL))
+	require.NoError(t, err)
+
+	r := resourceNewRelicCardinalityLimits()
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id":         "1",
+			"account_id": "1",
+			"batch_size": "1",
+			"limits.%":   "1",
+			"limits.a":   "100",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id": 1,
+		"batch_size": 1,
+		"limits":     map[string]interface{}{"a": 100, "b": 200, "c": 300, "d": 400},
+	})
+
+	diff, err := r.Diff(context.Background(), state, config, nil)
+	require.NoError(t, err)
+
+	newState, diags := r.Apply(context.Background(), state, diff, &ProviderConfig{NewClient: client})
+	require.True(t, diags.HasError())
+	require.Contains(t, diags[0].Summary, "failed to set the cardinality limits of metrics c")
+	require.Equal(t, 2, requests)
+
+	// b was set before c failed, so the next apply only sets c and d
+	require.Equal(t, "2", newState.Attributes["limits.%"])
+	require.Equal(t, "100", newState.Attributes["limits.a"])
+	require.Equal(t, "200", newState.Attributes["limits.b"])
+	require.NotContains(t, newState.Attributes, "limits.c")
+}
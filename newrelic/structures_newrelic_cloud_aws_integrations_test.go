@@ -0,0 +1,318 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+	"github.com/stretchr/testify/require"
+)
+
+// Regenerates the golden files with `go test -tags unit -run TestCloudAwsIntegrations_Golden -update-golden`.
+var updateGolden = flag.Bool("update-golden", false, "update the golden files of unit tests")
+
+const testCloudAwsLinkedAccountID = 1234
+
+// The expand, disable and flatten functions of each AWS integrations resource, whose behavior
+// is recorded in testdata/cloud_aws_integrations.
+type cloudAwsIntegrationsGoldenResource struct {
+	resource func() *schema.Resource
+	expand   func(d *schema.ResourceData) (cloud.CloudIntegrationsInput, cloud.CloudDisableIntegrationsInput)
+	delete   func(d *schema.ResourceData) cloud.CloudDisableIntegrationsInput
+	flatten  func(d *schema.ResourceData, linkedAccount *cloud.CloudLinkedAccount)
+}
+
+var cloudAwsIntegrationsGoldenResources = map[string]cloudAwsIntegrationsGoldenResource{
+	"newrelic_cloud_aws_integrations": {
+		resource: resourceNewRelicCloudAwsIntegrations,
+		expand:   expandCloudAwsIntegrationsInput,
+		delete:   buildDeleteInput,
+		flatten:  flattenCloudAwsLinkedAccount,
+	},
+	"newrelic_cloud_aws_govcloud_integrations": {
+		resource: resourceNewRelicAwsGovCloudIntegrations,
+		expand:   expandAwsGovCloudIntegrationsInput,
+		delete:   expandAwsGovCloudDisableInputs,
+		flatten:  flattenAwsGovCloudLinkedAccount,
+	},
+	"newrelic_cloud_aws_eu_sovereign_integrations": {
+		resource: resourceNewRelicCloudAwsEuSovereignIntegrations,
+		// Integrations removed from the configuration are not disabled on update.
+		expand: func(d *schema.ResourceData) (cloud.CloudIntegrationsInput, cloud.CloudDisableIntegrationsInput) {
+			return expandCloudAwsEuSovereignIntegrationsInput(d), cloud.CloudDisableIntegrationsInput{}
+		},
+		delete: expandCloudAwsEuSovereignDisableIntegrationsInput,
+		flatten: func(d *schema.ResourceData, linkedAccount *cloud.CloudLinkedAccount) {
+			flattenCloudAwsEuSovereignIntegrations(linkedAccount, linkedAccount.NrAccountId, d)
+		},
+	},
+}
+
+// Every AWS integration returned in linked accounts.
+var testCloudAwsIntegrations = []cloud.CloudIntegrationInterface{
+	&cloud.CloudAPIgatewayIntegration{}, &cloud.CloudAlbIntegration{}, &cloud.CloudAutoscalingIntegration{},
+	&cloud.CloudAwsAppsyncIntegration{}, &cloud.CloudAwsAthenaIntegration{}, &cloud.CloudAwsAutoDiscoveryIntegration{},
+	&cloud.CloudAwsCognitoIntegration{}, &cloud.CloudAwsConnectIntegration{}, &cloud.CloudAwsDirectconnectIntegration{},
+	&cloud.CloudAwsDocdbIntegration{}, &cloud.CloudAwsFsxIntegration{}, &cloud.CloudAwsGlueIntegration{},
+	&cloud.CloudAwsKinesisanalyticsIntegration{}, &cloud.CloudAwsMediaconvertIntegration{}, &cloud.CloudAwsMediapackagevodIntegration{},
+	&cloud.CloudAwsMqIntegration{}, &cloud.CloudAwsMskIntegration{}, &cloud.CloudAwsNeptuneIntegration{},
+	&cloud.CloudAwsQldbIntegration{}, &cloud.CloudAwsRoute53resolverIntegration{}, &cloud.CloudAwsStatesIntegration{},
+	&cloud.CloudAwsTransitgatewayIntegration{}, &cloud.CloudAwsWafIntegration{}, &cloud.CloudAwsWafv2Integration{},
+	&cloud.CloudAwsXrayIntegration{}, &cloud.CloudBillingIntegration{}, &cloud.CloudCloudfrontIntegration{},
+	&cloud.CloudCloudtrailIntegration{}, &cloud.CloudDynamodbIntegration{}, &cloud.CloudEbsIntegration{},
+	&cloud.CloudEc2Integration{}, &cloud.CloudEcsIntegration{}, &cloud.CloudEfsIntegration{},
+	&cloud.CloudElasticacheIntegration{}, &cloud.CloudElasticbeanstalkIntegration{}, &cloud.CloudElasticsearchIntegration{},
+	&cloud.CloudElbIntegration{}, &cloud.CloudEmrIntegration{}, &cloud.CloudHealthIntegration{},
+	&cloud.CloudIamIntegration{}, &cloud.CloudIotIntegration{}, &cloud.CloudKinesisFirehoseIntegration{},
+	&cloud.CloudKinesisIntegration{}, &cloud.CloudLambdaIntegration{}, &cloud.CloudRdsIntegration{},
+	&cloud.CloudRedshiftIntegration{}, &cloud.CloudRoute53Integration{}, &cloud.CloudS3Integration{},
+	&cloud.CloudSecurityHubIntegration{}, &cloud.CloudSesIntegration{}, &cloud.CloudSnsIntegration{},
+	&cloud.CloudSqsIntegration{}, &cloud.CloudTrustedadvisorIntegration{}, &cloud.CloudVpcIntegration{},
+}
+
+func TestCloudAwsIntegrations_Golden(t *testing.T) {
+	for name, r := range cloudAwsIntegrationsGoldenResources {
+		t.Run(name, func(t *testing.T) {
+			resource := r.resource()
+			integrations := testCloudAwsIntegrationBlocks(resource)
+
+			full := testCloudAwsIntegrationsResourceData(t, resource, nil, func(block string, elem *schema.Resource) interface{} {
+				return []interface{}{testCloudAwsIntegrationConfig(elem)}
+			})
+			empty := testCloudAwsIntegrationsResourceData(t, resource, nil, func(block string, elem *schema.Resource) interface{} {
+				return []interface{}{map[string]interface{}{}}
+			})
+
+			// Every other integration is removed from the configuration.
+			removed := map[string]bool{}
+			for i, block := range integrations {
+				removed[block] = i%2 == 0
+			}
+			update := testCloudAwsIntegrationsResourceData(t, resource, full.State(), func(block string, elem *schema.Resource) interface{} {
+				if removed[block] {
+					return nil
+				}
+				return []interface{}{testCloudAwsIntegrationConfig(elem)}
+			})
+
+			golden := map[string]interface{}{
+				"schema": testGoldenSchema(resource.Schema),
+			}
+
+			configure, disable := r.expand(full)
+			golden["expand_full"] = map[string]interface{}{"configure": configure, "disable": disable}
+			configure, disable = r.expand(empty)
+			golden["expand_empty"] = map[string]interface{}{"configure": configure, "disable": disable}
+			configure, disable = r.expand(update)
+			golden["expand_update"] = map[string]interface{}{"configure": configure, "disable": disable}
+
+			golden["delete_full"] = r.delete(full)
+			golden["delete_empty"] = r.delete(empty)
+
+			flattened := resource.Data(nil)
+			flattened.SetId(fmt.Sprint(testCloudAwsLinkedAccountID))
+			r.flatten(flattened, testCloudAwsLinkedAccount())
+			golden["flatten"] = flattened.State().Attributes
+
+			testCompareGolden(t, filepath.Join("testdata", "cloud_aws_integrations", name+".golden.json"), golden)
+		})
+	}
+}
+
+// Every service of the catalog must be expanded to, and flattened from, fields of the client.
+func TestCloudAwsServices(t *testing.T) {
+	types := map[schema.ValueType]reflect.Type{
+		schema.TypeInt:    reflect.TypeOf(0),
+		schema.TypeBool:   reflect.TypeOf(false),
+		schema.TypeString: reflect.TypeOf(""),
+		schema.TypeList:   reflect.TypeOf([]string{}),
+	}
+	blocks := map[cloudAwsPartition]map[string]bool{}
+
+	for _, service := range cloudAwsServices {
+		for _, a := range service.arguments {
+			argument, ok := cloudAwsIntegrationArguments[a]
+			require.Truef(t, ok, "unknown argument %s of %s", a, service.field)
+
+			f, ok := reflect.TypeOf(service.integration).Elem().FieldByName(cloudAwsIntegrationField(a))
+			require.Truef(t, ok, "%T has no field for %s", service.integration, a)
+			require.Equal(t, types[argument.Type], f.Type, a)
+		}
+
+		require.NotEmptyf(t, service.actions.polling, "%s has no polling actions", service.field)
+		for a, actions := range service.actions.arguments {
+			require.Containsf(t, service.arguments, a, "%s is not an argument of %s", a, service.field)
+			require.Equal(t, schema.TypeBool, cloudAwsIntegrationArguments[a].Type, a)
+			require.NotEmpty(t, actions, a)
+		}
+		for _, action := range testCloudAwsServiceActions(service) {
+			require.Regexp(t, `^[a-z0-9-]+:[A-Z][A-Za-z0-9]*$`, action, service.field)
+		}
+
+		for partition, block := range service.blocks {
+			_, ok := reflect.TypeOf(cloud.CloudDisableIntegrationsInput{}).FieldByName(string(partition))
+			require.True(t, ok, partition)
+			input, ok := reflect.TypeOf(cloud.CloudIntegrationsInput{}).FieldByName(string(partition))
+			require.True(t, ok, partition)
+			field, ok := input.Type.FieldByName(service.field)
+			require.Truef(t, ok, "%s is not available in %s", service.field, partition)
+
+			for _, a := range service.blockArguments(block) {
+				f, ok := field.Type.Elem().FieldByName(cloudAwsIntegrationField(a))
+				require.Truef(t, ok, "%s has no field for %s", field.Type.Elem(), a)
+				require.Equal(t, types[cloudAwsIntegrationArguments[a].Type], f.Type, a)
+			}
+			for _, a := range block.unsupported {
+				require.Containsf(t, service.arguments, a, "%s is not an argument of %s", a, service.field)
+			}
+
+			if blocks[partition] == nil {
+				blocks[partition] = map[string]bool{}
+			}
+			require.Falsef(t, blocks[partition][block.name], "duplicate %s block in %s", block.name, partition)
+			blocks[partition][block.name] = true
+		}
+	}
+}
+
+func testCloudAwsServiceActions(service cloudAwsService) []string {
+	actions := append([]string{}, service.actions.polling...)
+	for _, a := range service.actions.arguments {
+		actions = append(actions, a...)
+	}
+
+	return actions
+}
+
+func testCloudAwsIntegrationBlocks(resource *schema.Resource) []string {
+	var blocks []string
+	for k, s := range resource.Schema {
+		if _, ok := s.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+		}
+	}
+	sort.Strings(blocks)
+
+	return blocks
+}
+
+// Returns the resource data of a plan from the given state to a configuration with the given integration blocks,
+// where a nil block is left out of the configuration.
+func testCloudAwsIntegrationsResourceData(t *testing.T, resource *schema.Resource, state *terraform.InstanceState, block func(string, *schema.Resource) interface{}) *schema.ResourceData {
+	raw := map[string]interface{}{
+		"account_id":        1,
+		"linked_account_id": testCloudAwsLinkedAccountID,
+	}
+	for _, k := range testCloudAwsIntegrationBlocks(resource) {
+		if v := block(k, resource.Schema[k].Elem.(*schema.Resource)); v != nil {
+			raw[k] = v
+		}
+	}
+
+	m := schema.InternalMap(resource.Schema)
+	diff, err := m.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil, nil, true)
+	require.NoError(t, err)
+	d, err := m.Data(state, diff)
+	require.NoError(t, err)
+	d.SetId(fmt.Sprint(testCloudAwsLinkedAccountID))
+
+	return d
+}
+
+// Sets every argument of an integration to a value derived from its name.
+func testCloudAwsIntegrationConfig(elem *schema.Resource) map[string]interface{} {
+	config := map[string]interface{}{}
+	for k, s := range elem.Schema {
+		switch s.Type {
+		case schema.TypeInt:
+			config[k] = len(k) * 60
+		case schema.TypeBool:
+			config[k] = true
+		case schema.TypeString:
+			config[k] = k + "-value"
+		case schema.TypeList, schema.TypeSet:
+			config[k] = []interface{}{k + "-1", k + "-2"}
+		}
+	}
+
+	return config
+}
+
+// Returns a linked account with every AWS integration, with their fields set to values derived from their names.
+func testCloudAwsLinkedAccount() *cloud.CloudLinkedAccount {
+	linkedAccount := &cloud.CloudLinkedAccount{ID: testCloudAwsLinkedAccountID, NrAccountId: 1}
+
+	for _, i := range testCloudAwsIntegrations {
+		v := reflect.New(reflect.TypeOf(i).Elem())
+		for j := 0; j < v.Elem().NumField(); j++ {
+			field := v.Elem().Field(j)
+			name := v.Elem().Type().Field(j).Name
+			switch {
+			case field.Kind() == reflect.Int:
+				field.SetInt(int64(len(name) * 60))
+			case field.Kind() == reflect.Bool:
+				field.SetBool(true)
+			case field.Kind() == reflect.String:
+				field.SetString(name + "-value")
+			case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+				field.Set(reflect.ValueOf([]string{name + "-1", name + "-2"}))
+			}
+		}
+		linkedAccount.Integrations = append(linkedAccount.Integrations, v.Interface().(cloud.CloudIntegrationInterface))
+	}
+
+	return linkedAccount
+}
+
+// Describes a schema, for comparison with golden files.
+func testGoldenSchema(s map[string]*schema.Schema) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range s {
+		attribute := map[string]interface{}{
+			"type":        v.Type.String(),
+			"optional":    v.Optional,
+			"required":    v.Required,
+			"computed":    v.Computed,
+			"force_new":   v.ForceNew,
+			"max_items":   v.MaxItems,
+			"description": v.Description,
+		}
+		// The element of scalar attributes is ignored.
+		if v.Type == schema.TypeList || v.Type == schema.TypeSet || v.Type == schema.TypeMap {
+			switch elem := v.Elem.(type) {
+			case *schema.Resource:
+				attribute["elem"] = testGoldenSchema(elem.Schema)
+			case *schema.Schema:
+				attribute["elem"] = elem.Type.String()
+			}
+		}
+		out[k] = attribute
+	}
+
+	return out
+}
+
+func testCompareGolden(t *testing.T, path string, actual interface{}) {
+	b, err := json.MarshalIndent(actual, "", "  ")
+	require.NoError(t, err)
+
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, append(b, '\n'), 0o644))
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(b))
+}
@@ -0,0 +1,236 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
+	nrErrors "github.com/newrelic/newrelic-client-go/v2/pkg/errors"
+)
+
+// Resolves the entities tagged by a newrelic_entity_tags resource, from its guid, guids or entity_search argument.
+// The entities matched by a search are resolved again on every read, so entities matched later are tagged too.
+func resolveEntityTagsGUIDs(ctx context.Context, client *newrelic.NewRelic, d *schema.ResourceData) ([]common.EntityGUID, error) {
+	if guid := d.Get("guid").(string); guid != "" {
+		return []common.EntityGUID{common.EntityGUID(guid)}, nil
+	}
+
+	var guids []common.EntityGUID
+
+	if query := d.Get("entity_search").(string); query != "" {
+		results, err := client.Entities.GetEntitySearchByQueryWithContext(ctx, entities.EntitySearchOptions{}, query, []entities.EntitySearchSortCriteria{})
+		if err != nil {
+			return nil, fmt.Errorf("error searching entities with query %q: %w", query, err)
+		}
+		if results == nil {
+			return nil, fmt.Errorf("GetEntitySearchByQuery response was nil")
+		}
+		if results.Count > entityTagsMaxEntities {
+			return nil, fmt.Errorf("entity search query %q matches %d entities, more than the %d that can be tagged by one resource", query, results.Count, entityTagsMaxEntities)
+		}
+
+		for _, e := range results.Results.Entities {
+			guids = append(guids, e.GetGUID())
+		}
+	} else {
+		for _, guid := range d.Get("guids").(*schema.Set).List() {
+			guids = append(guids, common.EntityGUID(guid.(string)))
+		}
+	}
+
+	sort.Slice(guids, func(i, j int) bool { return guids[i] < guids[j] })
+
+	return guids, nil
+}
+
+// The ID of a resource tagging several entities identifies the entities it was given.
+func entityTagsMergeID(d *schema.ResourceData) string {
+	if guid := d.Get("guid").(string); guid != "" {
+		return guid
+	}
+
+	if query := d.Get("entity_search").(string); query != "" {
+		return strconv.Itoa(schema.HashString(query))
+	}
+
+	guids := expandStringSlice(d.Get("guids").(*schema.Set).List())
+	sort.Strings(guids)
+
+	return strconv.Itoa(schema.HashString(strings.Join(guids, ",")))
+}
+
+// intersectEntityTags returns the declared tag values found in the current tags of an entity,
+// ignoring the keys and values which are not declared.
+func intersectEntityTags(declared []entities.TaggingTagInput, current []*entities.EntityTag) []entities.TaggingTagInput {
+	out := []entities.TaggingTagInput{}
+
+	for _, tag := range declared {
+		var values []string
+		for _, c := range current {
+			if c.Key != tag.Key {
+				continue
+			}
+			for _, v := range tag.Values {
+				if stringInSlice(c.Values, v) {
+					values = append(values, v)
+				}
+			}
+		}
+
+		if len(values) > 0 {
+			out = append(out, entities.TaggingTagInput{Key: tag.Key, Values: values})
+		}
+	}
+
+	return out
+}
+
+// diffEntityTags returns the tag values to add and remove to change the old tags into the new ones.
+func diffEntityTags(old []entities.TaggingTagInput, new []entities.TaggingTagInput) ([]entities.TaggingTagInput, []entities.TaggingTagValueInput) {
+	values := func(tags []entities.TaggingTagInput, key string) []string {
+		for _, t := range tags {
+			if t.Key == key {
+				return t.Values
+			}
+		}
+		return nil
+	}
+
+	add := []entities.TaggingTagInput{}
+	for _, t := range new {
+		oldValues := values(old, t.Key)
+
+		var added []string
+		for _, v := range t.Values {
+			if !stringInSlice(oldValues, v) {
+				added = append(added, v)
+			}
+		}
+		if len(added) > 0 {
+			add = append(add, entities.TaggingTagInput{Key: t.Key, Values: added})
+		}
+	}
+
+	remove := []entities.TaggingTagValueInput{}
+	for _, t := range old {
+		newValues := values(new, t.Key)
+
+		for _, v := range t.Values {
+			if !stringInSlice(newValues, v) {
+				remove = append(remove, entities.TaggingTagValueInput{Key: t.Key, Value: v})
+			}
+		}
+	}
+
+	return add, remove
+}
+
+// entityTagsMergeRemovals returns the tag values to remove from the entities, the managed tag values of
+// the state which are no longer declared. Tags read in replace mode include the ones set by others, which
+// are left as they are once in merge mode, and have no managed values.
+func entityTagsMergeRemovals(d *schema.ResourceData) []entities.TaggingTagValueInput {
+	old, _ := d.GetChange("managed_tags")
+
+	_, remove := diffEntityTags(expandEntityTags(old.(*schema.Set).List()), expandEntityTags(d.Get("tag").(*schema.Set).List()))
+
+	return remove
+}
+
+func flattenEntityTagsManaged(tags []entities.TaggingTagInput) []interface{} {
+	out := make([]interface{}, len(tags))
+	for i, t := range tags {
+		out[i] = map[string]interface{}{
+			"key":    t.Key,
+			"values": t.Values,
+		}
+	}
+
+	return out
+}
+
+// mergeEntityTags adds all the declared tags to every entity, so that entities matched later, and values
+// removed outside of Terraform, are tagged too, and removes the values no longer declared.
+func mergeEntityTags(ctx context.Context, client *newrelic.NewRelic, guids []common.EntityGUID, tags []entities.TaggingTagInput, remove []entities.TaggingTagValueInput) error {
+	for _, guid := range guids {
+		log.Printf("[INFO] Updating New Relic entity tags for entity guid %s", guid)
+
+		if err := addEntityTags(ctx, client, guid, tags); err != nil {
+			return err
+		}
+		if err := deleteEntityTagValues(ctx, client, guid, remove); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addEntityTags(ctx context.Context, client *newrelic.NewRelic, guid common.EntityGUID, tags []entities.TaggingTagInput) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	res, err := client.Entities.TaggingAddTagsToEntityWithContext(ctx, guid, tags)
+	if err != nil {
+		return err
+	}
+
+	return entityTagsMutationError(guid, res)
+}
+
+func deleteEntityTagValues(ctx context.Context, client *newrelic.NewRelic, guid common.EntityGUID, values []entities.TaggingTagValueInput) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	res, err := client.Entities.TaggingDeleteTagValuesFromEntityWithContext(ctx, guid, values)
+	if err != nil {
+		return err
+	}
+
+	return entityTagsMutationError(guid, res)
+}
+
+func entityTagsMutationError(guid common.EntityGUID, res *entities.TaggingMutationResult) error {
+	if res == nil || len(res.Errors) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(res.Errors))
+	for i, e := range res.Errors {
+		messages[i] = e.Message + ": " + string(e.Type)
+	}
+
+	return fmt.Errorf("error tagging entity %s: %s", guid, strings.Join(messages, ", "))
+}
+
+// Tags are eventually consistent, so the declared values are waited for before being read back.
+func waitForEntityTags(ctx context.Context, client *newrelic.NewRelic, timeout time.Duration, guids []common.EntityGUID, tags []entities.TaggingTagInput) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		for _, guid := range guids {
+			current, err := client.Entities.GetTagsForEntityWithContext(ctx, guid)
+			if err != nil {
+				if _, ok := err.(*nrErrors.NotFound); ok {
+					continue
+				}
+				return resource.NonRetryableError(fmt.Errorf("error retrieving entity tags for guid %s: %s", guid, err))
+			}
+
+			found := intersectEntityTags(tags, current)
+			if _, missing := diffEntityTags(tags, found); len(missing) > 0 {
+				return resource.RetryableError(fmt.Errorf("expected entity tag %s to have value %s on entity %s but it was not found", missing[0].Key, missing[0].Value, guid))
+			}
+		}
+
+		return nil
+	})
+}
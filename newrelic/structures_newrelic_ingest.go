@@ -0,0 +1,288 @@
+package newrelic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/region"
+)
+
+const (
+	// The Metric and Log APIs reject payloads larger than 1MB once compressed. Batches are
+	// limited by their uncompressed size, which is never smaller than their compressed size.
+	ingestMaxPayloadBytes = 1000000
+	ingestMaxAttempts     = 5
+	ingestRetryWait       = time.Second
+)
+
+// The schema of the keys the data is sent with, shared by the ingest resources.
+func ingestKeySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"license_key": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			ForceNew:    true,
+			DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_LICENSE_KEY", nil),
+			Description: "The license key of the account the data is sent to. Defaults to the NEW_RELIC_LICENSE_KEY environment variable.",
+		},
+		"insert_key": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			ForceNew:    true,
+			Description: "The Insights insert key of the account the data is sent to, used instead of the license key when set. Defaults to the insights_insert_key of the provider when no license key is set.",
+		},
+	}
+}
+
+// ingestSender sends data to the Metric and Log APIs in gzip compressed batches,
+// retrying the batches rejected because of throttling or server errors.
+type ingestSender struct {
+	url       string
+	keyHeader string
+	key       string
+	userAgent string
+	client    *http.Client
+	maxBytes  int
+	retryWait time.Duration
+}
+
+func newIngestSender(providerConfig *ProviderConfig, d *schema.ResourceData, url func(*region.Region) string) (*ingestSender, error) {
+	name, err := region.Parse(providerConfig.Region)
+	if err != nil {
+		name = region.Default
+	}
+
+	r, err := region.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := &ingestSender{
+		url:       url(r),
+		userAgent: providerConfig.GetUserAgent(),
+		client:    providerConfig.httpClient,
+		maxBytes:  ingestMaxPayloadBytes,
+		retryWait: ingestRetryWait,
+	}
+	if sender.client == nil {
+		sender.client = http.DefaultClient
+	}
+
+	switch {
+	case d.Get("insert_key").(string) != "":
+		sender.keyHeader, sender.key = "X-Insert-Key", d.Get("insert_key").(string)
+	case d.Get("license_key").(string) != "":
+		sender.keyHeader, sender.key = "Api-Key", d.Get("license_key").(string)
+	case providerConfig.insightsInsertKey != "":
+		sender.keyHeader, sender.key = "X-Insert-Key", providerConfig.insightsInsertKey
+	default:
+		return nil, fmt.Errorf("a license_key or insert_key is required to send data to New Relic")
+	}
+
+	return sender, nil
+}
+
+// send posts the items in as many batches as needed, each wrapped into a payload by the given function,
+// and returns the IDs of the accepted requests.
+func (s *ingestSender) send(ctx context.Context, items []interface{}, payload func(batch []interface{}) interface{}) ([]string, error) {
+	batches, err := s.batch(items, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	requestIDs := []string{}
+	for i, body := range batches {
+		log.Printf("[INFO] Sending batch %d of %d to %s", i+1, len(batches), s.url)
+
+		id, err := s.post(ctx, body)
+		if err != nil {
+			return requestIDs, err
+		}
+		requestIDs = append(requestIDs, id)
+	}
+
+	return requestIDs, nil
+}
+
+// batch splits the items into the fewest payloads not larger than the size limit, keeping their order.
+// The items are encoded once, as a payload is its wrapper with the items separated by commas.
+func (s *ingestSender) batch(items []interface{}, payload func(batch []interface{}) interface{}) ([][]byte, error) {
+	empty, err := json.Marshal(payload([]interface{}{}))
+	if err != nil {
+		return nil, err
+	}
+
+	var batches [][]byte
+	size := len(empty)
+	start := 0
+
+	for i, item := range items {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		if len(empty)+len(encoded) > s.maxBytes {
+			return nil, fmt.Errorf("item %d is %d bytes, larger than the limit of %d bytes", i+1, len(encoded), s.maxBytes)
+		}
+
+		if i > start {
+			size++
+		}
+		if size+len(encoded) > s.maxBytes {
+			body, err := json.Marshal(payload(items[start:i]))
+			if err != nil {
+				return nil, err
+			}
+			batches = append(batches, body)
+			start, size = i, len(empty)
+		}
+		size += len(encoded)
+	}
+
+	if start < len(items) {
+		body, err := json.Marshal(payload(items[start:]))
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, body)
+	}
+
+	return batches, nil
+}
+
+func (s *ingestSender) post(ctx context.Context, body []byte) (string, error) {
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	if _, err := w.Write(body); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < ingestMaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(s.retryDelay(attempt, lastErr)):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(compressed.Bytes()))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+		req.Header.Set("User-Agent", s.userAgent)
+		req.Header.Set(s.keyHeader, s.key)
+
+		id, err := s.do(req)
+		if err == nil {
+			return id, nil
+		}
+
+		lastErr = err
+		if e, ok := err.(*ingestError); ok && !e.retryable() {
+			return "", err
+		}
+		log.Printf("[WARN] Sending data to %s failed, attempt %d of %d: %s", s.url, attempt+1, ingestMaxAttempts, err)
+	}
+
+	return "", lastErr
+}
+
+func (s *ingestSender) do(req *http.Request) (string, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode >= 300 {
+		e := &ingestError{statusCode: resp.StatusCode, message: strings.TrimSpace(string(respBody))}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			e.retryAfter = time.Duration(seconds) * time.Second
+		}
+		return "", e
+	}
+
+	var accepted struct {
+		RequestID string `json:"requestId"`
+	}
+	_ = json.Unmarshal(respBody, &accepted)
+
+	return accepted.RequestID, nil
+}
+
+// Waits exponentially longer between attempts, unless told how long to wait by the API.
+func (s *ingestSender) retryDelay(attempt int, err error) time.Duration {
+	if e, ok := err.(*ingestError); ok && e.retryAfter > 0 {
+		return e.retryAfter
+	}
+
+	return s.retryWait * time.Duration(1<<(attempt-1))
+}
+
+type ingestError struct {
+	statusCode int
+	message    string
+	retryAfter time.Duration
+}
+
+func (e *ingestError) Error() string {
+	return fmt.Sprintf("%d response returned: %s", e.statusCode, e.message)
+}
+
+func (e *ingestError) retryable() bool {
+	return e.statusCode == http.StatusRequestTimeout || e.statusCode == http.StatusTooManyRequests || e.statusCode >= 500
+}
+
+// expandIngestAttributes converts attribute blocks, typed like the ones of newrelic_insights_event, into a map.
+func expandIngestAttributes(attributes []interface{}) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(attributes))
+
+	for _, a := range attributes {
+		attribute := a.(map[string]interface{})
+		key := attribute["key"].(string)
+		value := attribute["value"].(string)
+
+		switch strings.ToLower(attribute["type"].(string)) {
+		case "int":
+			i, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("unable to convert value %q of attribute %s to an int", value, key)
+			}
+			out[key] = i
+		case "float":
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unable to convert value %q of attribute %s to a float", value, key)
+			}
+			out[key] = f
+		default:
+			out[key] = value
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,144 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/region"
+	"github.com/stretchr/testify/require"
+)
+
+func testIngestPayload(batch []interface{}) interface{} {
+	return []map[string]interface{}{{"common": map[string]interface{}{}, "logs": batch}}
+}
+
+func TestIngestSenderBatch(t *testing.T) {
+	t.Parallel()
+
+	items := []interface{}{}
+	for i := 0; i < 10; i++ {
+		items = append(items, map[string]interface{}{"message": strings.Repeat("x", 20)})
+	}
+
+	empty, _ := json.Marshal(testIngestPayload([]interface{}{}))
+	item, _ := json.Marshal(items[0])
+
+	// Room for exactly three items per batch
+	sender := &ingestSender{maxBytes: len(empty) + 3*len(item) + 2}
+
+	batches, err := sender.batch(items, testIngestPayload)
+	require.NoError(t, err)
+	require.Len(t, batches, 4)
+
+	var sent []interface{}
+	for i, b := range batches {
+		require.LessOrEqual(t, len(b), sender.maxBytes)
+
+		var payload []struct {
+			Logs []interface{} `json:"logs"`
+		}
+		require.NoError(t, json.Unmarshal(b, &payload))
+		if i < 3 {
+			require.Len(t, payload[0].Logs, 3)
+		}
+		sent = append(sent, payload[0].Logs...)
+	}
+	require.Len(t, sent, 10)
+
+	sender.maxBytes = len(empty) + len(item) - 1
+	_, err = sender.batch(items, testIngestPayload)
+	require.EqualError(t, err, fmt.Sprintf("item 1 is %d bytes, larger than the limit of %d bytes", len(item), sender.maxBytes))
+}
+
+func TestIngestSenderSend(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	var received []map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		require.Equal(t, "NRAK-TEST", r.Header.Get("Api-Key"))
+
+		// Throttled once, then accepted
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		body, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		raw, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(raw, &received))
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"requestId":"f1b2c3"}`))
+	}))
+	defer server.Close()
+
+	sender := &ingestSender{
+		url:       server.URL,
+		keyHeader: "Api-Key",
+		key:       "NRAK-TEST",
+		client:    server.Client(),
+		maxBytes:  ingestMaxPayloadBytes,
+		retryWait: time.Millisecond,
+	}
+
+	ids, err := sender.send(context.Background(), []interface{}{map[string]interface{}{"message": "deployed"}}, testIngestPayload)
+	require.NoError(t, err)
+	require.Equal(t, []string{"f1b2c3"}, ids)
+	require.Equal(t, int32(2), attempts)
+	require.Equal(t, []interface{}{map[string]interface{}{"message": "deployed"}}, received[0]["logs"])
+}
+
+func TestIngestSenderSend_NotRetried(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("invalid key"))
+	}))
+	defer server.Close()
+
+	sender := &ingestSender{url: server.URL, keyHeader: "X-Insert-Key", key: "x", client: server.Client(), maxBytes: ingestMaxPayloadBytes, retryWait: time.Millisecond}
+
+	_, err := sender.send(context.Background(), []interface{}{map[string]interface{}{"message": "deployed"}}, testIngestPayload)
+	require.EqualError(t, err, "403 response returned: invalid key")
+	require.Equal(t, int32(1), attempts)
+}
+
+func TestNewIngestSender(t *testing.T) {
+	t.Parallel()
+
+	s := resourceNewRelicIngestLogs().Schema
+	raw := func(keys map[string]interface{}) *schema.ResourceData {
+		keys["log"] = []interface{}{map[string]interface{}{"message": "deployed"}}
+		return schema.TestResourceDataRaw(t, s, keys)
+	}
+
+	sender, err := newIngestSender(&ProviderConfig{Region: "EU"}, raw(map[string]interface{}{"license_key": "license"}), (*region.Region).LogsURL)
+	require.NoError(t, err)
+	require.Equal(t, "https://log-api.eu.newrelic.com/log/v1", sender.url)
+	require.Equal(t, []string{"Api-Key", "license"}, []string{sender.keyHeader, sender.key})
+
+	sender, err = newIngestSender(&ProviderConfig{Region: "US"}, raw(map[string]interface{}{"license_key": "license", "insert_key": "insert"}), (*region.Region).MetricsURL)
+	require.NoError(t, err)
+	require.Equal(t, "https://metric-api.newrelic.com/metric/v1", sender.url)
+	require.Equal(t, []string{"X-Insert-Key", "insert"}, []string{sender.keyHeader, sender.key})
+}
@@ -0,0 +1,354 @@
+package newrelic
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/errors"
+)
+
+// The maximum size of the CSV content of a lookup table accepted by New Relic.
+const lookupTableMaxSize = 4 * 1024 * 1024
+
+// The lookup table API is not part of newrelic-client-go, so its endpoints are kept here by region.
+var lookupTableBaseURLs = map[string]string{
+	"us":      "https://nrql-lookup.service.newrelic.com/v1",
+	"eu":      "https://nrql-lookup.service.eu.newrelic.com/v1",
+	"jp":      "https://nrql-lookup.service.jp.newrelic.com/v1",
+	"staging": "https://nrql-lookup.staging-service.newrelic.com/v1",
+}
+
+var lookupTableColumnTypes = []string{"string", "int", "float", "boolean"}
+
+type lookupTableColumn struct {
+	name       string
+	columnType string
+}
+
+// lookupTableData is satisfied by both schema.ResourceData and schema.ResourceDiff,
+// so tables are expanded and validated the same way at plan and apply time.
+type lookupTableData interface {
+	Get(key string) interface{}
+}
+
+type lookupTableClient struct {
+	baseURL   string
+	apiKey    string
+	userAgent string
+	client    *http.Client
+}
+
+func newLookupTableClient(providerConfig *ProviderConfig) (*lookupTableClient, error) {
+	region := strings.ToLower(providerConfig.Region)
+	if region == "" {
+		region = "us"
+	}
+
+	baseURL, ok := lookupTableBaseURLs[region]
+	if !ok {
+		return nil, fmt.Errorf("lookup tables are not supported in the %s region", providerConfig.Region)
+	}
+
+	client := providerConfig.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &lookupTableClient{
+		baseURL:   baseURL,
+		apiKey:    providerConfig.PersonalAPIKey,
+		userAgent: providerConfig.GetUserAgent(),
+		client:    client,
+	}, nil
+}
+
+func (c *lookupTableClient) tableURL(accountID int, name string) string {
+	return fmt.Sprintf("%s/accounts/%d/%s", c.baseURL, accountID, url.PathEscape(name))
+}
+
+// The hash of the content is kept in the description of the table when uploaded, so
+// changes are detected from the metadata of the table without downloading it.
+const lookupTableContentHashPrefix = "content_hash="
+
+type lookupTableMetadata struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// contentHash returns the hash of the content recorded on upload, or an empty string
+// if the table was uploaded outside of Terraform.
+func (m *lookupTableMetadata) contentHash() string {
+	hash, _ := strings.CutPrefix(m.Description, lookupTableContentHashPrefix)
+	if hash == m.Description {
+		return ""
+	}
+
+	return hash
+}
+
+// upload creates the table, or replaces the content of an existing one, recording the
+// hash of the content in its metadata.
+func (c *lookupTableClient) upload(ctx context.Context, accountID int, name string, content string, contentHash string, replace bool) error {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+
+	if err := form.WriteField("description", lookupTableContentHashPrefix+contentHash); err != nil {
+		return err
+	}
+
+	file, err := form.CreateFormFile("table", name+".csv")
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(file, content); err != nil {
+		return err
+	}
+	if err = form.Close(); err != nil {
+		return err
+	}
+
+	method := http.MethodPost
+	if replace {
+		method = http.MethodPut
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.tableURL(accountID, name), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	_, err = c.do(req)
+	return err
+}
+
+// download returns the CSV content of the table, or a NotFound error if there is no such table.
+func (c *lookupTableClient) download(ctx context.Context, accountID int, name string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.tableURL(accountID, name), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/csv")
+
+	return c.do(req)
+}
+
+// metadata returns the metadata of the table, or a NotFound error if there is no such table.
+func (c *lookupTableClient) metadata(ctx context.Context, accountID int, name string) (*lookupTableMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.tableURL(accountID, name), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := struct {
+		Table lookupTableMetadata `json:"table"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return nil, fmt.Errorf("invalid lookup table metadata: %w", err)
+	}
+
+	return &resp.Table, nil
+}
+
+func (c *lookupTableClient) delete(ctx context.Context, accountID int, name string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.tableURL(accountID, name), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(req)
+	return err
+}
+
+func (c *lookupTableClient) do(req *http.Request) (string, error) {
+	req.Header.Set("Api-Key", c.apiKey)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", errors.NewNotFoundf("lookup table not found: %s", req.URL.Path)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return "", errors.NewUnauthorizedError()
+	case resp.StatusCode >= 300:
+		return "", errors.NewUnexpectedStatusCode(resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return string(body), nil
+}
+
+// expandLookupTable returns the records of the table, header first, from either the csv or the row arguments.
+func expandLookupTable(d lookupTableData) ([][]string, []lookupTableColumn, error) {
+	columns := []lookupTableColumn{}
+	for _, c := range d.Get("column").([]interface{}) {
+		column := c.(map[string]interface{})
+		columns = append(columns, lookupTableColumn{
+			name:       column["name"].(string),
+			columnType: column["type"].(string),
+		})
+	}
+
+	if content := d.Get("csv").(string); content != "" {
+		records, err := parseLookupTableCSV(content)
+		return records, columns, err
+	}
+
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+
+	records := [][]string{header}
+	for i, r := range d.Get("row").([]interface{}) {
+		if r == nil {
+			return nil, nil, fmt.Errorf("row %d has no values", i+1)
+		}
+		records = append(records, expandStringSlice(r.(map[string]interface{})["values"].([]interface{})))
+	}
+
+	return records, columns, nil
+}
+
+func parseLookupTableCSV(content string) ([][]string, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	// Rows of the wrong length are reported by validateLookupTable, like the ones of the row arguments.
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("invalid CSV: a header row is required")
+	}
+
+	return records, nil
+}
+
+// validateLookupTable checks the table the way New Relic does on upload, and the values against the declared column types.
+func validateLookupTable(records [][]string, columns []lookupTableColumn) error {
+	header := records[0]
+	if len(header) == 0 {
+		return fmt.Errorf("the table must have at least one column")
+	}
+
+	names := map[string]bool{}
+	for _, name := range header {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("column names must not be empty")
+		}
+		if names[name] {
+			return fmt.Errorf("column %q is defined more than once", name)
+		}
+		names[name] = true
+	}
+
+	if len(columns) > 0 {
+		if len(columns) != len(header) {
+			return fmt.Errorf("the table has %d columns, but %d are declared", len(header), len(columns))
+		}
+		for i, c := range columns {
+			if c.name != header[i] {
+				return fmt.Errorf("column %d is %q, but %q is declared", i+1, header[i], c.name)
+			}
+		}
+	}
+
+	for i, record := range records[1:] {
+		if len(record) != len(header) {
+			return fmt.Errorf("row %d has %d values, but the table has %d columns", i+1, len(record), len(header))
+		}
+
+		for j, value := range record {
+			if len(columns) == 0 || value == "" {
+				continue
+			}
+			if err := validateLookupTableValue(value, columns[j].columnType); err != nil {
+				return fmt.Errorf("row %d, column %q: %w", i+1, header[j], err)
+			}
+		}
+	}
+
+	if size := len(encodeLookupTable(records)); size > lookupTableMaxSize {
+		return fmt.Errorf("the table is %d bytes, larger than the limit of %d bytes", size, lookupTableMaxSize)
+	}
+
+	return nil
+}
+
+func validateLookupTableValue(value string, columnType string) error {
+	var err error
+
+	switch columnType {
+	case "int":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "float":
+		_, err = strconv.ParseFloat(value, 64)
+	case "boolean":
+		_, err = strconv.ParseBool(value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%q is not a valid %s", value, columnType)
+	}
+
+	return nil
+}
+
+// encodeLookupTable returns the records as CSV, quoted consistently regardless of how they were written.
+func encodeLookupTable(records [][]string) string {
+	var b strings.Builder
+
+	w := csv.NewWriter(&b)
+	_ = w.WriteAll(records)
+
+	return b.String()
+}
+
+// lookupTableContentHash identifies the content of a table, so changes made outside of Terraform are detected.
+func lookupTableContentHash(records [][]string) string {
+	sum := sha256.Sum256([]byte(encodeLookupTable(records)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func parseLookupTableID(id string) (int, string, error) {
+	a, name, err := parseCompositeID(id)
+	if err != nil {
+		return 0, "", err
+	}
+
+	accountID, err := strconv.Atoi(a)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid account ID %q: %w", a, err)
+	}
+
+	return accountID, name, nil
+}
@@ -0,0 +1,167 @@
+package newrelic
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+)
+
+// NRQL alert conditions embedded in other resources, such as the template of
+// newrelic_nrql_alert_condition_set or the conditions of newrelic_alert_policy_bundle,
+// support the arguments of newrelic_nrql_alert_condition, except the ones set on
+// the parent resource and the deprecated ones.
+func embeddedNrqlAlertConditionSchema() map[string]*schema.Schema {
+	s := resourceNewRelicNrqlAlertCondition().Schema
+
+	for _, k := range []string{"policy_id", "account_id", "type", "entity_guid"} {
+		delete(s, k)
+	}
+
+	pruneEmbeddedNrqlAlertConditionSchema(s)
+
+	// These suppress diffs based on top level attributes of newrelic_nrql_alert_condition,
+	// which do not exist within an embedded block.
+	s["aggregation_method"].DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+		return strings.EqualFold(old, new)
+	}
+	s["aggregation_delay"].DiffSuppressFunc = nil
+	s["aggregation_timer"].DiffSuppressFunc = nil
+
+	return s
+}
+
+func pruneEmbeddedNrqlAlertConditionSchema(s map[string]*schema.Schema) {
+	for k, v := range s {
+		if v.Deprecated != "" {
+			delete(s, k)
+			continue
+		}
+
+		// Attribute paths are absolute, so they do not apply within an embedded block.
+		v.ConflictsWith = nil
+		v.RequiredWith = nil
+
+		if r, ok := v.Elem.(*schema.Resource); ok {
+			pruneEmbeddedNrqlAlertConditionSchema(r.Schema)
+		}
+	}
+}
+
+// embeddedNrqlAlertCondition is an embedded condition, expanded into NerdGraph inputs.
+type embeddedNrqlAlertCondition struct {
+	Key         string
+	Type        string
+	Name        string
+	CreateInput *alerts.NrqlConditionCreateInput
+	UpdateInput *alerts.NrqlConditionUpdateInput
+}
+
+// Expands the configuration of an embedded condition with the same logic as
+// newrelic_nrql_alert_condition.
+func expandEmbeddedNrqlAlertCondition(key string, conditionType string, cfg map[string]interface{}) (*embeddedNrqlAlertCondition, error) {
+	d := resourceNewRelicNrqlAlertCondition().Data(nil)
+
+	if err := d.Set("type", conditionType); err != nil {
+		return nil, err
+	}
+
+	for k, v := range cfg {
+		if err := d.Set(k, v); err != nil {
+			return nil, err
+		}
+	}
+
+	createInput, err := expandNrqlAlertConditionCreateInput(d)
+	if err != nil {
+		return nil, err
+	}
+
+	updateInput, err := expandNrqlAlertConditionUpdateInput(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &embeddedNrqlAlertCondition{
+		Key:         key,
+		Type:        conditionType,
+		Name:        createInput.Name,
+		CreateInput: createInput,
+		UpdateInput: updateInput,
+	}, nil
+}
+
+// Splits embedded conditions into the ones to create, update and delete, by
+// comparing the previously applied conditions with the configured ones.
+// Conditions without an ID in state are (re)created.
+func diffEmbeddedNrqlAlertConditions(oldConditions map[string]embeddedNrqlAlertCondition, newConditions map[string]embeddedNrqlAlertCondition, conditionIDs map[string]string) (toCreate []string, toUpdate []string, toDelete []string) {
+	for key, condition := range newConditions {
+		if conditionIDs[key] == "" {
+			toCreate = append(toCreate, key)
+			continue
+		}
+
+		old, ok := oldConditions[key]
+		if !ok || old.Type != condition.Type || !reflect.DeepEqual(old.UpdateInput, condition.UpdateInput) {
+			toUpdate = append(toUpdate, key)
+		}
+	}
+
+	for key := range conditionIDs {
+		if _, ok := newConditions[key]; !ok {
+			toDelete = append(toDelete, key)
+		}
+	}
+
+	sort.Strings(toCreate)
+	sort.Strings(toUpdate)
+	sort.Strings(toDelete)
+
+	return toCreate, toUpdate, toDelete
+}
+
+func createNrqlAlertConditionOfType(ctx context.Context, client *newrelic.NewRelic, accountID int, policyID string, conditionType string, input alerts.NrqlConditionCreateInput) (*alerts.NrqlAlertCondition, error) {
+	switch strings.ToLower(conditionType) {
+	case "baseline":
+		return client.Alerts.CreateNrqlConditionBaselineMutationWithContext(ctx, accountID, policyID, input)
+	case "outlier":
+		return client.Alerts.CreateNrqlConditionOutlierMutationWithContext(ctx, accountID, policyID, input)
+	default:
+		return client.Alerts.CreateNrqlConditionStaticMutationWithContext(ctx, accountID, policyID, input)
+	}
+}
+
+func updateNrqlAlertConditionOfType(ctx context.Context, client *newrelic.NewRelic, accountID int, conditionID string, conditionType string, input alerts.NrqlConditionUpdateInput) (*alerts.NrqlAlertCondition, error) {
+	switch strings.ToLower(conditionType) {
+	case "baseline":
+		return client.Alerts.UpdateNrqlConditionBaselineMutationWithContext(ctx, accountID, conditionID, input)
+	case "outlier":
+		return client.Alerts.UpdateNrqlConditionOutlierMutationWithContext(ctx, accountID, conditionID, input)
+	default:
+		return client.Alerts.UpdateNrqlConditionStaticMutationWithContext(ctx, accountID, conditionID, input)
+	}
+}
+
+func expandEmbeddedNrqlAlertConditionIDs(in interface{}) map[string]string {
+	out := map[string]string{}
+	if m, ok := in.(map[string]interface{}); ok {
+		for k, v := range m {
+			out[k] = v.(string)
+		}
+	}
+
+	return out
+}
+
+func flattenEmbeddedNrqlAlertConditionIDs(in map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+
+	return out
+}
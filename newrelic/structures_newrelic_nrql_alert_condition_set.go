@@ -0,0 +1,368 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+)
+
+// The default number of conditions of a set created, updated or deleted at the same time.
+const nrqlAlertConditionSetDefaultConcurrency = 4
+
+// Matches the `{{ name }}` placeholders of a condition set template.
+var nrqlAlertConditionSetPlaceholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.\-]+)\s*\}\}`)
+
+// Replaces the placeholders of the given template value with the substitutions.
+// Placeholders without a substitution, such as the Handlebars variables of
+// title_template, are left untouched.
+func renderNrqlAlertConditionSetValue(v interface{}, substitutions map[string]string) interface{} {
+	switch x := v.(type) {
+	case string:
+		return nrqlAlertConditionSetPlaceholderPattern.ReplaceAllStringFunc(x, func(placeholder string) string {
+			name := nrqlAlertConditionSetPlaceholderPattern.FindStringSubmatch(placeholder)[1]
+			if value, ok := substitutions[name]; ok {
+				return value
+			}
+			return placeholder
+		})
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, e := range x {
+			out[i] = renderNrqlAlertConditionSetValue(e, substitutions)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			out[k] = renderNrqlAlertConditionSetValue(e, substitutions)
+		}
+		return out
+	case *schema.Set:
+		return renderNrqlAlertConditionSetValue(x.List(), substitutions)
+	}
+
+	return v
+}
+
+func expandNrqlAlertConditionSetSubstitutions(key string, in map[string]interface{}) map[string]string {
+	substitutions := map[string]string{"key": key}
+	for k, v := range in {
+		substitutions[k] = v.(string)
+	}
+
+	return substitutions
+}
+
+// Overrides the threshold of the critical or warning term of a rendered template.
+func setNrqlAlertConditionSetThreshold(cfg map[string]interface{}, priority string, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s_threshold %q: %s", priority, value, err)
+	}
+
+	terms, _ := cfg[priority].([]interface{})
+	if len(terms) == 0 || terms[0] == nil {
+		return fmt.Errorf("%s_threshold is set, but the template has no `%s` block", priority, priority)
+	}
+
+	terms[0].(map[string]interface{})["threshold"] = threshold
+
+	return nil
+}
+
+// Renders the conditions of a set, keyed by instance key. The conditions are
+// expanded with the same logic as newrelic_nrql_alert_condition.
+func expandNrqlAlertConditionSetMembers(conditionType string, template []interface{}, instances []interface{}) (map[string]embeddedNrqlAlertCondition, error) {
+	if len(template) == 0 || template[0] == nil {
+		return nil, fmt.Errorf("a `template` block is required")
+	}
+
+	members := make(map[string]embeddedNrqlAlertCondition, len(instances))
+	names := map[string]string{}
+
+	for _, i := range instances {
+		instance := i.(map[string]interface{})
+		key := instance["key"].(string)
+
+		if _, ok := members[key]; ok {
+			return nil, fmt.Errorf("duplicate instance key %q", key)
+		}
+
+		substitutions := expandNrqlAlertConditionSetSubstitutions(key, instance["substitutions"].(map[string]interface{}))
+		cfg := renderNrqlAlertConditionSetValue(template[0], substitutions).(map[string]interface{})
+
+		if err := setNrqlAlertConditionSetThreshold(cfg, "critical", instance["critical_threshold"].(string)); err != nil {
+			return nil, fmt.Errorf("instance %q: %s", key, err)
+		}
+
+		if err := setNrqlAlertConditionSetThreshold(cfg, "warning", instance["warning_threshold"].(string)); err != nil {
+			return nil, fmt.Errorf("instance %q: %s", key, err)
+		}
+
+		member, err := expandEmbeddedNrqlAlertCondition(key, conditionType, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("instance %q: %s", key, err)
+		}
+
+		if other, ok := names[member.Name]; ok {
+			return nil, fmt.Errorf("instances %q and %q render the same condition name %q; use a placeholder such as {{key}} in the template name", other, key, member.Name)
+		}
+
+		names[member.Name] = key
+		members[key] = *member
+	}
+
+	return members, nil
+}
+
+// Runs fn for every key, with at most `concurrency` calls in flight, and returns
+// the conditions returned by the successful calls. Failures are combined into a
+// single error.
+func applyNrqlAlertConditionSetOperations(ctx context.Context, keys []string, concurrency int, fn func(ctx context.Context, key string) (*alerts.NrqlAlertCondition, error)) (map[string]*alerts.NrqlAlertCondition, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = map[string]*alerts.NrqlAlertCondition{}
+		errs    = map[string]error{}
+		sem     = make(chan struct{}, concurrency)
+	)
+
+	for _, key := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(key string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			condition, err := fn(ctx, key)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs[key] = err
+				return
+			}
+			results[key] = condition
+		}(key)
+	}
+
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return results, nil
+	}
+
+	failed := make([]string, 0, len(errs))
+	for key := range errs {
+		failed = append(failed, key)
+	}
+	sort.Strings(failed)
+
+	messages := make([]string, len(failed))
+	for i, key := range failed {
+		messages[i] = fmt.Sprintf("%s: %s", key, errs[key])
+	}
+
+	return results, fmt.Errorf("%d of %d conditions failed:\n%s", len(errs), len(keys), strings.Join(messages, "\n"))
+}
+
+// The attributes of the conditions of a set compared with their template, to
+// detect the changes made to the conditions outside of Terraform.
+func nrqlAlertConditionSetConditionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"key": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The key of the instance of the condition.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the condition.",
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the condition is enabled.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The description of the condition.",
+		},
+		"runbook_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The runbook URL of the condition.",
+		},
+		"nrql_query": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The NRQL query of the condition.",
+		},
+		"critical_threshold": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The threshold of the critical term of the condition.",
+		},
+		"warning_threshold": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The threshold of the warning term of the condition, if any.",
+		},
+	}
+}
+
+// Flattens a condition of the set as returned by New Relic.
+func flattenNrqlAlertConditionSetCondition(key string, condition *alerts.NrqlAlertCondition) map[string]interface{} {
+	return map[string]interface{}{
+		"key":                key,
+		"name":               condition.Name,
+		"enabled":            condition.Enabled,
+		"description":        condition.Description,
+		"runbook_url":        condition.RunbookURL,
+		"nrql_query":         condition.Nrql.Query,
+		"critical_threshold": nrqlAlertConditionSetTermThreshold(condition.Terms, alerts.NrqlConditionPriorities.Critical),
+		"warning_threshold":  nrqlAlertConditionSetTermThreshold(condition.Terms, alerts.NrqlConditionPriorities.Warning),
+	}
+}
+
+// Flattens a condition of the set as rendered from the template.
+func flattenNrqlAlertConditionSetMember(member embeddedNrqlAlertCondition) map[string]interface{} {
+	input := member.CreateInput
+
+	return map[string]interface{}{
+		"key":                member.Key,
+		"name":               input.Name,
+		"enabled":            input.Enabled,
+		"description":        input.Description,
+		"runbook_url":        input.RunbookURL,
+		"nrql_query":         input.Nrql.Query,
+		"critical_threshold": nrqlAlertConditionSetTermThreshold(input.Terms, alerts.NrqlConditionPriorities.Critical),
+		"warning_threshold":  nrqlAlertConditionSetTermThreshold(input.Terms, alerts.NrqlConditionPriorities.Warning),
+	}
+}
+
+func nrqlAlertConditionSetTermThreshold(terms []alerts.NrqlConditionTerm, priority alerts.NrqlConditionPriority) string {
+	for _, term := range terms {
+		if strings.EqualFold(string(term.Priority), string(priority)) && term.Threshold != nil {
+			return strconv.FormatFloat(*term.Threshold, 'f', -1, 64)
+		}
+	}
+
+	return ""
+}
+
+// Sorts the flattened conditions by key, so the list is stable across reads.
+func sortNrqlAlertConditionSetConditions(conditions []interface{}) []interface{} {
+	sort.Slice(conditions, func(i, j int) bool {
+		return conditions[i].(map[string]interface{})["key"].(string) < conditions[j].(map[string]interface{})["key"].(string)
+	})
+
+	return conditions
+}
+
+// Renders the conditions of the given members, sorted by key.
+func flattenNrqlAlertConditionSetMembers(members map[string]embeddedNrqlAlertCondition) []interface{} {
+	out := make([]interface{}, 0, len(members))
+	for _, member := range members {
+		out = append(out, flattenNrqlAlertConditionSetMember(member))
+	}
+
+	return sortNrqlAlertConditionSetConditions(out)
+}
+
+// Returns the keys of the members whose condition, as last read from New
+// Relic, no longer matches the template, i.e. was changed outside of Terraform.
+// Members without a condition are created rather than updated, so are skipped.
+func driftedNrqlAlertConditionSetMembers(conditions []interface{}, members map[string]embeddedNrqlAlertCondition) []string {
+	var drifted []string
+
+	for _, c := range conditions {
+		condition := c.(map[string]interface{})
+		key := condition["key"].(string)
+
+		member, ok := members[key]
+		if !ok {
+			continue
+		}
+
+		rendered := flattenNrqlAlertConditionSetMember(member)
+		for attribute, value := range rendered {
+			if condition[attribute] != value {
+				drifted = append(drifted, key)
+				break
+			}
+		}
+	}
+
+	sort.Strings(drifted)
+
+	return drifted
+}
+
+// The ID of a set is the ID of its policy, along with a hash of the IDs of the
+// conditions it was created with, so that several sets can share a policy.
+func nrqlAlertConditionSetID(policyID int, conditionIDs map[string]string) string {
+	ids := make([]string, 0, len(conditionIDs))
+	for _, id := range conditionIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return fmt.Sprintf("%d:%d", policyID, schema.HashString(strings.Join(ids, ",")))
+}
+
+// Parses the import ID of a set, `<policy_id>:<key>=<condition_id>[,<key>=<condition_id>...]`.
+func parseNrqlAlertConditionSetImportID(importID string) (int, map[string]string, error) {
+	format := "<policy_id>:<key>=<condition_id>[,<key>=<condition_id>...]"
+
+	parts := strings.SplitN(importID, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return 0, nil, fmt.Errorf("invalid import ID %q, expected %s", importID, format)
+	}
+
+	policyID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid policy ID %q in import ID, expected %s", parts[0], format)
+	}
+
+	conditionIDs := map[string]string{}
+	for _, pair := range strings.Split(parts[1], ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return 0, nil, fmt.Errorf("invalid condition %q in import ID, expected %s", pair, format)
+		}
+
+		key, id := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		if _, err := strconv.Atoi(id); err != nil {
+			return 0, nil, fmt.Errorf("invalid ID %q of condition %q in import ID, expected a number", id, key)
+		}
+		if _, ok := conditionIDs[key]; ok {
+			return 0, nil, fmt.Errorf("duplicate condition %q in import ID", key)
+		}
+
+		conditionIDs[key] = id
+	}
+
+	return policyID, conditionIDs, nil
+}
@@ -0,0 +1,272 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/alerts"
+	"github.com/stretchr/testify/require"
+)
+
+func testNrqlAlertConditionSetData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, resourceNewRelicNrqlAlertConditionSet().Schema, raw)
+}
+
+func testNrqlAlertConditionSetTemplate() []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name":           "{{appName}} error rate",
+			"title_template": "{{conditionName}} on {{appName}}",
+			"nrql": []interface{}{
+				map[string]interface{}{
+					"query": "SELECT percentage(count(*), WHERE error IS true) FROM Transaction WHERE appName = '{{appName}}'",
+				},
+			},
+			"critical": []interface{}{
+				map[string]interface{}{
+					"operator":              "above",
+					"threshold":             5.0,
+					"threshold_duration":    300,
+					"threshold_occurrences": "ALL",
+				},
+			},
+		},
+	}
+}
+
+func TestExpandNrqlAlertConditionSetMembers(t *testing.T) {
+	d := testNrqlAlertConditionSetData(t, map[string]interface{}{
+		"policy_id": 1,
+		"template":  testNrqlAlertConditionSetTemplate(),
+		"instance": []interface{}{
+			map[string]interface{}{
+				"key":           "checkout",
+				"substitutions": map[string]interface{}{"appName": "Checkout Service"},
+			},
+			map[string]interface{}{
+				"key":                "search",
+				"substitutions":      map[string]interface{}{"appName": "Search Service"},
+				"critical_threshold": "0",
+			},
+		},
+	})
+
+	members, err := expandNrqlAlertConditionSetMembers(d.Get("type").(string), d.Get("template").([]interface{}), d.Get("instance").(*schema.Set).List())
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	checkout := members["checkout"]
+	require.Equal(t, "Checkout Service error rate", checkout.Name)
+	require.Equal(t, "SELECT percentage(count(*), WHERE error IS true) FROM Transaction WHERE appName = 'Checkout Service'", checkout.CreateInput.Nrql.Query)
+	// Placeholders without a substitution are Handlebars variables and are kept.
+	require.Equal(t, "{{conditionName}} on Checkout Service", *checkout.CreateInput.TitleTemplate)
+	require.Equal(t, 5.0, *checkout.CreateInput.Terms[0].Threshold)
+	require.Equal(t, alerts.NrqlConditionPriorities.Critical, checkout.CreateInput.Terms[0].Priority)
+	require.Equal(t, 300, checkout.UpdateInput.Terms[0].ThresholdDuration)
+
+	search := members["search"]
+	require.Equal(t, "Search Service error rate", search.Name)
+	require.Equal(t, 0.0, *search.CreateInput.Terms[0].Threshold)
+}
+
+func TestExpandNrqlAlertConditionSetMembers_Errors(t *testing.T) {
+	template := testNrqlAlertConditionSetTemplate()
+
+	tests := map[string]struct {
+		instances []interface{}
+		message   string
+	}{
+		"duplicate names": {
+			instances: []interface{}{
+				map[string]interface{}{"key": "a", "substitutions": map[string]interface{}{"appName": "Same"}},
+				map[string]interface{}{"key": "b", "substitutions": map[string]interface{}{"appName": "Same"}},
+			},
+			message: "render the same condition name",
+		},
+		"duplicate keys": {
+			instances: []interface{}{
+				map[string]interface{}{"key": "a", "substitutions": map[string]interface{}{"appName": "A"}},
+				map[string]interface{}{"key": "a", "substitutions": map[string]interface{}{"appName": "B"}},
+			},
+			message: "duplicate instance key",
+		},
+		"warning threshold without warning term": {
+			instances: []interface{}{
+				map[string]interface{}{"key": "a", "substitutions": map[string]interface{}{"appName": "A"}, "warning_threshold": "1"},
+			},
+			message: "the template has no `warning` block",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, i := range tt.instances {
+				instance := i.(map[string]interface{})
+				for _, k := range []string{"critical_threshold", "warning_threshold"} {
+					if _, ok := instance[k]; !ok {
+						instance[k] = ""
+					}
+				}
+			}
+
+			_, err := expandNrqlAlertConditionSetMembers("static", template, tt.instances)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestDiffEmbeddedNrqlAlertConditions(t *testing.T) {
+	member := func(key string, threshold float64) embeddedNrqlAlertCondition {
+		return embeddedNrqlAlertCondition{
+			Key: key,
+			UpdateInput: &alerts.NrqlConditionUpdateInput{
+				NrqlConditionUpdateBase: alerts.NrqlConditionUpdateBase{
+					Terms: []alerts.NrqlConditionTerm{{Threshold: &threshold}},
+				},
+			},
+		}
+	}
+
+	oldMembers := map[string]embeddedNrqlAlertCondition{
+		"unchanged": member("unchanged", 1),
+		"changed":   member("changed", 1),
+		"removed":   member("removed", 1),
+		"missing":   member("missing", 1),
+	}
+	newMembers := map[string]embeddedNrqlAlertCondition{
+		"unchanged": member("unchanged", 1),
+		"changed":   member("changed", 2),
+		"missing":   member("missing", 1),
+		"added":     member("added", 1),
+	}
+	conditionIDs := map[string]string{
+		"unchanged": "1",
+		"changed":   "2",
+		"removed":   "3",
+	}
+
+	toCreate, toUpdate, toDelete := diffEmbeddedNrqlAlertConditions(oldMembers, newMembers, conditionIDs)
+	require.Equal(t, []string{"added", "missing"}, toCreate)
+	require.Equal(t, []string{"changed"}, toUpdate)
+	require.Equal(t, []string{"removed"}, toDelete)
+}
+
+func TestApplyNrqlAlertConditionSetOperations(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e", "f"}
+
+	var inFlight, maxInFlight int32
+	results, err := applyNrqlAlertConditionSetOperations(context.Background(), keys, 2, func(ctx context.Context, key string) (*alerts.NrqlAlertCondition, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if key == "c" || key == "e" {
+			return nil, fmt.Errorf("boom")
+		}
+		return &alerts.NrqlAlertCondition{ID: key}, nil
+	})
+
+	require.LessOrEqual(t, maxInFlight, int32(2))
+	require.Len(t, results, 4)
+	require.Error(t, err)
+	require.Equal(t, "2 of 6 conditions failed:\nc: boom\ne: boom", err.Error())
+}
+
+func TestDriftedNrqlAlertConditionSetMembers(t *testing.T) {
+	d := testNrqlAlertConditionSetData(t, map[string]interface{}{
+		"policy_id": 1,
+		"template":  testNrqlAlertConditionSetTemplate(),
+		"instance": []interface{}{
+			map[string]interface{}{
+				"key":           "checkout",
+				"substitutions": map[string]interface{}{"appName": "Checkout Service"},
+			},
+			map[string]interface{}{
+				"key":           "search",
+				"substitutions": map[string]interface{}{"appName": "Search Service"},
+			},
+			map[string]interface{}{
+				"key":           "cart",
+				"substitutions": map[string]interface{}{"appName": "Cart Service"},
+			},
+		},
+	})
+
+	members, err := expandNrqlAlertConditionSetMembers(d.Get("type").(string), d.Get("template").([]interface{}), d.Get("instance").(*schema.Set).List())
+	require.NoError(t, err)
+
+	rendered := flattenNrqlAlertConditionSetMembers(members)
+	require.Equal(t, []string{"cart", "checkout", "search"}, []string{
+		rendered[0].(map[string]interface{})["key"].(string),
+		rendered[1].(map[string]interface{})["key"].(string),
+		rendered[2].(map[string]interface{})["key"].(string),
+	})
+	require.Equal(t, "5", rendered[1].(map[string]interface{})["critical_threshold"])
+	require.Equal(t, "", rendered[1].(map[string]interface{})["warning_threshold"])
+	require.Empty(t, driftedNrqlAlertConditionSetMembers(rendered, members))
+
+	// The checkout condition was disabled and the search one got a new
+	// threshold outside of Terraform, the cart one has not been created yet.
+	threshold := 7.0
+	conditions := []interface{}{
+		flattenNrqlAlertConditionSetCondition("checkout", &alerts.NrqlAlertCondition{
+			NrqlConditionBase: alerts.NrqlConditionBase{
+				Name:    "Checkout Service error rate",
+				Enabled: false,
+				Nrql:    alerts.NrqlConditionQuery{Query: members["checkout"].CreateInput.Nrql.Query},
+				Terms:   members["checkout"].CreateInput.Terms,
+			},
+		}),
+		flattenNrqlAlertConditionSetCondition("search", &alerts.NrqlAlertCondition{
+			NrqlConditionBase: alerts.NrqlConditionBase{
+				Name:    "Search Service error rate",
+				Enabled: true,
+				Nrql:    alerts.NrqlConditionQuery{Query: members["search"].CreateInput.Nrql.Query},
+				Terms:   []alerts.NrqlConditionTerm{{Priority: "CRITICAL", Threshold: &threshold}},
+			},
+		}),
+	}
+
+	require.Equal(t, []string{"checkout", "search"}, driftedNrqlAlertConditionSetMembers(conditions, members))
+}
+
+func TestNrqlAlertConditionSetID(t *testing.T) {
+	first := nrqlAlertConditionSetID(1, map[string]string{"checkout": "10", "search": "11"})
+	second := nrqlAlertConditionSetID(1, map[string]string{"cart": "12"})
+
+	require.Regexp(t, `^1:\d+$`, first)
+	require.NotEqual(t, first, second)
+	require.Equal(t, first, nrqlAlertConditionSetID(1, map[string]string{"search": "11", "checkout": "10"}))
+}
+
+func TestParseNrqlAlertConditionSetImportID(t *testing.T) {
+	policyID, conditionIDs, err := parseNrqlAlertConditionSetImportID("123:checkout=10, search=11")
+	require.NoError(t, err)
+	require.Equal(t, 123, policyID)
+	require.Equal(t, map[string]string{"checkout": "10", "search": "11"}, conditionIDs)
+
+	for importID, expected := range map[string]string{
+		"123":                       "expected <policy_id>:<key>=<condition_id>",
+		"abc:checkout=10":           "invalid policy ID",
+		"123:checkout":              "invalid condition \"checkout\"",
+		"123:checkout=x":            "expected a number",
+		"123:checkout=1,checkout=2": "duplicate condition \"checkout\"",
+	} {
+		_, _, err := parseNrqlAlertConditionSetImportID(importID)
+		require.ErrorContains(t, err, expected, importID)
+	}
+}
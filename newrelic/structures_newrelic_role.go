@@ -0,0 +1,454 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/authorizationmanagement"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/customeradministration"
+)
+
+// Custom roles are not covered by the NerdGraph client yet, so their mutations
+// and the permissions of a role are queried directly.
+const customRoleCreateMutation = `mutation(
+	$container: CustomRoleContainerInput!,
+	$name: String!,
+	$permissionIds: [Int!]!,
+) { customRoleCreate(
+	container: $container,
+	name: $name,
+	permissionIds: $permissionIds,
+) {
+	id
+} }`
+
+const customRoleUpdateMutation = `mutation(
+	$id: Int!,
+	$name: String,
+	$permissionIds: [Int!],
+) { customRoleUpdate(
+	id: $id,
+	name: $name,
+	permissionIds: $permissionIds,
+) {
+	id
+} }`
+
+const customRoleDeleteMutation = `mutation(
+	$id: Int!,
+) { customRoleDelete(
+	id: $id,
+) {
+	id
+} }`
+
+const rolePermissionsQuery = `query(
+	$cursor: String,
+	$roleId: String!,
+) { customerAdministration { permissions(
+	cursor: $cursor,
+	filter: { roleId: { eq: $roleId } },
+) {
+	items {
+		id
+	}
+	nextCursor
+} } }`
+
+type customRoleContainerInput struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type customRoleResponse struct {
+	ID int `json:"id"`
+}
+
+type customRoleCreateResponse struct {
+	CustomRoleCreate customRoleResponse `json:"customRoleCreate"`
+}
+
+type customRoleUpdateResponse struct {
+	CustomRoleUpdate customRoleResponse `json:"customRoleUpdate"`
+}
+
+type customRoleDeleteResponse struct {
+	CustomRoleDelete customRoleResponse `json:"customRoleDelete"`
+}
+
+type rolePermissionsResponse struct {
+	CustomerAdministration struct {
+		Permissions customeradministration.MultiTenantAuthorizationPermissionCollection `json:"permissions"`
+	} `json:"customerAdministration"`
+}
+
+func createCustomRole(ctx context.Context, client *newrelic.NewRelic, organizationID string, name string, permissionIDs []int) (int, error) {
+	resp := customRoleCreateResponse{}
+	vars := map[string]interface{}{
+		"container":     customRoleContainerInput{ID: organizationID, Type: "ORGANIZATION"},
+		"name":          name,
+		"permissionIds": permissionIDs,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, customRoleCreateMutation, vars, &resp); err != nil {
+		return 0, err
+	}
+
+	if resp.CustomRoleCreate.ID == 0 {
+		return 0, fmt.Errorf("error creating custom role %q: no ID returned", name)
+	}
+
+	return resp.CustomRoleCreate.ID, nil
+}
+
+func updateCustomRole(ctx context.Context, client *newrelic.NewRelic, roleID int, name string, permissionIDs []int) error {
+	resp := customRoleUpdateResponse{}
+	vars := map[string]interface{}{
+		"id":            roleID,
+		"name":          name,
+		"permissionIds": permissionIDs,
+	}
+
+	return client.NerdGraph.QueryWithResponseAndContext(ctx, customRoleUpdateMutation, vars, &resp)
+}
+
+func deleteCustomRole(ctx context.Context, client *newrelic.NewRelic, roleID int) error {
+	resp := customRoleDeleteResponse{}
+	vars := map[string]interface{}{
+		"id": roleID,
+	}
+
+	return client.NerdGraph.QueryWithResponseAndContext(ctx, customRoleDeleteMutation, vars, &resp)
+}
+
+// Returns the role with the given ID, or nil if it does not exist.
+func getRole(ctx context.Context, client *newrelic.NewRelic, roleID int) (*customeradministration.MultiTenantAuthorizationRole, error) {
+	roles, err := client.CustomerAdministration.GetRolesWithContext(
+		ctx,
+		"",
+		customeradministration.MultiTenantAuthorizationRoleFilterInputExpression{
+			ID: &customeradministration.MultiTenantAuthorizationRoleIdInputFilter{Eq: roleID},
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles.Items {
+		if role.ID == roleID {
+			return &role, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func getRolePermissionIDs(ctx context.Context, client *newrelic.NewRelic, roleID int) ([]string, error) {
+	var permissionIDs []string
+
+	cursor := ""
+	for {
+		resp := rolePermissionsResponse{}
+		vars := map[string]interface{}{
+			"roleId": strconv.Itoa(roleID),
+		}
+		if cursor != "" {
+			vars["cursor"] = cursor
+		}
+
+		if err := client.NerdGraph.QueryWithResponseAndContext(ctx, rolePermissionsQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, p := range resp.CustomerAdministration.Permissions.Items {
+			permissionIDs = append(permissionIDs, p.ID)
+		}
+
+		cursor = resp.CustomerAdministration.Permissions.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+
+	sort.Strings(permissionIDs)
+
+	return permissionIDs, nil
+}
+
+func expandRolePermissionIDs(in []interface{}) ([]int, error) {
+	out := make([]int, 0, len(in))
+	for _, v := range in {
+		id, err := strconv.Atoi(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid permission ID %q: must be numeric", v)
+		}
+		out = append(out, id)
+	}
+
+	sort.Ints(out)
+
+	return out, nil
+}
+
+func flattenRoles(roles []customeradministration.MultiTenantAuthorizationRole) []interface{} {
+	out := make([]interface{}, len(roles))
+	for i, role := range roles {
+		out[i] = map[string]interface{}{
+			"id":    strconv.Itoa(role.ID),
+			"name":  role.Name,
+			"scope": strings.ToUpper(role.Scope),
+			"type":  strings.ToUpper(role.Type),
+		}
+	}
+
+	return out
+}
+
+// groupAccessGrantID identifies a grant of a role to a group on an account or
+// the organization, serialized as <group_id>:<role_id>:<scope_type>:<scope_id>.
+type groupAccessGrantID struct {
+	GroupID   string
+	RoleID    string
+	ScopeType string
+	ScopeID   string
+}
+
+func (id groupAccessGrantID) String() string {
+	return strings.Join([]string{id.GroupID, id.RoleID, id.ScopeType, id.ScopeID}, ":")
+}
+
+func parseGroupAccessGrantID(id string) (*groupAccessGrantID, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid group access grant ID %q, expected <group_id>:<role_id>:<scope_type>:<scope_id>", id)
+	}
+
+	for _, p := range parts {
+		if p == "" {
+			return nil, fmt.Errorf("invalid group access grant ID %q, expected <group_id>:<role_id>:<scope_type>:<scope_id>", id)
+		}
+	}
+
+	scopeType := strings.ToUpper(parts[2])
+	switch scopeType {
+	case string(authorizationmanagement.AuthorizationManagementIamParentScopeTypeEnumTypes.ACCOUNT),
+		string(authorizationmanagement.AuthorizationManagementIamParentScopeTypeEnumTypes.ORGANIZATION):
+	default:
+		return nil, fmt.Errorf("invalid scope type %q in group access grant ID %q, expected ACCOUNT or ORGANIZATION", parts[2], id)
+	}
+
+	if _, err := strconv.Atoi(parts[1]); err != nil {
+		return nil, fmt.Errorf("invalid role ID %q in group access grant ID %q: must be numeric", parts[1], id)
+	}
+
+	return &groupAccessGrantID{
+		GroupID:   parts[0],
+		RoleID:    parts[1],
+		ScopeType: scopeType,
+		ScopeID:   parts[3],
+	}, nil
+}
+
+func expandGroupAccessGrant(id groupAccessGrantID, dataAccessPolicyID string) (authorizationmanagement.AuthorizationManagementGrantAccess, error) {
+	grant := authorizationmanagement.AuthorizationManagementGrantAccess{GroupId: id.GroupID}
+
+	if id.ScopeType == string(authorizationmanagement.AuthorizationManagementIamParentScopeTypeEnumTypes.ORGANIZATION) {
+		if dataAccessPolicyID != "" {
+			return grant, fmt.Errorf("data access policies can only be used with grants on an account")
+		}
+
+		grant.OrganizationAccessGrants = []authorizationmanagement.AuthorizationManagementOrganizationAccessGrant{
+			{RoleId: id.RoleID},
+		}
+		return grant, nil
+	}
+
+	accountID, err := strconv.Atoi(id.ScopeID)
+	if err != nil {
+		return grant, fmt.Errorf("invalid account ID %q: must be numeric", id.ScopeID)
+	}
+
+	grant.AccountAccessGrants = []authorizationmanagement.AuthorizationManagementAccountAccessGrant{
+		{
+			AccountID:          accountID,
+			RoleId:             id.RoleID,
+			DataAccessPolicyId: dataAccessPolicyID,
+		},
+	}
+
+	return grant, nil
+}
+
+// Revokes the role of the ID from the group on its scope.
+func revokeGroupAccessGrant(ctx context.Context, client *newrelic.NewRelic, id groupAccessGrantID, dataAccessPolicyID string) error {
+	grant, err := expandGroupAccessGrant(id, dataAccessPolicyID)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Revoking role %s on %s %s from group %s", id.RoleID, id.ScopeType, id.ScopeID, id.GroupID)
+
+	_, err = client.AuthorizationManagement.AuthorizationManagementRevokeAccessWithContext(ctx, authorizationmanagement.AuthorizationManagementRevokeAccess{
+		GroupId:                  grant.GroupId,
+		AccountAccessGrants:      grant.AccountAccessGrants,
+		OrganizationAccessGrants: grant.OrganizationAccessGrants,
+	})
+
+	return err
+}
+
+// Returns the grant matching the ID, or nil if the role is not granted to the group
+// on the scope, e.g. because it was revoked outside of Terraform.
+func getGroupAccessGrant(ctx context.Context, client *newrelic.NewRelic, id groupAccessGrantID) (*customeradministration.MultiTenantAuthorizationGrant, error) {
+	roleID, err := strconv.Atoi(id.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := client.CustomerAdministration.GetGrantsWithContext(
+		ctx,
+		"",
+		customeradministration.MultiTenantAuthorizationGrantFilterInputExpression{
+			GroupId:   &customeradministration.MultiTenantAuthorizationGrantGroupIdInputFilter{Eq: id.GroupID},
+			RoleId:    &customeradministration.MultiTenantAuthorizationGrantRoleIdInputFilter{Eq: roleID},
+			ScopeId:   &customeradministration.MultiTenantAuthorizationGrantScopeIdInputFilter{Eq: id.ScopeID},
+			ScopeType: &customeradministration.MultiTenantAuthorizationGrantScopeTypeInputFilter{Eq: customeradministration.MultiTenantAuthorizationGrantScopeEnum(id.ScopeType)},
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return findGroupAccessGrant(grants.Items, id), nil
+}
+
+func findGroupAccessGrant(grants []customeradministration.MultiTenantAuthorizationGrant, id groupAccessGrantID) *customeradministration.MultiTenantAuthorizationGrant {
+	for _, grant := range grants {
+		if grant.Group.ID == id.GroupID &&
+			strconv.Itoa(grant.Role.ID) == id.RoleID &&
+			grant.Scope.ID == id.ScopeID &&
+			strings.EqualFold(string(grant.Scope.Type), id.ScopeType) {
+			return &grant
+		}
+	}
+
+	return nil
+}
+
+// Returns all the grants of the group on the scope of the ID, whatever their role.
+func getGroupScopeAccessGrants(ctx context.Context, client *newrelic.NewRelic, id groupAccessGrantID) ([]customeradministration.MultiTenantAuthorizationGrant, error) {
+	var grants []customeradministration.MultiTenantAuthorizationGrant
+
+	cursor := ""
+	for {
+		page, err := client.CustomerAdministration.GetGrantsWithContext(
+			ctx,
+			cursor,
+			customeradministration.MultiTenantAuthorizationGrantFilterInputExpression{
+				GroupId:   &customeradministration.MultiTenantAuthorizationGrantGroupIdInputFilter{Eq: id.GroupID},
+				ScopeId:   &customeradministration.MultiTenantAuthorizationGrantScopeIdInputFilter{Eq: id.ScopeID},
+				ScopeType: &customeradministration.MultiTenantAuthorizationGrantScopeTypeInputFilter{Eq: customeradministration.MultiTenantAuthorizationGrantScopeEnum(id.ScopeType)},
+			},
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		grants = append(grants, page.Items...)
+
+		cursor = page.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+
+	return grants, nil
+}
+
+// Returns the grants of the group on the scope of the ID which are neither the
+// grant of the ID nor a grant of one of the other roles, sorted by role ID.
+func unmanagedGroupAccessGrants(grants []customeradministration.MultiTenantAuthorizationGrant, id groupAccessGrantID, otherRoleIDs []string) []customeradministration.MultiTenantAuthorizationGrant {
+	var unmanaged []customeradministration.MultiTenantAuthorizationGrant
+
+	for _, grant := range grants {
+		roleID := strconv.Itoa(grant.Role.ID)
+		if grant.Group.ID != id.GroupID ||
+			grant.Scope.ID != id.ScopeID ||
+			!strings.EqualFold(string(grant.Scope.Type), id.ScopeType) ||
+			roleID == id.RoleID ||
+			stringInSlice(otherRoleIDs, roleID) {
+			continue
+		}
+
+		unmanaged = append(unmanaged, grant)
+	}
+
+	sort.Slice(unmanaged, func(i, j int) bool {
+		if unmanaged[i].Role.ID != unmanaged[j].Role.ID {
+			return unmanaged[i].Role.ID < unmanaged[j].Role.ID
+		}
+		return unmanaged[i].ID < unmanaged[j].ID
+	})
+
+	return unmanaged
+}
+
+// Revokes the grants of the group on the scope of the ID which are not managed, i.e. neither the grant of
+// the ID nor a grant of one of the other roles. Grants are eventually consistent, so the revoked grants are
+// waited for to be gone, for the state read afterwards to have no unmanaged grants, as planned.
+func revokeUnmanagedGroupAccessGrants(ctx context.Context, client *newrelic.NewRelic, id groupAccessGrantID, otherRoleIDs []string, timeout time.Duration) error {
+	grants, err := getGroupScopeAccessGrants(ctx, client, id)
+	if err != nil {
+		return err
+	}
+
+	unmanaged := unmanagedGroupAccessGrants(grants, id, otherRoleIDs)
+	if len(unmanaged) == 0 {
+		return nil
+	}
+
+	for _, grant := range unmanaged {
+		unmanagedID := id
+		unmanagedID.RoleID = strconv.Itoa(grant.Role.ID)
+
+		if err := revokeGroupAccessGrant(ctx, client, unmanagedID, grant.DataAccessPolicy.ID); err != nil {
+			return err
+		}
+	}
+
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		grants, err := getGroupScopeAccessGrants(ctx, client, id)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if remaining := unmanagedGroupAccessGrants(grants, id, otherRoleIDs); len(remaining) > 0 {
+			return resource.RetryableError(fmt.Errorf("expected role %d to have been revoked from group %s on %s %s", remaining[0].Role.ID, id.GroupID, id.ScopeType, id.ScopeID))
+		}
+
+		return nil
+	})
+}
+
+func flattenUnmanagedGroupAccessGrants(grants []customeradministration.MultiTenantAuthorizationGrant) []interface{} {
+	out := make([]interface{}, len(grants))
+	for i, grant := range grants {
+		out[i] = map[string]interface{}{
+			"grant_id":              strconv.Itoa(grant.ID),
+			"role_id":               strconv.Itoa(grant.Role.ID),
+			"data_access_policy_id": grant.DataAccessPolicy.ID,
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,194 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/authorizationmanagement"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/customeradministration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGroupAccessGrantID(t *testing.T) {
+	id, err := parseGroupAccessGrantID("abc-123:1254:account:12345")
+	require.NoError(t, err)
+	require.Equal(t, groupAccessGrantID{GroupID: "abc-123", RoleID: "1254", ScopeType: "ACCOUNT", ScopeID: "12345"}, *id)
+	require.Equal(t, "abc-123:1254:ACCOUNT:12345", id.String())
+
+	id, err = parseGroupAccessGrantID("abc-123:1254:ORGANIZATION:org-1")
+	require.NoError(t, err)
+	require.Equal(t, "ORGANIZATION", id.ScopeType)
+
+	for _, invalid := range []string{
+		"abc-123:1254:ACCOUNT",
+		"abc-123::ACCOUNT:12345",
+		"abc-123:admin:ACCOUNT:12345",
+		"abc-123:1254:GROUP:12345",
+	} {
+		_, err := parseGroupAccessGrantID(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestExpandGroupAccessGrant(t *testing.T) {
+	grant, err := expandGroupAccessGrant(groupAccessGrantID{GroupID: "g", RoleID: "1254", ScopeType: "ACCOUNT", ScopeID: "12345"}, "policy")
+	require.NoError(t, err)
+	require.Equal(t, authorizationmanagement.AuthorizationManagementGrantAccess{
+		GroupId: "g",
+		AccountAccessGrants: []authorizationmanagement.AuthorizationManagementAccountAccessGrant{
+			{AccountID: 12345, RoleId: "1254", DataAccessPolicyId: "policy"},
+		},
+	}, grant)
+
+	grant, err = expandGroupAccessGrant(groupAccessGrantID{GroupID: "g", RoleID: "1254", ScopeType: "ORGANIZATION", ScopeID: "org"}, "")
+	require.NoError(t, err)
+	require.Equal(t, authorizationmanagement.AuthorizationManagementGrantAccess{
+		GroupId: "g",
+		OrganizationAccessGrants: []authorizationmanagement.AuthorizationManagementOrganizationAccessGrant{
+			{RoleId: "1254"},
+		},
+	}, grant)
+
+	_, err = expandGroupAccessGrant(groupAccessGrantID{GroupID: "g", RoleID: "1254", ScopeType: "ORGANIZATION", ScopeID: "org"}, "policy")
+	require.Error(t, err)
+
+	_, err = expandGroupAccessGrant(groupAccessGrantID{GroupID: "g", RoleID: "1254", ScopeType: "ACCOUNT", ScopeID: "abc"}, "")
+	require.Error(t, err)
+}
+
+func TestFindGroupAccessGrant(t *testing.T) {
+	grant := func(id int, groupID string, roleID int, scopeType string, scopeID string) customeradministration.MultiTenantAuthorizationGrant {
+		return customeradministration.MultiTenantAuthorizationGrant{
+			ID:    id,
+			Group: customeradministration.MultiTenantAuthorizationGrantGroup{ID: groupID},
+			Role:  customeradministration.MultiTenantAuthorizationGrantRole{ID: roleID},
+			Scope: customeradministration.MultiTenantAuthorizationGrantScope{
+				ID:   scopeID,
+				Type: customeradministration.MultiTenantAuthorizationGrantScopeEnum(scopeType),
+			},
+		}
+	}
+	grants := []customeradministration.MultiTenantAuthorizationGrant{
+		grant(1, "g", 1254, "ACCOUNT", "1"),
+		grant(2, "g", 1254, "ACCOUNT", "2"),
+		grant(3, "g", 1255, "ORGANIZATION", "org"),
+	}
+
+	found := findGroupAccessGrant(grants, groupAccessGrantID{GroupID: "g", RoleID: "1254", ScopeType: "ACCOUNT", ScopeID: "2"})
+	require.NotNil(t, found)
+	require.Equal(t, 2, found.ID)
+
+	found = findGroupAccessGrant(grants, groupAccessGrantID{GroupID: "g", RoleID: "1255", ScopeType: "ORGANIZATION", ScopeID: "org"})
+	require.NotNil(t, found)
+	require.Equal(t, 3, found.ID)
+
+	// Revoked outside of Terraform
+	require.Nil(t, findGroupAccessGrant(grants, groupAccessGrantID{GroupID: "g", RoleID: "1255", ScopeType: "ACCOUNT", ScopeID: "1"}))
+}
+
+func TestUnmanagedGroupAccessGrants(t *testing.T) {
+	grant := func(id int, groupID string, roleID int, scopeType string, scopeID string) customeradministration.MultiTenantAuthorizationGrant {
+		return customeradministration.MultiTenantAuthorizationGrant{
+			ID:    id,
+			Group: customeradministration.MultiTenantAuthorizationGrantGroup{ID: groupID},
+			Role:  customeradministration.MultiTenantAuthorizationGrantRole{ID: roleID},
+			Scope: customeradministration.MultiTenantAuthorizationGrantScope{
+				ID:   scopeID,
+				Type: customeradministration.MultiTenantAuthorizationGrantScopeEnum(scopeType),
+			},
+		}
+	}
+	grants := []customeradministration.MultiTenantAuthorizationGrant{
+		grant(1, "g", 1254, "ACCOUNT", "1"),
+		grant(2, "g", 1300, "ACCOUNT", "1"),
+		grant(3, "g", 1256, "ACCOUNT", "1"),
+		grant(4, "g", 1255, "ACCOUNT", "1"),
+		grant(5, "g", 1257, "ACCOUNT", "2"),
+		grant(6, "other", 1258, "ACCOUNT", "1"),
+	}
+	grants[3].DataAccessPolicy.ID = "policy"
+
+	id := groupAccessGrantID{GroupID: "g", RoleID: "1254", ScopeType: "ACCOUNT", ScopeID: "1"}
+
+	unmanaged := unmanagedGroupAccessGrants(grants, id, []string{"1256"})
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"grant_id": "4", "role_id": "1255", "data_access_policy_id": "policy"},
+		map[string]interface{}{"grant_id": "2", "role_id": "1300", "data_access_policy_id": ""},
+	}, flattenUnmanagedGroupAccessGrants(unmanaged))
+
+	require.Empty(t, unmanagedGroupAccessGrants(grants, id, []string{"1255", "1256", "1300"}))
+}
+
+func TestRevokeUnmanagedGroupAccessGrants(t *testing.T) {
+	t.Parallel()
+
+	grant := func(id int, roleID int) string {
+		return fmt.Sprintf(`{"id": %d, "group": {"id": "g"}, "role": {"id": %d}, "scope": {"id": "1", "type": "ACCOUNT"}, "dataAccessPolicy": {}}`, id, roleID)
+	}
+
+	// The revoked grant is still listed by the first read after the revocation
+	lists := 0
+	var revoked []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request testNerdGraphRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(request.Query, "authorizationManagementRevokeAccess") {
+			revoked = append(revoked, request.Variables["revokeAccessOptions"])
+			_, _ = w.Write([]byte(`{"data": {"authorizationManagementRevokeAccess": {"accessGrants": [{"id": "2"}]}}}`))
+			return
+		}
+
+		lists++
+		items := []string{grant(1, 1254), grant(3, 1256)}
+		if lists <= 2 {
+			items = append(items, grant(2, 1300))
+		}
+		_, _ = w.Write([]byte(`{"data": {"customerAdministration": {"grants": {"items": [` + strings.Join(items, ",") + `], "nextCursor": null}}}}`))
+	}))
+	defer server.Close()
+
+	client, err := newrelic.New(newrelic.ConfigPersonalAPIKey("NRAK-TEST"), newrelic.ConfigNerdGraphBaseURL(server.URL))
+	require.NoError(t, err)
+
+	id := groupAccessGrantID{GroupID: "g", RoleID: "1254", ScopeType: "ACCOUNT", ScopeID: "1"}
+	require.NoError(t, revokeUnmanagedGroupAccessGrants(context.Background(), client, id, []string{"1256"}, time.Minute))
+
+	require.Len(t, revoked, 1)
+	require.Contains(t, fmt.Sprint(revoked[0]), "1300")
+	require.Equal(t, 3, lists)
+
+	// Nothing is revoked when every grant is managed
+	revoked = nil
+	require.NoError(t, revokeUnmanagedGroupAccessGrants(context.Background(), client, id, []string{"1256"}, time.Minute))
+	require.Empty(t, revoked)
+}
+
+func TestExpandRolePermissionIDs(t *testing.T) {
+	ids, err := expandRolePermissionIDs([]interface{}{"30", "2", "100"})
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 30, 100}, ids)
+
+	_, err = expandRolePermissionIDs([]interface{}{"read"})
+	require.Error(t, err)
+}
+
+func TestFlattenRoles(t *testing.T) {
+	roles := flattenRoles([]customeradministration.MultiTenantAuthorizationRole{
+		{ID: 1254, Name: "All Product Admin", Scope: "account", Type: "standard"},
+	})
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"id": "1254", "name": "All Product Admin", "scope": "ACCOUNT", "type": "STANDARD"},
+	}, roles)
+}
@@ -0,0 +1,113 @@
+package newrelic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const syntheticsPublicLocationCloudPrefix = "AWS_"
+
+// All the public locations run in AWS, so locations are only spread across continents.
+const syntheticsPublicLocationsSpreadContinents = "spread_continents"
+
+// syntheticsPublicLocation describes a public location monitors can run from.
+type syntheticsPublicLocation struct {
+	id        SyntheticsPublicLocation
+	label     string
+	region    string
+	cloud     string
+	continent string
+}
+
+// The catalog of the public locations, as listed in
+// https://docs.newrelic.com/docs/synthetics/synthetic-monitoring/administration/synthetic-public-minion-ips/
+var syntheticsPublicLocationCatalog = []syntheticsPublicLocation{
+	{syntheticsPublicLocations.US_EAST_1, "Washington, DC, USA", "us-east-1", "AWS", "North America"},
+	{syntheticsPublicLocations.US_EAST_2, "Columbus, OH, USA", "us-east-2", "AWS", "North America"},
+	{syntheticsPublicLocations.US_WEST_1, "San Francisco, CA, USA", "us-west-1", "AWS", "North America"},
+	{syntheticsPublicLocations.US_WEST_2, "Portland, OR, USA", "us-west-2", "AWS", "North America"},
+	{syntheticsPublicLocations.CA_CENTRAL_1, "Montreal, Québec, CA", "ca-central-1", "AWS", "North America"},
+	{syntheticsPublicLocations.EU_WEST_1, "Dublin, IE", "eu-west-1", "AWS", "Europe"},
+	{syntheticsPublicLocations.EU_WEST_2, "London, England, UK", "eu-west-2", "AWS", "Europe"},
+	{syntheticsPublicLocations.EU_WEST_3, "Paris, FR", "eu-west-3", "AWS", "Europe"},
+	{syntheticsPublicLocations.EU_CENTRAL_1, "Frankfurt, DE", "eu-central-1", "AWS", "Europe"},
+	{syntheticsPublicLocations.EU_SOUTH_1, "Milan, IT", "eu-south-1", "AWS", "Europe"},
+	{syntheticsPublicLocations.EU_NORTH_1, "Stockholm, SE", "eu-north-1", "AWS", "Europe"},
+	{syntheticsPublicLocations.SA_EAST_1, "São Paulo, BR", "sa-east-1", "AWS", "South America"},
+	{syntheticsPublicLocations.AF_SOUTH_1, "Cape Town, ZA", "af-south-1", "AWS", "Africa"},
+	{syntheticsPublicLocations.AP_EAST_1, "Hong Kong, HK", "ap-east-1", "AWS", "Asia"},
+	{syntheticsPublicLocations.ME_SOUTH_1, "Manama, BH", "me-south-1", "AWS", "Asia"},
+	{syntheticsPublicLocations.AP_SOUTH_1, "Mumbai, IN", "ap-south-1", "AWS", "Asia"},
+	{syntheticsPublicLocations.AP_NORTHEAST_2, "Seoul, KR", "ap-northeast-2", "AWS", "Asia"},
+	{syntheticsPublicLocations.AP_SOUTHEAST_1, "Singapore, SG", "ap-southeast-1", "AWS", "Asia"},
+	{syntheticsPublicLocations.AP_NORTHEAST_1, "Tokyo, JP", "ap-northeast-1", "AWS", "Asia"},
+	{syntheticsPublicLocations.AP_SOUTHEAST_2, "Sydney, AU", "ap-southeast-2", "AWS", "Oceania"},
+}
+
+// findSyntheticsPublicLocation returns the location of the catalog with the given ID, with or without its cloud prefix.
+func findSyntheticsPublicLocation(id string) (syntheticsPublicLocation, bool) {
+	id = strings.TrimPrefix(id, syntheticsPublicLocationCloudPrefix)
+
+	for _, l := range syntheticsPublicLocationCatalog {
+		if string(l.id) == id {
+			return l, true
+		}
+	}
+
+	return syntheticsPublicLocation{}, false
+}
+
+// validateSyntheticsPublicLocation checks the locations_public of monitors against the catalog. Locations
+// missing from it are reported with a warning rather than an error, since new locations may be added before
+// the catalog is updated.
+func validateSyntheticsPublicLocation(v interface{}, key string) (warns []string, errs []error) {
+	id, ok := v.(string)
+	if !ok {
+		errs = append(errs, fmt.Errorf("expected type of %s to be string", key))
+		return warns, errs
+	}
+
+	if _, ok := findSyntheticsPublicLocation(id); !ok {
+		var ids []string
+		for _, l := range syntheticsPublicLocationCatalog {
+			ids = append(ids, string(l.id))
+		}
+		warns = append(warns, fmt.Sprintf("%s contains the unknown public location %q, expected one of %s", key, id, strings.Join(ids, ", ")))
+	}
+
+	return warns, errs
+}
+
+// selectSyntheticsPublicLocations picks count locations, taking them in turn from each continent, so that
+// they are spread as evenly as possible. The continents are visited in the order of their first location in
+// the catalog, and the locations of each continent in the order of the catalog.
+func selectSyntheticsPublicLocations(locations []syntheticsPublicLocation, count int) ([]string, error) {
+	if count > len(locations) {
+		return nil, fmt.Errorf("%d locations can't be selected from the %d matching locations", count, len(locations))
+	}
+
+	var groups []string
+	members := map[string][]syntheticsPublicLocation{}
+	for _, l := range locations {
+		g := l.continent
+		if _, ok := members[g]; !ok {
+			groups = append(groups, g)
+		}
+		members[g] = append(members[g], l)
+	}
+
+	selected := []string{}
+	for round := 0; len(selected) < count; round++ {
+		for _, g := range groups {
+			if round < len(members[g]) && len(selected) < count {
+				selected = append(selected, string(members[g][round].id))
+			}
+		}
+	}
+
+	// Sorted, so that the same locations are always in the same order
+	sort.Strings(selected)
+
+	return selected, nil
+}
@@ -0,0 +1,152 @@
+package newrelic
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// syntheticsSecretProvider reads the values of secure credentials from outside of the configuration,
+// so that they are never stored in the state.
+type syntheticsSecretProvider interface {
+	// Resolve returns the secret the reference points to.
+	Resolve(ctx context.Context, reference string) (string, error)
+}
+
+// syntheticsSecretProviders are the types of value_source. Other secret stores are supported by adding
+// an implementation of syntheticsSecretProvider here.
+var syntheticsSecretProviders = map[string]syntheticsSecretProvider{
+	"env":          syntheticsEnvSecretProvider{},
+	"file":         syntheticsFileSecretProvider{},
+	"secrets_file": syntheticsSecretsFileSecretProvider{},
+}
+
+func syntheticsSecretProviderTypes() []string {
+	types := make([]string, 0, len(syntheticsSecretProviders))
+	for t := range syntheticsSecretProviders {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// syntheticsEnvSecretProvider reads the secret from the environment variable named by the reference.
+type syntheticsEnvSecretProvider struct{}
+
+func (syntheticsEnvSecretProvider) Resolve(_ context.Context, reference string) (string, error) {
+	value, ok := os.LookupEnv(reference)
+	if !ok {
+		return "", fmt.Errorf("the environment variable %s is not set", reference)
+	}
+
+	return value, nil
+}
+
+// syntheticsFileSecretProvider reads the secret from the file at the path of the reference,
+// without its trailing newline.
+type syntheticsFileSecretProvider struct{}
+
+func (syntheticsFileSecretProvider) Resolve(_ context.Context, reference string) (string, error) {
+	content, err := os.ReadFile(reference)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// syntheticsSecretsFileSecretProvider reads the secret from a JSON object of secrets by name, with references
+// of the form path#name. It stands in for a secret store where one isn't available, e.g. in tests and CI.
+type syntheticsSecretsFileSecretProvider struct{}
+
+func (syntheticsSecretsFileSecretProvider) Resolve(_ context.Context, reference string) (string, error) {
+	i := strings.LastIndex(reference, "#")
+	if i <= 0 || i == len(reference)-1 {
+		return "", fmt.Errorf("invalid reference %q, expected path#name", reference)
+	}
+	path, name := reference[:i], reference[i+1:]
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	var secrets map[string]string
+	if err := json.Unmarshal(content, &secrets); err != nil {
+		return "", fmt.Errorf("invalid secrets file %s: %w", path, err)
+	}
+
+	value, ok := secrets[name]
+	if !ok {
+		return "", fmt.Errorf("the secrets file %s has no secret %s", path, name)
+	}
+
+	return value, nil
+}
+
+// syntheticsSecureCredentialData is satisfied by both schema.ResourceData and schema.ResourceDiff,
+// so the value is resolved the same way at plan and apply time.
+type syntheticsSecureCredentialData interface {
+	Get(key string) interface{}
+}
+
+// resolveSyntheticsSecureCredentialValue returns the value of the credential, from either value or value_source.
+func resolveSyntheticsSecureCredentialValue(ctx context.Context, d syntheticsSecureCredentialData) (string, error) {
+	sources := d.Get("value_source").([]interface{})
+	if len(sources) == 0 || sources[0] == nil {
+		return d.Get("value").(string), nil
+	}
+
+	source := sources[0].(map[string]interface{})
+	sourceType := source["type"].(string)
+	reference := source["reference"].(string)
+
+	provider, ok := syntheticsSecretProviders[sourceType]
+	if !ok {
+		return "", fmt.Errorf("unknown value_source type %q, expected one of %s", sourceType, strings.Join(syntheticsSecretProviderTypes(), ", "))
+	}
+
+	value, err := provider.Resolve(ctx, reference)
+	if err != nil {
+		return "", fmt.Errorf("reading the secure credential value from %s %q: %w", sourceType, reference, err)
+	}
+	if value == "" {
+		return "", fmt.Errorf("the secure credential value read from %s %q is empty", sourceType, reference)
+	}
+
+	return value, nil
+}
+
+func newSyntheticsSecureCredentialSalt() (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(salt), nil
+}
+
+// syntheticsSecureCredentialValueHash returns the salted hash of the value, as salt:hash. The salt keeps values
+// which are easily guessed from being looked up from the state.
+func syntheticsSecureCredentialValueHash(salt string, value string) string {
+	sum := sha256.Sum256([]byte(salt + value))
+
+	return salt + ":" + hex.EncodeToString(sum[:])
+}
+
+// syntheticsSecureCredentialValueChanged reports whether the value differs from the one of the hash.
+func syntheticsSecureCredentialValueChanged(hash string, value string) bool {
+	salt, _, ok := strings.Cut(hash, ":")
+	if !ok {
+		return true
+	}
+
+	return syntheticsSecureCredentialValueHash(salt, value) != hash
+}
@@ -0,0 +1,176 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticsSecretProviders(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	path := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(path, []byte("s3cr3t\n"), 0600))
+
+	value, err := syntheticsSecretProviders["file"].Resolve(ctx, path)
+	require.NoError(t, err)
+	require.Equal(t, "s3cr3t", value)
+
+	t.Setenv("TF_TEST_SECURE_CREDENTIAL", "from env")
+	value, err = syntheticsSecretProviders["env"].Resolve(ctx, "TF_TEST_SECURE_CREDENTIAL")
+	require.NoError(t, err)
+	require.Equal(t, "from env", value)
+
+	_, err = syntheticsSecretProviders["env"].Resolve(ctx, "TF_TEST_SECURE_CREDENTIAL_UNSET")
+	require.EqualError(t, err, "the environment variable TF_TEST_SECURE_CREDENTIAL_UNSET is not set")
+
+	secrets := filepath.Join(dir, "secrets.json")
+	require.NoError(t, os.WriteFile(secrets, []byte(`{"API_TOKEN": "token#1"}`), 0600))
+
+	value, err = syntheticsSecretProviders["secrets_file"].Resolve(ctx, secrets+"#API_TOKEN")
+	require.NoError(t, err)
+	require.Equal(t, "token#1", value)
+
+	_, err = syntheticsSecretProviders["secrets_file"].Resolve(ctx, secrets+"#PASSWORD")
+	require.EqualError(t, err, "the secrets file "+secrets+" has no secret PASSWORD")
+
+	_, err = syntheticsSecretProviders["secrets_file"].Resolve(ctx, secrets)
+	require.EqualError(t, err, `invalid reference "`+secrets+`", expected path#name`)
+}
+
+func TestSyntheticsSecureCredentialValueHash(t *testing.T) {
+	t.Parallel()
+
+	hash := syntheticsSecureCredentialValueHash("0011", "s3cr3t")
+	require.Regexp(t, "^0011:[0-9a-f]{64}$", hash)
+	require.NotContains(t, hash, "s3cr3t")
+
+	require.False(t, syntheticsSecureCredentialValueChanged(hash, "s3cr3t"))
+	require.True(t, syntheticsSecureCredentialValueChanged(hash, "other"))
+	require.True(t, syntheticsSecureCredentialValueChanged("", "s3cr3t"))
+
+	// The same value has a different hash with another salt
+	require.NotEqual(t, hash, syntheticsSecureCredentialValueHash("2233", "s3cr3t"))
+}
+
+func TestResourceNewRelicSyntheticsSecureCredentialDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(path, []byte("s3cr3t"), 0600))
+
+	r := resourceNewRelicSyntheticsSecureCredential()
+	ctx := context.Background()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"key": "MY_KEY",
+		"value_source": []interface{}{
+			map[string]interface{}{"type": "file", "reference": path},
+		},
+	})
+	state := &terraform.InstanceState{
+		ID: "MY_KEY",
+		Attributes: map[string]string{
+			"id":                       "MY_KEY",
+			"key":                      "MY_KEY",
+			"value_source.#":           "1",
+			"value_source.0.type":      "file",
+			"value_source.0.reference": path,
+			"value_hash":               syntheticsSecureCredentialValueHash("0011", "s3cr3t"),
+			"last_updated":             "2026-01-02T03:04:05Z",
+		},
+	}
+
+	diff, err := r.Diff(ctx, state, config, nil)
+	require.NoError(t, err)
+	require.Nil(t, diff)
+
+	// The file changed since the credential was uploaded
+	require.NoError(t, os.WriteFile(path, []byte("n3w s3cr3t"), 0600))
+
+	diff, err = r.Diff(ctx, state, config, nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	require.True(t, diff.Attributes["value_hash"].NewComputed)
+
+	require.NoError(t, os.Remove(path))
+
+	_, err = r.Diff(ctx, state, config, nil)
+	require.ErrorContains(t, err, "reading the secure credential value from file")
+}
+
+func TestExpandSyntheticsSecureCredential(t *testing.T) {
+	t.Setenv("TF_TEST_SECURE_CREDENTIAL", "from env")
+
+	d := schema.TestResourceDataRaw(t, resourceNewRelicSyntheticsSecureCredential().Schema, map[string]interface{}{
+		"key": "my_key",
+		"value_source": []interface{}{
+			map[string]interface{}{"type": "env", "reference": "TF_TEST_SECURE_CREDENTIAL"},
+		},
+	})
+
+	sc, err := expandSyntheticsSecureCredential(context.Background(), d)
+	require.NoError(t, err)
+	require.Equal(t, "MY_KEY", sc.Key)
+	require.Equal(t, "from env", sc.Value)
+
+	require.NoError(t, setSyntheticsSecureCredentialValueHash(d, sc.Value))
+	require.False(t, syntheticsSecureCredentialValueChanged(d.Get("value_hash").(string), "from env"))
+	require.Empty(t, d.Get("value"))
+}
+
+func TestSetSyntheticsSecureCredentialValueHash_KeptForSameValue(t *testing.T) {
+	t.Setenv("TF_TEST_SECURE_CREDENTIAL", "s3cr3t")
+
+	r := resourceNewRelicSyntheticsSecureCredential()
+	ctx := context.Background()
+	hash := syntheticsSecureCredentialValueHash("0011", "s3cr3t")
+	state := &terraform.InstanceState{
+		ID: "MY_KEY",
+		Attributes: map[string]string{
+			"id":                       "MY_KEY",
+			"key":                      "MY_KEY",
+			"description":              "old",
+			"value_source.#":           "1",
+			"value_source.0.type":      "env",
+			"value_source.0.reference": "TF_TEST_SECURE_CREDENTIAL",
+			"value_hash":               hash,
+			"last_updated":             "2026-01-02T03:04:05Z",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"key":         "MY_KEY",
+		"description": "new",
+		"value_source": []interface{}{
+			map[string]interface{}{"type": "env", "reference": "TF_TEST_SECURE_CREDENTIAL"},
+		},
+	})
+
+	// Only the description changes, so the planned hash is the one of the state
+	diff, err := r.Diff(ctx, state, config, nil)
+	require.NoError(t, err)
+	require.Nil(t, diff.Attributes["value_hash"])
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+	require.NoError(t, setSyntheticsSecureCredentialValueHash(d, "s3cr3t"))
+	require.Equal(t, hash, d.Get("value_hash"))
+
+	// A new value is hashed with a new salt
+	t.Setenv("TF_TEST_SECURE_CREDENTIAL", "n3w s3cr3t")
+
+	diff, err = r.Diff(ctx, state, config, nil)
+	require.NoError(t, err)
+	require.True(t, diff.Attributes["value_hash"].NewComputed)
+
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+	require.NoError(t, setSyntheticsSecureCredentialValueHash(d, "n3w s3cr3t"))
+	require.NotEqual(t, hash, d.Get("value_hash"))
+	require.False(t, syntheticsSecureCredentialValueChanged(d.Get("value_hash").(string), "n3w s3cr3t"))
+}
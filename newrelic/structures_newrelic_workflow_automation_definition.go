@@ -0,0 +1,444 @@
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The structure of definitions, from
+// https://docs.newrelic.com/docs/workflow-automation/workflow-automation-apis/definition-schema/
+var (
+	workflowAutomationStepTypes  = []string{"action", "assign", "loop", "switch", "wait"}
+	workflowAutomationInputTypes = []string{"Boolean", "Float", "Int", "List", "Map", "String"}
+
+	workflowAutomationActionRegex          = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)+$`)
+	workflowAutomationExpressionRegex      = regexp.MustCompile(`\$\{\{(.*?)\}\}`)
+	workflowAutomationInputReferenceRegex  = regexp.MustCompile(`\.workflowInputs\.([A-Za-z_][A-Za-z0-9_]*)`)
+	workflowAutomationStepReferenceRegex   = regexp.MustCompile(`\.steps\.([A-Za-z_][A-Za-z0-9_]*)`)
+	workflowAutomationSecretReferenceRegex = regexp.MustCompile(`:secrets:([^\s}]*)`)
+)
+
+const workflowAutomationNextEnd = "end"
+
+// workflowAutomationDefinitionValidator collects the errors of a definition, so that they are all reported at once.
+type workflowAutomationDefinitionValidator struct {
+	errs   []error
+	inputs map[string]bool
+	steps  map[string]string
+
+	// The strings of the steps, whose references are checked once all the steps are known
+	references map[string][]string
+}
+
+func (v *workflowAutomationDefinitionValidator) errorf(path string, format string, args ...interface{}) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+// parseWorkflowAutomationDefinition returns the definition as generic YAML values.
+func parseWorkflowAutomationDefinition(definition string) (map[string]interface{}, error) {
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal([]byte(definition), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML definition: %w", err)
+	}
+	if parsed == nil {
+		return nil, fmt.Errorf("failed to parse YAML definition: the definition is empty")
+	}
+
+	return parsed, nil
+}
+
+// validateWorkflowAutomationDefinition validates the definition against the structure of definitions: the steps and
+// their next steps, the references to inputs, steps and secrets, and the control flow, which must reach every step and
+// always be able to end.
+func validateWorkflowAutomationDefinition(definition string) []error {
+	parsed, err := parseWorkflowAutomationDefinition(definition)
+	if err != nil {
+		return []error{err}
+	}
+
+	v := &workflowAutomationDefinitionValidator{
+		inputs:     map[string]bool{},
+		steps:      map[string]string{},
+		references: map[string][]string{},
+	}
+
+	if name, _ := parsed["name"].(string); name == "" {
+		v.errs = append(v.errs, fmt.Errorf("name field not found in YAML definition"))
+	}
+	if description, ok := parsed["description"]; ok {
+		if _, ok := description.(string); !ok {
+			v.errorf("description", "must be a string")
+		}
+	}
+
+	v.validateInputs(parsed["workflowInputs"])
+
+	if _, ok := parsed["steps"]; !ok {
+		v.errorf("steps", "at least one step is required")
+	} else {
+		v.validateSteps("steps", parsed["steps"], false)
+	}
+
+	v.validateReferences()
+
+	return v.errs
+}
+
+func (v *workflowAutomationDefinitionValidator) validateInputs(raw interface{}) {
+	if raw == nil {
+		return
+	}
+
+	inputs, ok := raw.(map[string]interface{})
+	if !ok {
+		v.errorf("workflowInputs", "must be a map of inputs by name")
+		return
+	}
+
+	for _, name := range workflowAutomationSortedKeys(inputs) {
+		v.inputs[name] = true
+		path := "workflowInputs." + name
+
+		input, ok := inputs[name].(map[string]interface{})
+		if !ok {
+			v.errorf(path, "must be a map")
+			continue
+		}
+
+		inputType, _ := input["type"].(string)
+		if !stringInSlice(workflowAutomationInputTypes, inputType) {
+			v.errorf(path, "type must be one of %s, got %q", strings.Join(workflowAutomationInputTypes, ", "), inputType)
+		}
+
+		if validations, ok := input["validations"]; ok {
+			list, ok := validations.([]interface{})
+			if !ok {
+				v.errorf(path, "validations must be a list")
+				continue
+			}
+			for i, raw := range list {
+				validation, _ := raw.(map[string]interface{})
+				if t, _ := validation["type"].(string); t == "" {
+					v.errorf(fmt.Sprintf("%s.validations[%d]", path, i), "type is required")
+				}
+			}
+		}
+	}
+}
+
+// validateSteps validates a list of steps, either the steps of the workflow or the ones of a loop, whose next steps
+// must be in the same list.
+func (v *workflowAutomationDefinitionValidator) validateSteps(path string, raw interface{}, inLoop bool) {
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		v.errorf(path, "at least one step is required")
+		return
+	}
+
+	terminals := []string{workflowAutomationNextEnd}
+	if inLoop {
+		terminals = append(terminals, "continue", "break")
+	}
+
+	// The control flow is only checked once the names and next steps of the list are valid
+	valid := true
+
+	names := make([]string, len(list))
+	paths := make([]string, len(list))
+	index := map[string]int{}
+	nexts := make([][]string, len(list))
+
+	for i, item := range list {
+		paths[i] = fmt.Sprintf("%s[%d]", path, i)
+
+		step, ok := item.(map[string]interface{})
+		if !ok {
+			v.errorf(paths[i], "must be a map")
+			valid = false
+			continue
+		}
+
+		name, _ := step["name"].(string)
+		if name == "" {
+			v.errorf(paths[i], "name is required")
+			valid = false
+		} else {
+			paths[i] = fmt.Sprintf("%s (%s)", paths[i], name)
+			if other, ok := v.steps[name]; ok {
+				v.errorf(paths[i], "the name is already used by %s", other)
+				valid = false
+			} else {
+				v.steps[name] = paths[i]
+			}
+			names[i] = name
+			index[name] = i
+		}
+
+		nexts[i] = v.validateStep(paths[i], step)
+	}
+
+	// The next steps, with the following step when a step has no next step
+	successors := make([][]string, len(list))
+	for i, next := range nexts {
+		following := workflowAutomationNextEnd
+		if i+1 < len(list) {
+			following = names[i+1]
+		}
+
+		for _, n := range next {
+			if n == "" {
+				n = following
+			} else if _, ok := index[n]; !ok && !stringInSlice(terminals, n) {
+				if other, ok := v.steps[n]; ok {
+					v.errorf(paths[i], "next references %s, which isn't in the same list of steps", other)
+				} else {
+					v.errorf(paths[i], "next references the unknown step %q, expected a step of the same list or one of %s", n, strings.Join(terminals, ", "))
+				}
+				valid = false
+				continue
+			}
+			successors[i] = append(successors[i], n)
+		}
+	}
+
+	if valid {
+		v.validateControlFlow(paths, names, index, successors, terminals)
+	}
+}
+
+// validateStep validates a step, returning its next steps, "" standing for the following step.
+func (v *workflowAutomationDefinitionValidator) validateStep(path string, step map[string]interface{}) []string {
+	// The steps of loops are collected with their own steps
+	for _, k := range workflowAutomationSortedKeys(step) {
+		if k != "for" {
+			v.references[path] = workflowAutomationStrings(step[k], v.references[path])
+		}
+	}
+
+	next := []string{""}
+	if raw, ok := step["next"]; ok {
+		n, ok := raw.(string)
+		if !ok || n == "" {
+			v.errorf(path, "next must be the name of a step")
+		} else {
+			next = []string{n}
+		}
+	}
+
+	stepType, _ := step["type"].(string)
+	switch stepType {
+	case "action":
+		action, _ := step["action"].(string)
+		if action == "" {
+			v.errorf(path, "action is required for steps of type action")
+		} else if !workflowAutomationActionRegex.MatchString(action) {
+			v.errorf(path, "action %q isn't the name of an action, e.g. newrelic.nrdb.query", action)
+		}
+
+		if raw, ok := step["version"]; !ok {
+			v.errorf(path, "version is required for steps of type action")
+		} else if !workflowAutomationValidVersion(raw) {
+			v.errorf(path, "version must be a positive integer, got %v", raw)
+		}
+
+		if inputs, ok := step["inputs"]; ok {
+			if _, ok := inputs.(map[string]interface{}); !ok {
+				v.errorf(path, "inputs must be a map")
+			}
+		}
+	case "wait":
+		raw, ok := step["seconds"]
+		if !ok {
+			v.errorf(path, "seconds is required for steps of type wait")
+		} else if seconds, ok := raw.(int); !ok || seconds < 1 {
+			v.errorf(path, "seconds must be a positive integer, got %v", raw)
+		}
+	case "switch":
+		cases, ok := step["switch"].([]interface{})
+		if !ok || len(cases) == 0 {
+			v.errorf(path, "switch must be a list of at least one condition for steps of type switch")
+			break
+		}
+
+		// The step's own next is the default when no condition matches
+		for i, raw := range cases {
+			c, _ := raw.(map[string]interface{})
+			if condition, _ := c["condition"].(string); condition == "" {
+				v.errorf(path, "switch[%d] requires a condition", i)
+			}
+			if n, _ := c["next"].(string); n == "" {
+				v.errorf(path, "switch[%d] requires a next step", i)
+			} else {
+				next = append(next, n)
+			}
+		}
+	case "loop":
+		loop, ok := step["for"].(map[string]interface{})
+		if !ok {
+			v.errorf(path, "for is required for steps of type loop")
+			break
+		}
+		if in, _ := loop["in"].(string); in == "" {
+			v.errorf(path, "for.in is required for steps of type loop")
+		}
+
+		v.references[path] = workflowAutomationStrings(loop["in"], v.references[path])
+		v.validateSteps(path+".for.steps", loop["steps"], true)
+	case "assign":
+	default:
+		v.errorf(path, "type must be one of %s, got %q", strings.Join(workflowAutomationStepTypes, ", "), stepType)
+	}
+
+	return next
+}
+
+// validateControlFlow checks that the steps are all reached from the first one, and that none of them is part of
+// a cycle which can't be exited, i.e. from which no terminal next step can be reached.
+func (v *workflowAutomationDefinitionValidator) validateControlFlow(paths []string, names []string, index map[string]int, successors [][]string, terminals []string) {
+	reached := make([]bool, len(names))
+	queue := []int{0}
+	reached[0] = true
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, n := range successors[i] {
+			if j, ok := index[n]; ok && !reached[j] {
+				reached[j] = true
+				queue = append(queue, j)
+			}
+		}
+	}
+
+	ends := make([]bool, len(names))
+	for changed := true; changed; {
+		changed = false
+		for i := range names {
+			if ends[i] {
+				continue
+			}
+			for _, n := range successors[i] {
+				if j, ok := index[n]; (ok && ends[j]) || (!ok && stringInSlice(terminals, n)) {
+					ends[i] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var endless []string
+	for i := range names {
+		switch {
+		case !reached[i]:
+			v.errorf(paths[i], "the step is unreachable, no step goes to it")
+		case !ends[i]:
+			endless = append(endless, names[i])
+		}
+	}
+
+	if len(endless) > 0 {
+		v.errs = append(v.errs, fmt.Errorf("the steps %s form a cycle which never ends, add a switch step to exit it", strings.Join(endless, ", ")))
+	}
+}
+
+// validateReferences checks the inputs, steps and secrets referenced by the expressions of the steps.
+func (v *workflowAutomationDefinitionValidator) validateReferences() {
+	paths := make([]string, 0, len(v.references))
+	for path := range v.references {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		reported := map[string]bool{}
+		report := func(format string, args ...interface{}) {
+			message := fmt.Sprintf(format, args...)
+			if !reported[message] {
+				reported[message] = true
+				v.errorf(path, "%s", message)
+			}
+		}
+
+		for _, s := range v.references[path] {
+			for _, expression := range workflowAutomationExpressionRegex.FindAllStringSubmatch(s, -1) {
+				for _, m := range workflowAutomationInputReferenceRegex.FindAllStringSubmatch(expression[1], -1) {
+					if !v.inputs[m[1]] {
+						report("references the undeclared workflow input %q", m[1])
+					}
+				}
+				for _, m := range workflowAutomationStepReferenceRegex.FindAllStringSubmatch(expression[1], -1) {
+					if _, ok := v.steps[m[1]]; !ok {
+						report("references the unknown step %q", m[1])
+					}
+				}
+				for _, m := range workflowAutomationSecretReferenceRegex.FindAllStringSubmatch(expression[1], -1) {
+					if m[1] == "" {
+						report("references a secret without a name, expected ${{ :secrets:secretName }}")
+					}
+				}
+			}
+		}
+	}
+}
+
+// workflowAutomationStrings appends the strings of a YAML value.
+func workflowAutomationStrings(raw interface{}, strs []string) []string {
+	switch value := raw.(type) {
+	case string:
+		strs = append(strs, value)
+	case []interface{}:
+		for _, item := range value {
+			strs = workflowAutomationStrings(item, strs)
+		}
+	case map[string]interface{}:
+		for _, k := range workflowAutomationSortedKeys(value) {
+			strs = workflowAutomationStrings(value[k], strs)
+		}
+	}
+
+	return strs
+}
+
+// Versions are integers, quoted or not.
+func workflowAutomationValidVersion(raw interface{}) bool {
+	switch version := raw.(type) {
+	case int:
+		return version > 0
+	case string:
+		n, err := strconv.Atoi(version)
+		return err == nil && n > 0
+	}
+
+	return false
+}
+
+func workflowAutomationSortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// normalizeWorkflowAutomationDefinition returns the definition without its formatting, comments and key order,
+// which don't change the workflow.
+func normalizeWorkflowAutomationDefinition(definition string) (string, error) {
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(definition), &parsed); err != nil {
+		return "", err
+	}
+
+	normalized, err := yaml.Marshal(parsed)
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
@@ -0,0 +1,306 @@
+//go:build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorkflowAutomationErrors(definition string) []string {
+	var messages []string
+	for _, err := range validateWorkflowAutomationDefinition(definition) {
+		messages = append(messages, err.Error())
+	}
+
+	return messages
+}
+
+func TestValidateWorkflowAutomationDefinition_Valid(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"query and wait": `
+name: test_query_workflow
+description: Simple workflow that queries NRDB and waits
+steps:
+  - name: queryNrdb
+    type: action
+    action: newrelic.nrdb.query
+    version: 1
+    inputs:
+      query: SELECT count(*) from Log LIMIT 10
+  - name: wait
+    type: wait
+    seconds: 3
+    signals: []
+    next: end
+`,
+		"inputs and secrets": `
+name: advanced-workflow
+workflowInputs:
+  accountId:
+    type: String
+  threshold:
+    type: Int
+    defaultValue: 5000
+    validations:
+      - type: minIntValue
+        errorMessage: "Minimum value must be at least 100"
+        minValue: 100
+steps:
+  - name: runQuery
+    type: action
+    action: newrelic.nrdb.query
+    version: '1'
+    inputs:
+      accountIds: "${{ .workflowInputs.accountId }}"
+      query: "SELECT count(*) FROM Log WHERE duration > ${{ .workflowInputs.threshold }}"
+  - name: postMessage
+    type: action
+    action: slack.chat.postMessage
+    version: 1
+    inputs:
+      token: "${{ :secrets:slackToken }}"
+      text: '${{ .steps.runQuery.outputs.results }}'
+`,
+		"polling with a switch": `
+name: polling
+steps:
+  - name: waitForCompletion
+    type: wait
+    seconds: 10
+  - name: hasCompleted
+    type: switch
+    switch:
+      - condition: ${{ .steps.waitForCompletion.outputs.status == "Failed" }}
+        next: displayError
+      - condition: ${{ .steps.waitForCompletion.outputs.status == "Success" }}
+        next: displaySuccess
+    next: waitForCompletion
+  - name: displayError
+    type: action
+    action: newrelic.ingest.sendLogs
+    version: 1
+    next: end
+  - name: displaySuccess
+    type: action
+    action: newrelic.ingest.sendLogs
+    version: 1
+`,
+		"loop": `
+name: loop
+steps:
+  - name: loopStep
+    type: loop
+    for:
+      in: ${{ [range(0; 5)] }}
+      steps:
+        - name: sendLogs
+          type: action
+          action: newrelic.ingest.sendLogs
+          version: '1'
+          inputs:
+            logs:
+              - message: "Iteration ${{ .steps.loopStep.loop.element }}"
+          next: continue
+`,
+	}
+
+	for name, definition := range cases {
+		definition := definition
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Empty(t, testWorkflowAutomationErrors(definition))
+		})
+	}
+}
+
+func TestValidateWorkflowAutomationDefinition_Invalid(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		definition string
+		errors     []string
+	}{
+		"invalid yaml": {
+			definition: "this is not valid yaml: [[[{",
+			errors:     []string{"failed to parse YAML definition: yaml: line 1: did not find expected node content"},
+		},
+		"no name and no steps": {
+			definition: "description: No steps",
+			errors:     []string{"name field not found in YAML definition", "steps: at least one step is required"},
+		},
+		"step structure": {
+			definition: `
+name: broken
+workflowInputs:
+  count:
+    type: Integer
+steps:
+  - name: query
+    type: action
+    action: nrdb
+    version: latest
+  - name: query
+    type: wait
+  - type: action
+    action: newrelic.nrdb.query
+    version: 1
+  - name: unknown
+    type: waitAgain
+`,
+			errors: []string{
+				`workflowInputs.count: type must be one of Boolean, Float, Int, List, Map, String, got "Integer"`,
+				`steps[0] (query): action "nrdb" isn't the name of an action, e.g. newrelic.nrdb.query`,
+				"steps[0] (query): version must be a positive integer, got latest",
+				"steps[1] (query): the name is already used by steps[0] (query)",
+				"steps[1] (query): seconds is required for steps of type wait",
+				"steps[2]: name is required",
+				`steps[3] (unknown): type must be one of action, assign, loop, switch, wait, got "waitAgain"`,
+			},
+		},
+		"zero wait": {
+			definition: `
+name: zero
+steps:
+  - name: pause
+    type: wait
+    seconds: 0
+`,
+			errors: []string{"steps[0] (pause): seconds must be a positive integer, got 0"},
+		},
+		"next references": {
+			definition: `
+name: next
+steps:
+  - name: first
+    type: wait
+    seconds: 1
+    next: thrid
+  - name: third
+    type: loop
+    for:
+      in: ${{ [1, 2] }}
+      steps:
+        - name: inner
+          type: wait
+          seconds: 1
+          next: first
+  - name: fourth
+    type: wait
+    seconds: 1
+    next: break
+`,
+			errors: []string{
+				// The steps of loops are validated with their loop step
+				"steps[1] (third).for.steps[0] (inner): next references steps[0] (first), which isn't in the same list of steps",
+				`steps[0] (first): next references the unknown step "thrid", expected a step of the same list or one of end`,
+				`steps[2] (fourth): next references the unknown step "break", expected a step of the same list or one of end`,
+			},
+		},
+		"references": {
+			definition: `
+name: references
+workflowInputs:
+  accountId:
+    type: String
+steps:
+  - name: query
+    type: action
+    action: newrelic.nrdb.query
+    version: 1
+    inputs:
+      accountIds: "${{ .workflowInputs.acountId }}"
+      query: "${{ .workflowInputs.query }} ${{ .workflowInputs.query }}"
+      token: "${{ :secrets: }}"
+  - name: post
+    type: action
+    action: slack.chat.postMessage
+    version: 1
+    inputs:
+      text: "${{ .steps.qeury.outputs.results }}"
+`,
+			errors: []string{
+				`steps[0] (query): references the undeclared workflow input "acountId"`,
+				`steps[0] (query): references the undeclared workflow input "query"`,
+				"steps[0] (query): references a secret without a name, expected ${{ :secrets:secretName }}",
+				`steps[1] (post): references the unknown step "qeury"`,
+			},
+		},
+		"unreachable and endless": {
+			definition: `
+name: cycle
+steps:
+  - name: first
+    type: wait
+    seconds: 1
+    next: second
+  - name: second
+    type: wait
+    seconds: 1
+    next: first
+  - name: orphan
+    type: wait
+    seconds: 1
+`,
+			errors: []string{
+				"steps[2] (orphan): the step is unreachable, no step goes to it",
+				"the steps first, second form a cycle which never ends, add a switch step to exit it",
+			},
+		},
+	}
+
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, c.errors, testWorkflowAutomationErrors(c.definition))
+		})
+	}
+}
+
+func TestResourceNewRelicWorkflowAutomationSuppressDefinitionDiff(t *testing.T) {
+	t.Parallel()
+
+	old := `name: formatting
+steps:
+  - name: wait
+    type: wait
+    seconds: 10
+`
+	formatted := `# Waits for ten seconds
+name: "formatting"
+steps:
+    -   type: wait
+        name: wait
+        seconds: 10
+`
+	changed := `name: formatting
+steps:
+  - name: wait
+    type: wait
+    seconds: 20
+`
+
+	require.True(t, resourceNewRelicWorkflowAutomationSuppressDefinitionDiff("definition", old, formatted, nil))
+	require.False(t, resourceNewRelicWorkflowAutomationSuppressDefinitionDiff("definition", old, changed, nil))
+	require.False(t, resourceNewRelicWorkflowAutomationSuppressDefinitionDiff("definition", "", old, nil))
+}
+
+func TestResourceNewRelicWorkflowAutomationDiff_NameMismatch(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"name":       "wrong_name",
+		"scope_id":   "1",
+		"scope_type": "ACCOUNT",
+		"definition": "name: right_name\nsteps:\n  - name: wait\n    type: wait\n    seconds: 1\n",
+	}
+
+	_, err := resourceNewRelicWorkflowAutomation().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+	require.EqualError(t, err, "name in resource configuration (wrong_name) does not match name in YAML definition (right_name). The name field in your YAML must match the resource name")
+}
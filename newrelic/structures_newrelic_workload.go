@@ -1,13 +1,66 @@
 package newrelic
 
 import (
+	"context"
+	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
 	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
 	"github.com/newrelic/newrelic-client-go/v2/pkg/workloads"
 )
 
+// The number of GUIDs looked up per entity search request.
+const workloadEntityGUIDBatchSize = 25
+
+// The entity search of newrelic-client-go does not paginate, so the entities
+// of a workload are searched with this query instead.
+const workloadEntitySearchQuery = `query(
+	$cursor: String,
+	$query: String,
+) { actor { entitySearch(
+	query: $query,
+) {
+	count
+	results(
+		cursor: $cursor,
+	) {
+		entities {
+			accountId
+			domain
+			guid
+			name
+			type
+			... on AlertableEntityOutline {
+				alertSeverity
+			}
+		}
+		nextCursor
+	}
+} } }`
+
+type workloadEntitySearchResponse struct {
+	Actor struct {
+		EntitySearch struct {
+			Count   int `json:"count"`
+			Results struct {
+				Entities []struct {
+					AccountID     int    `json:"accountId"`
+					AlertSeverity string `json:"alertSeverity"`
+					Domain        string `json:"domain"`
+					GUID          string `json:"guid"`
+					Name          string `json:"name"`
+					Type          string `json:"type"`
+				} `json:"entities"`
+				NextCursor string `json:"nextCursor"`
+			} `json:"results"`
+		} `json:"entitySearch"`
+	} `json:"actor"`
+}
+
 func expandWorkloadCreateInput(d *schema.ResourceData) workloads.WorkloadCreateInput {
 	createInput := workloads.WorkloadCreateInput{
 		Name:         d.Get("name").(string),
@@ -398,7 +451,7 @@ func listValidWorkloadRuleThresholdType() []string {
 		string(workloads.WorkloadRuleThresholdTypeTypes.PERCENTAGE),
 	}
 }
-func flattenWorkload(workload *workloads.WorkloadCollection, d *schema.ResourceData) error {
+func flattenWorkload(workload *workloads.WorkloadCollection, d *schema.ResourceData, resolved map[string]workloadEntitySearchQueryResult) error {
 	_ = d.Set("account_id", workload.Account.ID)
 	_ = d.Set("guid", workload.GUID)
 	_ = d.Set("workload_id", workload.ID)
@@ -406,7 +459,7 @@ func flattenWorkload(workload *workloads.WorkloadCollection, d *schema.ResourceD
 	_ = d.Set("permalink", workload.Permalink)
 	_ = d.Set("composite_entity_search_query", workload.EntitySearchQuery)
 	_ = d.Set("entity_guids", flattenWorkloadEntityGUIDs(workload.Entities))
-	_ = d.Set("entity_search_query", flattenWorkloadEntitySearchQueries(workload.EntitySearchQueries))
+	_ = d.Set("entity_search_query", flattenWorkloadEntitySearchQueries(workload.EntitySearchQueries, workloadEntitySearchQueryStates(d), resolved))
 	_ = d.Set("scope_account_ids", workload.ScopeAccounts.AccountIDs)
 
 	if workload.Description != "" {
@@ -464,16 +517,112 @@ func flattenWorkloadEntityGUIDs(in []workloads.WorkloadEntityRef) interface{} {
 	return out
 }
 
-func flattenWorkloadEntitySearchQueries(in []workloads.WorkloadEntitySearchQuery) interface{} {
+func flattenWorkloadEntitySearchQueries(in []workloads.WorkloadEntitySearchQuery, states map[string]workloadEntitySearchQueryState, resolved map[string]workloadEntitySearchQueryResult) interface{} {
 	out := make([]interface{}, len(in))
 	for i, e := range in {
+		key := formatEntitySearchQueryTags(e.Query)
+
 		m := make(map[string]interface{})
 		m["query"] = e.Query
+		m["max_entities"] = states[key].MaxEntities
+		m["resolve_entities"] = states[key].ResolveEntities
+
+		if r, ok := resolved[key]; ok {
+			m["resolved_entity_guids"] = r.EntityGUIDs
+			m["resolved_entity_count"] = r.Count
+		}
+
 		out[i] = m
 	}
 	return out
 }
 
+// The entities an entity search query of a workload currently matches.
+type workloadEntitySearchQueryResult struct {
+	EntityGUIDs []string
+	Count       int
+}
+
+// The arguments of an entity search query which are not stored by New Relic,
+// along with the entities it matched on the previous read.
+type workloadEntitySearchQueryState struct {
+	MaxEntities     int
+	ResolveEntities bool
+	Resolved        *workloadEntitySearchQueryResult
+}
+
+// Whether the entities matched by the query are looked up on every read.
+func (s workloadEntitySearchQueryState) resolve() bool {
+	return s.MaxEntities > 0 || s.ResolveEntities
+}
+
+// max_entities and resolve_entities are carried over from the configuration
+// (or prior state), keyed by the normalized query.
+func workloadEntitySearchQueryStates(d *schema.ResourceData) map[string]workloadEntitySearchQueryState {
+	states := map[string]workloadEntitySearchQueryState{}
+
+	if v, ok := d.GetOk("entity_search_query"); ok {
+		for _, q := range v.(*schema.Set).List() {
+			m := q.(map[string]interface{})
+
+			state := workloadEntitySearchQueryState{
+				MaxEntities:     m["max_entities"].(int),
+				ResolveEntities: m["resolve_entities"].(bool),
+			}
+			if guids, ok := m["resolved_entity_guids"].([]interface{}); ok && len(guids) > 0 {
+				state.Resolved = &workloadEntitySearchQueryResult{
+					EntityGUIDs: make([]string, len(guids)),
+					Count:       m["resolved_entity_count"].(int),
+				}
+				for i, guid := range guids {
+					state.Resolved.EntityGUIDs[i] = guid.(string)
+				}
+			}
+
+			states[formatEntitySearchQueryTags(m["query"].(string))] = state
+		}
+	}
+
+	return states
+}
+
+// Resolves the entities currently matched by the entity search queries of a
+// workload which have max_entities or resolve_entities set.
+func resolveWorkloadEntitySearchQueries(ctx context.Context, client *newrelic.NewRelic, queries []workloads.WorkloadEntitySearchQuery, states map[string]workloadEntitySearchQueryState, scopeAccountIDs []int) (map[string]workloadEntitySearchQueryResult, error) {
+	resolved := map[string]workloadEntitySearchQueryResult{}
+
+	for _, q := range queries {
+		key := formatEntitySearchQueryTags(q.Query)
+		if !states[key].resolve() {
+			continue
+		}
+
+		found, count, err := searchWorkloadEntities(ctx, client, scopeWorkloadEntitySearchQuery(q.Query, scopeAccountIDs))
+		if err != nil {
+			return nil, err
+		}
+
+		resolved[key] = workloadEntitySearchQueryResult{
+			EntityGUIDs: workloadStatusEntityGUIDs(found),
+			Count:       count,
+		}
+	}
+
+	return resolved, nil
+}
+
+// The entities resolved on the previous read, kept when they can't be resolved again.
+func previousWorkloadEntitySearchQueryResults(states map[string]workloadEntitySearchQueryState) map[string]workloadEntitySearchQueryResult {
+	resolved := map[string]workloadEntitySearchQueryResult{}
+	for key, state := range states {
+		if state.Resolved != nil {
+			resolved[key] = *state.Resolved
+		}
+	}
+
+	return resolved
+}
+
 func formatEntitySearchQueryTags(query string) string {
 	tagPattern := regexp.MustCompile(`tags(?:\.[a-zA-Z_][a-zA-Z0-9_]*)+`)
 
@@ -576,3 +725,96 @@ func flattenWorkloadAlertPolicy(in workloads.WorkloadAlertPolicyStatus) []interf
 	m["enabled"] = in.Enabled
 	return []interface{}{m}
 }
+
+// Restricts an entity search query to the given accounts, as New Relic does
+// with the entity search queries of a workload and its scope accounts.
+func scopeWorkloadEntitySearchQuery(query string, accountIDs []int) string {
+	if len(accountIDs) == 0 {
+		return query
+	}
+
+	ids := make([]string, len(accountIDs))
+	for i, id := range accountIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	return fmt.Sprintf("(%s) AND accountId IN (%s)", query, strings.Join(ids, ", "))
+}
+
+// Runs an entity search query and returns all the entities it matches, page by
+// page, along with the total number of entities matched.
+func searchWorkloadEntities(ctx context.Context, client *newrelic.NewRelic, query string) ([]workloadStatusEntity, int, error) {
+	var found []workloadStatusEntity
+	count := 0
+
+	cursor := ""
+	for {
+		resp := workloadEntitySearchResponse{}
+		vars := map[string]interface{}{
+			"query": query,
+		}
+		if cursor != "" {
+			vars["cursor"] = cursor
+		}
+
+		if err := client.NerdGraph.QueryWithResponseAndContext(ctx, workloadEntitySearchQuery, vars, &resp); err != nil {
+			return nil, 0, fmt.Errorf("error searching entities with query %q: %w", query, err)
+		}
+
+		search := resp.Actor.EntitySearch
+		count = search.Count
+
+		for _, e := range search.Results.Entities {
+			found = append(found, workloadStatusEntity{
+				GUID:          e.GUID,
+				Name:          e.Name,
+				Type:          fmt.Sprintf("%s-%s", e.Domain, e.Type),
+				AccountID:     e.AccountID,
+				AlertSeverity: e.AlertSeverity,
+			})
+		}
+
+		cursor = search.Results.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+
+	return found, count, nil
+}
+
+// Resolves a set of entity GUIDs and entity search queries into entities, along
+// with their current alert severity. Entities found by the queries are limited
+// to the given scope accounts.
+func resolveWorkloadStatusEntities(ctx context.Context, client *newrelic.NewRelic, guids []interface{}, queries []string, scopeAccountIDs []int) ([]workloadStatusEntity, error) {
+	var byGUID []workloadStatusEntity
+
+	for start := 0; start < len(guids); start += workloadEntityGUIDBatchSize {
+		end := start + workloadEntityGUIDBatchSize
+		if end > len(guids) {
+			end = len(guids)
+		}
+
+		quoted := make([]string, 0, end-start)
+		for _, guid := range guids[start:end] {
+			quoted = append(quoted, fmt.Sprintf("'%s'", escapeSingleQuote(guid.(string))))
+		}
+
+		found, _, err := searchWorkloadEntities(ctx, client, fmt.Sprintf("id IN (%s)", strings.Join(quoted, ", ")))
+		if err != nil {
+			return nil, err
+		}
+		byGUID = append(byGUID, found...)
+	}
+
+	var byQuery []workloadStatusEntity
+	for _, query := range queries {
+		found, _, err := searchWorkloadEntities(ctx, client, scopeWorkloadEntitySearchQuery(query, scopeAccountIDs))
+		if err != nil {
+			return nil, err
+		}
+		byQuery = append(byQuery, found...)
+	}
+
+	return mergeWorkloadStatusEntities(byGUID, byQuery), nil
+}
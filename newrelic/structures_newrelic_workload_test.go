@@ -3,7 +3,15 @@
 package newrelic
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/workloads"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFormatEntitySearchQueryTags(t *testing.T) {
@@ -143,3 +151,82 @@ func TestFormatEntitySearchQueryTags(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchWorkloadEntities_Paginates(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]string{
+		"": `{"data": {"actor": {"entitySearch": {"count": 3, "results": {"nextCursor": "page2", "entities": [
+			{"guid": "a", "name": "A", "domain": "APM", "type": "APPLICATION", "accountId": 1, "alertSeverity": "CRITICAL"},
+			{"guid": "b", "name": "B", "domain": "APM", "type": "APPLICATION", "accountId": 1}
+		]}}}}}`,
+		"page2": `{"data": {"actor": {"entitySearch": {"count": 3, "results": {"nextCursor": null, "entities": [
+			{"guid": "c", "name": "C", "domain": "BROWSER", "type": "APPLICATION", "accountId": 2, "alertSeverity": "NOT_ALERTING"}
+		]}}}}}`,
+	}
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		queries = append(queries, body.Variables["query"].(string))
+		cursor, _ := body.Variables["cursor"].(string)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(pages[cursor]))
+	}))
+	defer server.Close()
+
+	client, err := newrelic.New(newrelic.ConfigPersonalAPIKey("NRAK-TEST"), newrelic.ConfigNerdGraphBaseURL(server.URL))
+	require.NoError(t, err)
+
+	found, count, err := searchWorkloadEntities(context.Background(), client, "domain IN ('APM', 'BROWSER')")
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	require.Equal(t, []string{"domain IN ('APM', 'BROWSER')", "domain IN ('APM', 'BROWSER')"}, queries)
+	require.Equal(t, []workloadStatusEntity{
+		{GUID: "a", Name: "A", Type: "APM-APPLICATION", AccountID: 1, AlertSeverity: "CRITICAL"},
+		{GUID: "b", Name: "B", Type: "APM-APPLICATION", AccountID: 1},
+		{GUID: "c", Name: "C", Type: "BROWSER-APPLICATION", AccountID: 2, AlertSeverity: "NOT_ALERTING"},
+	}, found)
+}
+
+func TestResolveWorkloadEntitySearchQueries_OnlyOptedIn(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		queries = append(queries, body.Variables["query"].(string))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"actor": {"entitySearch": {"count": 1, "results": {"entities": [{"guid": "a", "domain": "APM", "type": "APPLICATION"}]}}}}}`))
+	}))
+	defer server.Close()
+
+	client, err := newrelic.New(newrelic.ConfigPersonalAPIKey("NRAK-TEST"), newrelic.ConfigNerdGraphBaseURL(server.URL))
+	require.NoError(t, err)
+
+	states := map[string]workloadEntitySearchQueryState{
+		"domain = 'APM'":     {MaxEntities: 5},
+		"domain = 'BROWSER'": {ResolveEntities: true},
+	}
+	resolved, err := resolveWorkloadEntitySearchQueries(context.Background(), client, []workloads.WorkloadEntitySearchQuery{
+		{Query: "domain = 'APM'"},
+		{Query: "domain = 'BROWSER'"},
+		{Query: "domain = 'INFRA'"},
+	}, states, []int{1})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"(domain = 'APM') AND accountId IN (1)", "(domain = 'BROWSER') AND accountId IN (1)"}, queries)
+	require.Equal(t, map[string]workloadEntitySearchQueryResult{
+		"domain = 'APM'":     {EntityGUIDs: []string{"a"}, Count: 1},
+		"domain = 'BROWSER'": {EntityGUIDs: []string{"a"}, Count: 1},
+	}, resolved)
+}